@@ -0,0 +1,91 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"backend/configs"
+	"backend/internal/clients"
+	"backend/internal/gateway/gcs"
+	firestoreRepo "backend/internal/repository/firestore"
+	"backend/internal/server"
+)
+
+// backfill-placeholders computes the BlurHash, LQIP and dominant color of images stored before
+// placeholders were generated on upload, and refreshes the galery events using them
+//
+// Usage: go run ./cmd/backfill-placeholders [-overwrite]
+func main() {
+	overwrite := flag.Bool("overwrite", false, "recompute placeholders of images that already have them")
+	flag.Parse()
+
+	// Stop between images on interrupt, the run can be resumed since finished images are skipped
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Println("Starting image placeholder backfill...")
+	log.Printf("Environment: %s", getEnv("RUNTIME_ENV", "development"))
+
+	// Initialize dependencies
+	config := initializeConfig()
+	gcsGateway := initializeGCSGateway(ctx, config)
+	defer gcsGateway.Close()
+	db := initializeDatabase(ctx, config)
+	defer db.Close()
+	srv := server.NewServer(db, clients.NewObjectClient(gcsGateway), clients.NewEventsClient())
+
+	report, err := srv.BackfillImagePlaceholders(ctx, *overwrite)
+
+	log.Printf("Images scanned: %d", report.Scanned)
+	log.Printf("Images updated: %d", report.Updated)
+	log.Printf("Images skipped: %d", report.Skipped)
+	log.Printf("Galery events updated: %d", report.GaleryUpdated)
+	if len(report.Failed) > 0 {
+		log.Printf("Images failed: %d %v", len(report.Failed), report.Failed)
+	}
+
+	if err != nil {
+		log.Fatalf("Backfill stopped: %v", err)
+	}
+
+	log.Println("Backfill finished")
+}
+
+// initializeConfig initializes and returns the configuration service
+func initializeConfig() configs.ConfigClient {
+	config, err := configs.NewConfigService()
+	if err != nil {
+		log.Fatalf("Failed to initialize configuration: %v", err)
+	}
+	return config
+}
+
+// initializeGCSGateway initializes and returns the GCS gateway
+func initializeGCSGateway(ctx context.Context, config configs.ConfigClient) *gcs.GCSGateway {
+	gcsGateway, err := gcs.NewGCSGatewayWithProvider(ctx, config)
+	if err != nil {
+		log.Fatalf("Failed to initialize GCS gateway: %v", err)
+	}
+	return gcsGateway
+}
+
+// initializeDatabase initializes and returns the Firestore database repository
+func initializeDatabase(ctx context.Context, config configs.ConfigClient) *firestoreRepo.DBRepository {
+	db, err := firestoreRepo.NewDBRepositoryWithProvider(ctx, config)
+	if err != nil {
+		log.Fatalf("Failed to initialize Firestore database: %v", err)
+	}
+	return db
+}
+
+// getEnv retrieves an environment variable or returns a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
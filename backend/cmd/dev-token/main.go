@@ -0,0 +1,55 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"backend/configs"
+	authPlatform "backend/internal/platform/auth"
+)
+
+// dev-token signs an ID token accepted by a server running the hmac verifier, so the editing routes
+// can be called locally without a Firebase account. The secret and issuer are read from the auth section
+// of the development config
+//
+// Usage: go run ./cmd/dev-token -uid <uid> [-role admin|editor|photographer|viewer] [-email <email>] [-ttl 1h]
+func main() {
+	uid := flag.String("uid", "", "uid of the user, the subject of the token")
+	roleName := flag.String("role", "", "role of the user: admin, editor, photographer or viewer")
+	email := flag.String("email", "", "email of the user, credited in the created_by fields")
+	ttl := flag.Duration("ttl", time.Hour, "lifetime of the token")
+	flag.Parse()
+
+	if *uid == "" {
+		log.Fatal("Usage: dev-token -uid <uid> [-role admin|editor|photographer|viewer] [-email <email>] [-ttl 1h]")
+	}
+	var role authPlatform.Role
+	if *roleName != "" {
+		var err error
+		if role, err = authPlatform.ParseRole(*roleName); err != nil {
+			log.Fatal(err)
+		}
+	}
+
+	config, err := configs.NewConfigService()
+	if err != nil {
+		log.Fatalf("Failed to initialize configuration: %v", err)
+	}
+	authConfig, err := config.GetAuthConfig()
+	if err != nil {
+		log.Fatalf("Failed to get auth config: %v", err)
+	}
+	if authConfig.Verifier != "hmac" {
+		log.Fatalf("The %s verifier is configured, dev-token only signs tokens for the hmac verifier", authConfig.Verifier)
+	}
+
+	principal := authPlatform.Principal{UID: *uid, Email: *email, Role: role}
+	token, err := authPlatform.SignHMACToken([]byte(authConfig.Secret), authConfig.Issuer, principal, *ttl)
+	if err != nil {
+		log.Fatalf("Failed to sign token: %v", err)
+	}
+	fmt.Fprintln(os.Stdout, token)
+}
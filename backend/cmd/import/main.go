@@ -0,0 +1,128 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"backend/configs"
+	"backend/internal/clients"
+	"backend/internal/entities"
+	"backend/internal/gateway/gcs"
+	"backend/internal/platform/importer"
+	firestoreRepo "backend/internal/repository/firestore"
+	"backend/internal/server"
+)
+
+// import upserts the timeline entries or texts of CSV, YAML or Markdown files, like POST /api/v1/import
+// The format of each file is read from its extension. Files are imported one after the other, a file with
+// invalid records is skipped as a whole and its errors are listed by line
+//
+// Usage: go run ./cmd/import -kind timeline|texts [-dry-run] files...
+func main() {
+	kind := flag.String("kind", "", "kind of the imported records: timeline or texts")
+	dryRun := flag.Bool("dry-run", false, "validate the files and report the changes without writing them")
+	flag.Parse()
+
+	if !entities.ImportKind(*kind).Valid() || flag.NArg() == 0 {
+		log.Fatal("Usage: import -kind timeline|texts [-dry-run] files...")
+	}
+
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Println("Starting import...")
+	log.Printf("Environment: %s", getEnv("RUNTIME_ENV", "development"))
+
+	// Initialize dependencies
+	config := initializeConfig()
+	gcsGateway := initializeGCSGateway(ctx, config)
+	defer gcsGateway.Close()
+	db := initializeDatabase(ctx, config)
+	defer db.Close()
+	srv := server.NewServer(db, clients.NewObjectClient(gcsGateway), clients.NewEventsClient())
+
+	failed := 0
+	for _, path := range flag.Args() {
+		if err := importFile(ctx, srv, entities.ImportKind(*kind), path, *dryRun); err != nil {
+			log.Printf("%s: %v", path, err)
+			failed++
+		}
+	}
+
+	if failed > 0 {
+		log.Fatalf("Import finished, %d of %d files were not imported", failed, flag.NArg())
+	}
+	if *dryRun {
+		log.Println("Dry run finished, nothing was written")
+		return
+	}
+	log.Println("Import finished")
+}
+
+// importFile imports a single file and logs its report
+func importFile(ctx context.Context, srv server.Server, kind entities.ImportKind, path string, dryRun bool) error {
+	format, err := importer.FormatFromFilename(path)
+	if err != nil {
+		return err
+	}
+
+	file, err := os.Open(path)
+	if err != nil {
+		return err
+	}
+	defer file.Close()
+
+	report, err := srv.Import(ctx, kind, format, file, dryRun)
+	for _, e := range report.Errors {
+		if e.Field != "" {
+			log.Printf("%s:%d: %s: %s", path, e.Line, e.Field, e.Message)
+		} else {
+			log.Printf("%s:%d: %s", path, e.Line, e.Message)
+		}
+	}
+	if err != nil {
+		return err
+	}
+
+	log.Printf("%s: %d records, %d created, %d updated", path, report.Records, report.Created, report.Updated)
+	return nil
+}
+
+// initializeConfig initializes and returns the configuration service
+func initializeConfig() configs.ConfigClient {
+	config, err := configs.NewConfigService()
+	if err != nil {
+		log.Fatalf("Failed to initialize configuration: %v", err)
+	}
+	return config
+}
+
+// initializeGCSGateway initializes and returns the GCS gateway
+func initializeGCSGateway(ctx context.Context, config configs.ConfigClient) *gcs.GCSGateway {
+	gcsGateway, err := gcs.NewGCSGatewayWithProvider(ctx, config)
+	if err != nil {
+		log.Fatalf("Failed to initialize GCS gateway: %v", err)
+	}
+	return gcsGateway
+}
+
+// initializeDatabase initializes and returns the Firestore database repository
+func initializeDatabase(ctx context.Context, config configs.ConfigClient) *firestoreRepo.DBRepository {
+	db, err := firestoreRepo.NewDBRepositoryWithProvider(ctx, config)
+	if err != nil {
+		log.Fatalf("Failed to initialize Firestore database: %v", err)
+	}
+	return db
+}
+
+// getEnv retrieves an environment variable or returns a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
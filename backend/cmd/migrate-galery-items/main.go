@@ -0,0 +1,50 @@
+package main
+
+import (
+	"context"
+	"log"
+	"os"
+
+	"backend/configs"
+	firestoreRepo "backend/internal/repository/firestore"
+)
+
+// migrate-galery-items stores the items of galery events created before items existed,
+// building them from the old image_ids and image_urls arrays
+// Events are upgraded on read anyway, the migration makes the stored documents match.
+// Migrated items have no placeholders, go run ./cmd/backfill-placeholders copies them from the images
+//
+// Usage: go run ./cmd/migrate-galery-items
+func main() {
+	ctx := context.Background()
+
+	log.Println("Starting galery items migration...")
+	log.Printf("Environment: %s", getEnv("RUNTIME_ENV", "development"))
+
+	config, err := configs.NewConfigService()
+	if err != nil {
+		log.Fatalf("Failed to initialize configuration: %v", err)
+	}
+
+	db, err := firestoreRepo.NewDBRepositoryWithProvider(ctx, config)
+	if err != nil {
+		log.Fatalf("Failed to initialize Firestore database: %v", err)
+	}
+	defer db.Close()
+
+	migrated, err := db.MigrateGaleryEventItems(ctx)
+	log.Printf("Galery events migrated: %d", migrated)
+	if err != nil {
+		log.Fatalf("Migration stopped: %v", err)
+	}
+
+	log.Println("Migration finished")
+}
+
+// getEnv retrieves an environment variable or returns a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -33,6 +33,8 @@ func main() {
 	// Initialize dependencies
 	config := initializeConfig()
 	eventsClient := initializeEventsClient()
+	eventSources := initializeEventSources(config)
+	notifications, webhooks := initializeWebhooks(config)
 	gcsGateway := initializeGCSGateway(ctx, config)
 	defer gcsGateway.Close()
 	objectStore := initializeObjectStore(gcsGateway)
@@ -40,8 +42,21 @@ func main() {
 	defer db.Close()
 	fbApp := initializeFirebaseApp(ctx, config)
 	authClient := initializeAuthClient(ctx, fbApp)
-	srv := initializeServer(db, objectStore, eventsClient)
-	handler := initializeRouter(ctx, srv, authClient, config)
+	srv := initializeServer(db, objectStore, eventsClient, eventSources, webhooks, notifications, clients.NewUserDirectory(authClient), config)
+	verifier := initializeTokenVerifier(ctx, authClient, config)
+	handler := initializeRouter(ctx, srv, verifier, config)
+
+	// Resume unfinished jobs before accepting requests, then run jobs in the background
+	jobsCtx, stopJobs := context.WithCancel(ctx)
+	jobsDone := startJobs(jobsCtx, srv)
+
+	// Watch the Grupy events for changes when someone is listening
+	watchCtx, stopWatch := context.WithCancel(ctx)
+	watchDone := startEventWatch(watchCtx, srv, len(webhooks), time.Duration(notifications.PollIntervalMinutes)*time.Minute)
+
+	// Create draft timeline entries from finished Grupy events when enabled
+	syncCtx, stopSync := context.WithCancel(ctx)
+	syncDone := startTimelineSync(syncCtx, srv, config.GetTimelineSyncConfig())
 
 	// Configure HTTP server
 	httpSrv := &http.Server{
@@ -58,9 +73,16 @@ func main() {
 		log.Printf("API available at: http://localhost:%s/api/v1", port)
 		log.Println("Available endpoints:")
 		log.Println("  GET  /api/v1/events")
+		log.Println("  GET  /api/v1/events/{id}/sessions")
+		log.Println("  GET  /api/v1/events.ics")
+		log.Println("  GET  /api/v1/feeds/{timeline,galery,events}.{atom,rss}")
 		log.Println("  GET  /api/v1/texts")
 		log.Println("  GET  /api/v1/images/{id}")
 		log.Println("  GET  /api/v1/timelineentries")
+		log.Println("  GET  /api/v1/jobs/{id} (requires authentication)")
+		log.Println("  GET  /api/v1/notifications/dead_letters (requires the admin role)")
+		log.Println("  PUT  /api/v1/users/{uid}/role (requires the admin role)")
+		log.Println("  GET  /api/v1/audit (requires the admin role)")
 		log.Println("  GET  /authorized (requires authentication)")
 		log.Println("  GET  /health")
 
@@ -84,6 +106,32 @@ func main() {
 		log.Fatalf("Server forced to shutdown: %v", err)
 	}
 
+	// Running jobs are rolled back and queued again for the next start
+	stopJobs()
+	select {
+	case <-jobsDone:
+		log.Println("Background jobs stopped")
+	case <-shutdownCtx.Done():
+		log.Println("Background jobs did not stop in time, they will be failed on next start")
+	}
+
+	// A poll in progress stops retrying, its undelivered notifications are dead-lettered
+	stopWatch()
+	select {
+	case <-watchDone:
+		log.Println("Event watch stopped")
+	case <-shutdownCtx.Done():
+		log.Println("Event watch did not stop in time")
+	}
+
+	stopSync()
+	select {
+	case <-syncDone:
+		log.Println("Timeline sync stopped")
+	case <-shutdownCtx.Done():
+		log.Println("Timeline sync did not stop in time")
+	}
+
 	log.Println("Server stopped gracefully")
 }
 
@@ -101,6 +149,55 @@ func initializeEventsClient() server.GrupyEventsPort {
 	return clients.NewEventsClient()
 }
 
+// initializeEventSources initializes the partner event sources merged with the Grupy events
+// A misconfigured source is skipped, the other sources and the Grupy events are still served
+func initializeEventSources(config configs.ConfigClient) []server.EventSourcePort {
+	sourceConfigs, err := config.GetEventSources()
+	if err != nil {
+		log.Printf("Failed to read event sources, serving Grupy events only: %v", err)
+		return nil
+	}
+
+	var sources []server.EventSourcePort
+	for _, cfg := range sourceConfigs {
+		if cfg.Name == "grupy" {
+			log.Printf("Skipping event source %s: the name is reserved for the Grupy events", cfg.Name)
+			continue
+		}
+
+		source, err := clients.NewEventSource(cfg)
+		if err != nil {
+			log.Printf("Skipping event source %s: %v", cfg.Name, err)
+			continue
+		}
+		sources = append(sources, source)
+		log.Printf("Event source %s (%s) enabled", cfg.Name, cfg.Type)
+	}
+	return sources
+}
+
+// initializeWebhooks initializes the webhooks notified of changes of the Grupy events
+// A misconfigured webhook is skipped, the other ones are still notified
+func initializeWebhooks(config configs.ConfigClient) (configs.NotificationsConfig, []server.WebhookPort) {
+	notifications, err := config.GetNotificationsConfig()
+	if err != nil {
+		log.Printf("Failed to read notifications config, event notifications disabled: %v", err)
+		return notifications, nil
+	}
+
+	var webhooks []server.WebhookPort
+	for _, cfg := range notifications.Webhooks {
+		hook, err := clients.NewWebhook(cfg)
+		if err != nil {
+			log.Printf("Skipping webhook %s: %v", cfg.Name, err)
+			continue
+		}
+		webhooks = append(webhooks, hook)
+		log.Printf("Webhook %s (%s) enabled", cfg.Name, cfg.Type)
+	}
+	return notifications, webhooks
+}
+
 // initializeGCSGateway initializes and returns the GCS gateway
 func initializeGCSGateway(ctx context.Context, config configs.ConfigClient) *gcs.GCSGateway {
 	log.Println("Initializing GCS...")
@@ -184,13 +281,161 @@ func initializeAuthClient(ctx context.Context, app *firebaseApp.App) *firebaseAu
 	return authClient
 }
 
+// initializeTokenVerifier returns the verifier of the ID tokens selected by the auth section of the config
+func initializeTokenVerifier(ctx context.Context, authClient *firebaseAuth.Client, config configs.ConfigClient) authPlatform.TokenVerifier {
+	authConfig, err := config.GetAuthConfig()
+	if err != nil {
+		log.Fatalf("Failed to get auth config: %v", err)
+	}
+	log.Printf("Token verifier: %s", authConfig.Verifier)
+
+	var verifier authPlatform.TokenVerifier
+	switch authConfig.Verifier {
+	case "oidc":
+		verifier, err = authPlatform.NewOIDCVerifier(ctx, authConfig.Issuer, authConfig.Audience, authConfig.JWKSURL)
+		log.Printf("  Issuer: %s", authConfig.Issuer)
+	case "hmac":
+		verifier, err = authPlatform.NewHMACVerifier([]byte(authConfig.Secret), authConfig.Issuer)
+		log.Println("  Tokens signed with the shared secret are trusted, see go run ./cmd/dev-token")
+	case "fake":
+		verifier = authPlatform.NewFakeVerifier()
+		log.Println("  WARNING: unsigned fake tokens are trusted, never deploy this config")
+	default:
+		verifier = clients.NewFirebaseTokenVerifier(authClient)
+	}
+	if err != nil {
+		log.Fatalf("Failed to initialize token verifier: %v", err)
+	}
+	return verifier
+}
+
 // initializeServer initializes and returns the server
-func initializeServer(db server.DBPort, objectStore server.ObjectStorePort, eventsClient server.GrupyEventsPort) server.Server {
-	return server.NewServer(db, objectStore, eventsClient)
+func initializeServer(db server.DBPort, objectStore server.ObjectStorePort, eventsClient server.GrupyEventsPort, eventSources []server.EventSourcePort, webhooks []server.WebhookPort, notifications configs.NotificationsConfig, users server.UserDirectoryPort, config configs.ConfigClient) server.Server {
+	uploadsConfig := config.GetUploadsConfig()
+	log.Printf("Galery upload concurrency: %d", uploadsConfig.GaleryConcurrency)
+
+	jobsConfig := config.GetJobsConfig()
+	log.Printf("Background job workers: %d", jobsConfig.Workers)
+
+	return server.NewServer(db, objectStore, eventsClient,
+		server.WithGaleryUploadConcurrency(uploadsConfig.GaleryConcurrency),
+		server.WithJobWorkers(jobsConfig.Workers),
+		server.WithSiteURL(config.GetSiteConfig().BaseURL),
+		server.WithEventSources(eventSources...),
+		server.WithWebhooks(webhooks...),
+		server.WithWebhookMaxAttempts(notifications.MaxAttempts),
+		server.WithUserDirectory(users),
+	)
+}
+
+// startJobs resumes the jobs left unfinished by the previous run and starts the job workers
+// The returned channel is closed once the workers have stopped
+func startJobs(ctx context.Context, srv server.Server) <-chan struct{} {
+	resumed, failed, err := srv.ResumeJobs(ctx)
+	if err != nil {
+		log.Printf("Failed to resume background jobs: %v", err)
+	} else {
+		log.Printf("Background jobs resumed: %d, failed after interruption: %d", resumed, failed)
+	}
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		srv.RunJobs(ctx)
+	}()
+	return done
+}
+
+// eventWatchLease is the lease held by the instance polling the Grupy events
+const eventWatchLease = "event_watch"
+
+// startEventWatch polls the Grupy events every interval and notifies the webhooks of their changes
+// Nothing is polled without webhooks. Only the instance holding the event watch lease polls, it renews
+// the lease on every tick and another instance takes over once a tick was missed.
+// The returned channel is closed once the watch has stopped
+func startEventWatch(ctx context.Context, srv server.Server, webhooks int, interval time.Duration) <-chan struct{} {
+	done := make(chan struct{})
+	if webhooks == 0 {
+		log.Println("Event watch disabled: no webhooks configured")
+		close(done)
+		return done
+	}
+
+	log.Printf("Event watch polling every %s for %d webhooks", interval, webhooks)
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			held, err := srv.AcquireLease(ctx, eventWatchLease, 2*interval)
+			if err != nil {
+				log.Printf("Failed to acquire the event watch lease: %v", err)
+			} else if held {
+				notifications, err := srv.PollEvents(ctx)
+				if err != nil {
+					log.Printf("Failed to poll Grupy events: %v", err)
+				} else if len(notifications) > 0 {
+					log.Printf("Event watch sent %d notifications", len(notifications))
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return done
+}
+
+// timelineSyncLease is the lease held by the instance syncing the timeline
+const timelineSyncLease = "timeline_sync"
+
+// startTimelineSync syncs the timeline with the finished Grupy events every configured interval
+// Nothing is synced unless enabled. Like the event watch, only the instance holding the timeline sync lease syncs.
+// The returned channel is closed once the sync has stopped
+func startTimelineSync(ctx context.Context, srv server.Server, cfg configs.TimelineSyncConfig) <-chan struct{} {
+	done := make(chan struct{})
+	if !cfg.Enabled {
+		log.Println("Timeline sync disabled")
+		close(done)
+		return done
+	}
+
+	interval := time.Duration(cfg.IntervalHours) * time.Hour
+	log.Printf("Timeline sync running every %s", interval)
+	go func() {
+		defer close(done)
+
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			held, err := srv.AcquireLease(ctx, timelineSyncLease, 2*interval)
+			if err != nil {
+				log.Printf("Failed to acquire the timeline sync lease: %v", err)
+			} else if held {
+				report, err := srv.SyncTimelineFromEvents(ctx, false)
+				if err != nil {
+					log.Printf("Failed to sync timeline: %v", err)
+				} else if report.Created > 0 || report.Updated > 0 {
+					log.Printf("Timeline sync created %d and updated %d draft entries", report.Created, report.Updated)
+				}
+			}
+
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+	return done
 }
 
 // initializeRouter initializes and returns the HTTP router
-func initializeRouter(ctx context.Context, srv server.Server, authClient *firebaseAuth.Client, config configs.ConfigClient) http.Handler {
+func initializeRouter(ctx context.Context, srv server.Server, verifier authPlatform.TokenVerifier, config configs.ConfigClient) http.Handler {
 	logger := log.New(os.Stdout, "", log.LstdFlags)
 
 	routerOpts := httpHandler.RouterOptions{
@@ -200,16 +445,16 @@ func initializeRouter(ctx context.Context, srv server.Server, authClient *fireba
 	authLevel := config.GetAuthLevel()
 	log.Printf("[AUTH LEVEL] Configured Auth Level: %s", authLevel.String())
 
-	if authClient != nil {
+	if verifier != nil {
 		routerOpts.AuthConfig = authPlatform.AuthConfig{
-			Client: authClient,
-			Level:  authLevel,
+			Verifier: verifier,
+			Level:    authLevel,
 		}
 		log.Println("Authentication enabled for protected endpoints")
 	} else {
 		routerOpts.AuthConfig = authPlatform.AuthConfig{
-			Client: nil,
-			Level:  authPlatform.AuthOptional,
+			Verifier: nil,
+			Level:    authPlatform.AuthOptional,
 		}
 		log.Println("Authentication disabled or unavailable")
 	}
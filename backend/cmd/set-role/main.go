@@ -0,0 +1,62 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+
+	"backend/configs"
+	"backend/internal/clients"
+	authPlatform "backend/internal/platform/auth"
+)
+
+// set-role assigns a role to a Firebase account, like PUT /api/v1/users/{uid}/role. It bootstraps the
+// first admin, who then manages the other roles through the API. The role applies once the user
+// refreshes their token, e.g. by signing in again
+//
+// Usage: go run ./cmd/set-role -uid <uid> -role admin|editor|photographer|viewer
+func main() {
+	uid := flag.String("uid", "", "Firebase uid of the account")
+	roleName := flag.String("role", "", "role to assign: admin, editor, photographer or viewer")
+	flag.Parse()
+
+	role, err := authPlatform.ParseRole(*roleName)
+	if *uid == "" || err != nil {
+		log.Fatal("Usage: set-role -uid <uid> -role admin|editor|photographer|viewer")
+	}
+
+	ctx := context.Background()
+	log.Printf("Environment: %s", getEnv("RUNTIME_ENV", "development"))
+
+	config, err := configs.NewConfigService()
+	if err != nil {
+		log.Fatalf("Failed to initialize configuration: %v", err)
+	}
+	fbConfig, err := config.GetFirebaseConfigWithJSONBytes()
+	if err != nil {
+		log.Fatalf("Failed to get Firebase config: %v", err)
+	}
+	app, err := clients.NewFirebaseAppClient(ctx, fbConfig)
+	if err != nil {
+		log.Fatalf("Failed to initialize Firebase App: %v", err)
+	}
+	authClient, err := clients.NewFirebaseAuthClient(ctx, app)
+	if err != nil {
+		log.Fatalf("Failed to initialize Firebase Auth client: %v", err)
+	}
+
+	user, err := clients.NewUserDirectory(authClient).SetUserRole(ctx, *uid, string(role))
+	if err != nil {
+		log.Fatalf("Failed to set role: %v", err)
+	}
+	log.Printf("%s (%s) is now %s", user.UID, user.Email, user.Role)
+}
+
+// getEnv retrieves an environment variable or returns a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
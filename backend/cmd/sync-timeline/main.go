@@ -0,0 +1,94 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"log"
+	"os"
+	"os/signal"
+	"syscall"
+
+	"backend/configs"
+	"backend/internal/clients"
+	"backend/internal/gateway/gcs"
+	firestoreRepo "backend/internal/repository/firestore"
+	"backend/internal/server"
+)
+
+// sync-timeline creates a draft timeline entry for every finished public Grupy event and refreshes
+// the generated entries no editor has changed. Editors publish the drafts they want on the History page
+//
+// Usage: go run ./cmd/sync-timeline [-dry-run]
+func main() {
+	dryRun := flag.Bool("dry-run", false, "report the changes without writing them")
+	flag.Parse()
+
+	// Stop between events on interrupt, the run can be repeated since synced events are recognized
+	ctx, stop := signal.NotifyContext(context.Background(), syscall.SIGINT, syscall.SIGTERM)
+	defer stop()
+
+	log.Println("Starting timeline sync...")
+	log.Printf("Environment: %s", getEnv("RUNTIME_ENV", "development"))
+
+	// Initialize dependencies
+	config := initializeConfig()
+	gcsGateway := initializeGCSGateway(ctx, config)
+	defer gcsGateway.Close()
+	db := initializeDatabase(ctx, config)
+	defer db.Close()
+	srv := server.NewServer(db, clients.NewObjectClient(gcsGateway), clients.NewEventsClient())
+
+	report, err := srv.SyncTimelineFromEvents(ctx, *dryRun)
+
+	log.Printf("Past events scanned: %d", report.Scanned)
+	log.Printf("Draft entries created: %d", report.Created)
+	log.Printf("Entries updated: %d", report.Updated)
+	log.Printf("Entries changed by editors, left alone: %d", report.Edited)
+	log.Printf("Entries unchanged: %d", report.Unchanged)
+	log.Printf("Entries deleted by editors, not created again: %d", report.Dismissed)
+
+	if err != nil {
+		log.Fatalf("Sync stopped: %v", err)
+	}
+
+	if *dryRun {
+		log.Println("Dry run finished, nothing was written")
+		return
+	}
+	log.Println("Sync finished")
+}
+
+// initializeConfig initializes and returns the configuration service
+func initializeConfig() configs.ConfigClient {
+	config, err := configs.NewConfigService()
+	if err != nil {
+		log.Fatalf("Failed to initialize configuration: %v", err)
+	}
+	return config
+}
+
+// initializeGCSGateway initializes and returns the GCS gateway
+func initializeGCSGateway(ctx context.Context, config configs.ConfigClient) *gcs.GCSGateway {
+	gcsGateway, err := gcs.NewGCSGatewayWithProvider(ctx, config)
+	if err != nil {
+		log.Fatalf("Failed to initialize GCS gateway: %v", err)
+	}
+	return gcsGateway
+}
+
+// initializeDatabase initializes and returns the Firestore database repository
+func initializeDatabase(ctx context.Context, config configs.ConfigClient) *firestoreRepo.DBRepository {
+	db, err := firestoreRepo.NewDBRepositoryWithProvider(ctx, config)
+	if err != nil {
+		log.Fatalf("Failed to initialize Firestore database: %v", err)
+	}
+	return db
+}
+
+// getEnv retrieves an environment variable or returns a default value
+func getEnv(key, defaultValue string) string {
+	if value := os.Getenv(key); value != "" {
+		return value
+	}
+	return defaultValue
+}
@@ -14,6 +14,15 @@ import (
 
 const (
 	_authLevelEnvVar = "AUTH_LEVEL"
+
+	_defaultGaleryUploadConcurrency = 4
+	_defaultJobWorkers              = 2
+	_defaultSiteBaseURL             = "https://site-grupy.vercel.app"
+	_defaultEventSourceTimeout      = 5  // seconds
+	_defaultEventPollInterval       = 15 // minutes
+	_defaultWebhookMaxAttempts      = 5
+	_defaultTimelineSyncInterval    = 24 // hours
+	_defaultTokenVerifier           = "firebase"
 )
 
 // FirebaseConfig holds Firebase-specific configuration loaded from YAML
@@ -25,10 +34,17 @@ type FirebaseConfig struct {
 
 // Collections holds the names of Firestore collections loaded from YAML
 type Collections struct {
-	Texts        string `yaml:"texts"`
-	Images       string `yaml:"images"`
-	Timelines    string `yaml:"timelines"`
-	GaleryEvents string `yaml:"galery_events"`
+	Texts              string `yaml:"texts"`
+	Images             string `yaml:"images"`
+	Timelines          string `yaml:"timelines"`
+	GaleryEvents       string `yaml:"galery_events"`
+	Jobs               string `yaml:"jobs"`
+	EventWatch         string `yaml:"event_watch"`
+	DeadLetters        string `yaml:"dead_letters"`
+	AuditLog           string `yaml:"audit_log"`
+	Leases             string `yaml:"leases"`
+	Uploads            string `yaml:"uploads"`
+	TimelineTombstones string `yaml:"timeline_tombstones"`
 }
 
 // GCSConfig holds Google Cloud Storage configuration
@@ -42,6 +58,66 @@ type GCSConfig struct {
 	BasePath               string `yaml:"base_path"` // Base path within bucket for all objects (e.g., "images", "media/uploads")
 }
 
+// UploadsConfig holds the tuning of image uploads
+type UploadsConfig struct {
+	GaleryConcurrency int `yaml:"galery_concurrency"` // Images of a galery event uploaded at the same time
+}
+
+// JobsConfig holds the tuning of background jobs
+type JobsConfig struct {
+	Workers int `yaml:"workers"` // Jobs run at the same time
+}
+
+// SiteConfig holds the public site served by the frontend
+type SiteConfig struct {
+	BaseURL string `yaml:"base_url"` // Root URL of the site, without trailing slash
+}
+
+// EventSourceConfig describes a partner source of community events merged into the events list
+// The Grupy Open Event instance is always the first source, it isn't configured here
+type EventSourceConfig struct {
+	Name           string `yaml:"name"`            // Unique name, stored in the source of its events
+	Type           string `yaml:"type"`            // "openevent", "ical" or "yaml"
+	URL            string `yaml:"url"`             // API root of an Open Event server or address of an iCal feed
+	WebURL         string `yaml:"web_url"`         // Open Event only: site hosting the event pages, e.g. https://eventos.python.org.br
+	Path           string `yaml:"path"`            // YAML only: file listing the events, relative to the working directory
+	TimeoutSeconds int    `yaml:"timeout_seconds"` // Time allowed to read the source
+}
+
+// NotificationsConfig holds the watcher of Grupy events and the webhooks it notifies
+type NotificationsConfig struct {
+	PollIntervalMinutes int             `yaml:"poll_interval_minutes"` // Time between two reads of the Grupy events
+	MaxAttempts         int             `yaml:"max_attempts"`          // Deliveries of a notification before it is dead-lettered
+	Webhooks            []WebhookConfig `yaml:"webhooks"`
+}
+
+// WebhookConfig describes a receiver of event notifications
+// URL, Secret and Token may reference environment variables, e.g. ${DISCORD_WEBHOOK_URL}
+type WebhookConfig struct {
+	Name   string `yaml:"name"`    // Unique name, stored in its dead letters
+	Type   string `yaml:"type"`    // "json", "discord" or "telegram"
+	URL    string `yaml:"url"`     // Address receiving the notifications, Telegram defaults to the Bot API
+	Secret string `yaml:"secret"`  // JSON only: key signing the payloads, unsigned when empty
+	Token  string `yaml:"token"`   // Telegram only: bot token
+	ChatID string `yaml:"chat_id"` // Telegram only: chat receiving the messages
+}
+
+// TimelineSyncConfig holds the scheduled creation of draft timeline entries from past Grupy events
+type TimelineSyncConfig struct {
+	Enabled       bool `yaml:"enabled"`        // Opt-in, the sync can also be run with cmd/sync-timeline
+	IntervalHours int  `yaml:"interval_hours"` // Time between two syncs
+}
+
+// AuthConfig selects the verifier of the ID tokens sent by the editors
+// The level of authentication is read from AUTH_LEVEL. Secret may reference environment variables, e.g. ${AUTH_HMAC_SECRET}
+type AuthConfig struct {
+	Verifier string `yaml:"verifier"` // "firebase", "oidc", "hmac" or "fake". hmac and fake are refused outside development
+	Issuer   string `yaml:"issuer"`   // OIDC: expected iss claim, root of the discovery document. HMAC: expected iss claim, optional
+	Audience string `yaml:"audience"` // OIDC only: expected aud claim, usually the client id of the site
+	JWKSURL  string `yaml:"jwks_url"` // OIDC only: signing keys, discovered from the issuer when empty
+	Secret   string `yaml:"secret"`   // HMAC only: key signing the tokens, at least 32 bytes
+}
+
 // ConfigClient provides access to configuration values
 type ConfigClient interface {
 	// GetConfig returns a config value by key (supports nested keys with dots, e.g., "collections.texts")
@@ -69,6 +145,27 @@ type ConfigClient interface {
 
 	//GetAuthLevel gets configured auth level
 	GetAuthLevel() auth.AuthLevel
+
+	// GetAuthConfig returns the token verifier configuration, Firebase when the section is missing
+	GetAuthConfig() (AuthConfig, error)
+
+	// GetUploadsConfig returns the image uploads configuration, missing values fall back to defaults
+	GetUploadsConfig() UploadsConfig
+
+	// GetJobsConfig returns the background jobs configuration, missing values fall back to defaults
+	GetJobsConfig() JobsConfig
+
+	// GetSiteConfig returns the public site configuration, missing values fall back to defaults
+	GetSiteConfig() SiteConfig
+
+	// GetEventSources returns the partner event sources, none when the section is missing
+	GetEventSources() ([]EventSourceConfig, error)
+
+	// GetNotificationsConfig returns the event notifications configuration, without webhooks when the section is missing
+	GetNotificationsConfig() (NotificationsConfig, error)
+
+	// GetTimelineSyncConfig returns the timeline sync configuration, disabled when the section is missing
+	GetTimelineSyncConfig() TimelineSyncConfig
 }
 
 type configService struct {
@@ -139,6 +236,44 @@ func (s *configService) GetAuthLevel() auth.AuthLevel {
 	return auth.AuthLevelFromString(authLevel)
 }
 
+// GetAuthConfig returns the token verifier configuration
+// The auth section is optional. OIDC needs an issuer and an audience, HMAC a secret; both HMAC and fake
+// tokens can be made by anyone holding the config, so they are only accepted in development
+func (s *configService) GetAuthConfig() (AuthConfig, error) {
+	config := AuthConfig{
+		Verifier: _defaultTokenVerifier,
+	}
+
+	if _, err := s.GetConfig("auth"); err != nil {
+		return config, nil
+	}
+	if err := s.UnmarshalKey("auth", &config); err != nil {
+		return AuthConfig{}, err
+	}
+	if config.Verifier == "" {
+		config.Verifier = _defaultTokenVerifier
+	}
+	config.Secret = os.ExpandEnv(config.Secret)
+
+	switch config.Verifier {
+	case "firebase":
+	case "oidc":
+		if config.Issuer == "" || config.Audience == "" {
+			return AuthConfig{}, errors.New("oidc verifier needs an issuer and an audience")
+		}
+	case "hmac", "fake":
+		if s.env != "development" {
+			return AuthConfig{}, fmt.Errorf("%s verifier is only allowed in development, not in %s", config.Verifier, s.env)
+		}
+		if config.Verifier == "hmac" && config.Secret == "" {
+			return AuthConfig{}, errors.New("hmac verifier needs a secret")
+		}
+	default:
+		return AuthConfig{}, fmt.Errorf("unknown token verifier %q, expected firebase, oidc, hmac or fake", config.Verifier)
+	}
+	return config, nil
+}
+
 // GetConfig returns a config value by key path
 // Supports nested keys using dot notation (e.g., "collections.texts")
 func (s *configService) GetConfig(cfgName string) (any, error) {
@@ -332,3 +467,150 @@ func (s *configService) GetGCSConfig() (GCSConfig, error) {
 
 	return config, nil
 }
+
+// GetUploadsConfig returns the image uploads configuration
+// The uploads section is optional, missing or invalid values fall back to defaults
+func (s *configService) GetUploadsConfig() UploadsConfig {
+	config := UploadsConfig{
+		GaleryConcurrency: _defaultGaleryUploadConcurrency,
+	}
+
+	if err := s.UnmarshalKey("uploads", &config); err != nil {
+		return UploadsConfig{GaleryConcurrency: _defaultGaleryUploadConcurrency}
+	}
+
+	if config.GaleryConcurrency <= 0 {
+		config.GaleryConcurrency = _defaultGaleryUploadConcurrency
+	}
+	return config
+}
+
+// GetJobsConfig returns the background jobs configuration
+// The jobs section is optional, missing or invalid values fall back to defaults
+func (s *configService) GetJobsConfig() JobsConfig {
+	config := JobsConfig{
+		Workers: _defaultJobWorkers,
+	}
+
+	if err := s.UnmarshalKey("jobs", &config); err != nil {
+		return JobsConfig{Workers: _defaultJobWorkers}
+	}
+
+	if config.Workers <= 0 {
+		config.Workers = _defaultJobWorkers
+	}
+	return config
+}
+
+// GetSiteConfig returns the public site configuration
+// The site section is optional, a missing base URL falls back to the production site
+func (s *configService) GetSiteConfig() SiteConfig {
+	config := SiteConfig{
+		BaseURL: _defaultSiteBaseURL,
+	}
+
+	if err := s.UnmarshalKey("site", &config); err != nil {
+		return SiteConfig{BaseURL: _defaultSiteBaseURL}
+	}
+
+	config.BaseURL = strings.TrimSuffix(config.BaseURL, "/")
+	if config.BaseURL == "" {
+		config.BaseURL = _defaultSiteBaseURL
+	}
+	return config
+}
+
+// GetEventSources returns the partner event sources
+// The event_sources section is optional. Sources must have a unique name and a type,
+// a missing timeout falls back to the default
+func (s *configService) GetEventSources() ([]EventSourceConfig, error) {
+	if _, err := s.GetConfig("event_sources"); err != nil {
+		return nil, nil
+	}
+
+	var sources []EventSourceConfig
+	if err := s.UnmarshalKey("event_sources", &sources); err != nil {
+		return nil, err
+	}
+
+	names := make(map[string]bool, len(sources))
+	for i := range sources {
+		source := &sources[i]
+		if source.Name == "" || source.Type == "" {
+			return nil, fmt.Errorf("event source %d must have a name and a type", i)
+		}
+		if names[source.Name] {
+			return nil, fmt.Errorf("event source %q is configured twice", source.Name)
+		}
+		names[source.Name] = true
+
+		if source.TimeoutSeconds <= 0 {
+			source.TimeoutSeconds = _defaultEventSourceTimeout
+		}
+	}
+	return sources, nil
+}
+
+// GetNotificationsConfig returns the event notifications configuration
+// The notifications section is optional. Webhooks must have a unique name and a known type,
+// missing intervals and attempts fall back to defaults
+func (s *configService) GetNotificationsConfig() (NotificationsConfig, error) {
+	config := NotificationsConfig{
+		PollIntervalMinutes: _defaultEventPollInterval,
+		MaxAttempts:         _defaultWebhookMaxAttempts,
+	}
+
+	if _, err := s.GetConfig("notifications"); err != nil {
+		return config, nil
+	}
+	if err := s.UnmarshalKey("notifications", &config); err != nil {
+		return NotificationsConfig{}, err
+	}
+
+	if config.PollIntervalMinutes <= 0 {
+		config.PollIntervalMinutes = _defaultEventPollInterval
+	}
+	if config.MaxAttempts <= 0 {
+		config.MaxAttempts = _defaultWebhookMaxAttempts
+	}
+
+	names := make(map[string]bool, len(config.Webhooks))
+	for i := range config.Webhooks {
+		hook := &config.Webhooks[i]
+		if hook.Name == "" {
+			return NotificationsConfig{}, fmt.Errorf("webhook %d must have a name", i)
+		}
+		if names[hook.Name] {
+			return NotificationsConfig{}, fmt.Errorf("webhook %q is configured twice", hook.Name)
+		}
+		names[hook.Name] = true
+
+		switch hook.Type {
+		case "json", "discord", "telegram":
+		default:
+			return NotificationsConfig{}, fmt.Errorf("webhook %s has unknown type %q", hook.Name, hook.Type)
+		}
+
+		hook.URL = os.ExpandEnv(hook.URL)
+		hook.Secret = os.ExpandEnv(hook.Secret)
+		hook.Token = os.ExpandEnv(hook.Token)
+	}
+	return config, nil
+}
+
+// GetTimelineSyncConfig returns the timeline sync configuration
+// The timeline_sync section is optional, the sync is disabled unless enabled explicitly
+func (s *configService) GetTimelineSyncConfig() TimelineSyncConfig {
+	config := TimelineSyncConfig{
+		IntervalHours: _defaultTimelineSyncInterval,
+	}
+
+	if err := s.UnmarshalKey("timeline_sync", &config); err != nil {
+		return TimelineSyncConfig{IntervalHours: _defaultTimelineSyncInterval}
+	}
+
+	if config.IntervalHours <= 0 {
+		config.IntervalHours = _defaultTimelineSyncInterval
+	}
+	return config
+}
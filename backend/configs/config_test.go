@@ -132,3 +132,207 @@ func TestUnmarshalKey_NilTarget(t *testing.T) {
 	assert.Error(t, err, "Should return error for nil target")
 	assert.Contains(t, err.Error(), "cannot be nil")
 }
+
+// TestGetUploadsConfig tests reading the uploads section
+func TestGetUploadsConfig(t *testing.T) {
+	os.Unsetenv("RUNTIME_ENV")
+
+	config, err := NewConfigService()
+	require.NoError(t, err)
+
+	uploads := config.GetUploadsConfig()
+	assert.Equal(t, 4, uploads.GaleryConcurrency)
+}
+
+// TestGetUploadsConfig_Defaults tests the defaults used when the uploads section is missing
+func TestGetUploadsConfig_Defaults(t *testing.T) {
+	config := &configService{data: map[string]any{}}
+
+	uploads := config.GetUploadsConfig()
+	assert.Equal(t, _defaultGaleryUploadConcurrency, uploads.GaleryConcurrency)
+}
+
+// TestGetJobsConfig tests reading the jobs section
+func TestGetJobsConfig(t *testing.T) {
+	os.Unsetenv("RUNTIME_ENV")
+
+	config, err := NewConfigService()
+	require.NoError(t, err)
+
+	jobs := config.GetJobsConfig()
+	assert.Equal(t, 2, jobs.Workers)
+}
+
+// TestGetJobsConfig_Defaults tests the defaults used when the jobs section is missing
+func TestGetJobsConfig_Defaults(t *testing.T) {
+	config := &configService{data: map[string]any{}}
+
+	jobs := config.GetJobsConfig()
+	assert.Equal(t, _defaultJobWorkers, jobs.Workers)
+}
+
+// TestGetSiteConfig tests reading the site section
+func TestGetSiteConfig(t *testing.T) {
+	os.Unsetenv("RUNTIME_ENV")
+
+	config, err := NewConfigService()
+	require.NoError(t, err)
+
+	site := config.GetSiteConfig()
+	assert.Equal(t, "http://localhost:5173", site.BaseURL)
+}
+
+// TestGetSiteConfig_Defaults tests the defaults used when the site section is missing
+func TestGetSiteConfig_Defaults(t *testing.T) {
+	config := &configService{data: map[string]any{}}
+	assert.Equal(t, _defaultSiteBaseURL, config.GetSiteConfig().BaseURL)
+
+	config = &configService{data: map[string]any{"site": map[string]any{"base_url": "https://example.com/"}}}
+	assert.Equal(t, "https://example.com", config.GetSiteConfig().BaseURL)
+}
+
+// TestGetEventSources tests reading the event_sources section
+func TestGetEventSources(t *testing.T) {
+	config := &configService{data: map[string]any{}}
+	sources, err := config.GetEventSources()
+	require.NoError(t, err)
+	assert.Empty(t, sources, "The section is optional")
+
+	config = &configService{data: map[string]any{"event_sources": []any{
+		map[string]any{"name": "pybr", "type": "ical", "url": "https://python.org.br/events.ics"},
+		map[string]any{"name": "partners", "type": "yaml", "path": "partners.yaml", "timeout_seconds": 2},
+	}}}
+	sources, err = config.GetEventSources()
+	require.NoError(t, err)
+	require.Len(t, sources, 2)
+	assert.Equal(t, EventSourceConfig{Name: "pybr", Type: "ical", URL: "https://python.org.br/events.ics", TimeoutSeconds: _defaultEventSourceTimeout}, sources[0])
+	assert.Equal(t, "partners.yaml", sources[1].Path)
+	assert.Equal(t, 2, sources[1].TimeoutSeconds)
+}
+
+// TestGetEventSources_Invalid tests that sources without a name or type and duplicated names are rejected
+func TestGetEventSources_Invalid(t *testing.T) {
+	for name, sources := range map[string][]any{
+		"missing name": {map[string]any{"type": "ical"}},
+		"missing type": {map[string]any{"name": "pybr"}},
+		"duplicated":   {map[string]any{"name": "pybr", "type": "ical"}, map[string]any{"name": "pybr", "type": "yaml"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := &configService{data: map[string]any{"event_sources": sources}}
+			_, err := config.GetEventSources()
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestGetNotificationsConfig tests reading the notifications section
+func TestGetNotificationsConfig(t *testing.T) {
+	config := &configService{data: map[string]any{}}
+	notifications, err := config.GetNotificationsConfig()
+	require.NoError(t, err)
+	assert.Equal(t, _defaultEventPollInterval, notifications.PollIntervalMinutes)
+	assert.Equal(t, _defaultWebhookMaxAttempts, notifications.MaxAttempts)
+	assert.Empty(t, notifications.Webhooks, "The section is optional")
+
+	t.Setenv("TEST_WEBHOOK_SECRET", "s3cret")
+	config = &configService{data: map[string]any{"notifications": map[string]any{
+		"poll_interval_minutes": 5,
+		"webhooks": []any{
+			map[string]any{"name": "site", "type": "json", "url": "https://example.com/hook", "secret": "${TEST_WEBHOOK_SECRET}"},
+			map[string]any{"name": "telegram", "type": "telegram", "token": "abc", "chat_id": "-100"},
+		},
+	}}}
+	notifications, err = config.GetNotificationsConfig()
+	require.NoError(t, err)
+	assert.Equal(t, 5, notifications.PollIntervalMinutes)
+	assert.Equal(t, _defaultWebhookMaxAttempts, notifications.MaxAttempts)
+	require.Len(t, notifications.Webhooks, 2)
+	assert.Equal(t, "s3cret", notifications.Webhooks[0].Secret, "Environment variables are expanded")
+	assert.Equal(t, "-100", notifications.Webhooks[1].ChatID)
+}
+
+// TestGetNotificationsConfig_Invalid tests that webhooks without a name, of unknown type and duplicated names are rejected
+func TestGetNotificationsConfig_Invalid(t *testing.T) {
+	for name, webhooks := range map[string][]any{
+		"missing name": {map[string]any{"type": "json"}},
+		"unknown type": {map[string]any{"name": "slack", "type": "slack"}},
+		"duplicated":   {map[string]any{"name": "site", "type": "json"}, map[string]any{"name": "site", "type": "discord"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := &configService{data: map[string]any{"notifications": map[string]any{"webhooks": webhooks}}}
+			_, err := config.GetNotificationsConfig()
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestGetTimelineSyncConfig tests reading the timeline_sync section
+func TestGetTimelineSyncConfig(t *testing.T) {
+	os.Unsetenv("RUNTIME_ENV")
+
+	config, err := NewConfigService()
+	require.NoError(t, err)
+
+	sync := config.GetTimelineSyncConfig()
+	assert.False(t, sync.Enabled)
+	assert.Equal(t, 24, sync.IntervalHours)
+}
+
+// TestGetTimelineSyncConfig_Defaults tests the defaults used when the timeline_sync section is missing
+func TestGetTimelineSyncConfig_Defaults(t *testing.T) {
+	config := &configService{data: map[string]any{}}
+
+	sync := config.GetTimelineSyncConfig()
+	assert.False(t, sync.Enabled, "The sync is opt-in")
+	assert.Equal(t, _defaultTimelineSyncInterval, sync.IntervalHours)
+
+	config = &configService{data: map[string]any{"timeline_sync": map[string]any{"enabled": true}}}
+	sync = config.GetTimelineSyncConfig()
+	assert.True(t, sync.Enabled)
+	assert.Equal(t, _defaultTimelineSyncInterval, sync.IntervalHours)
+}
+
+// TestGetAuthConfig tests reading the auth section
+func TestGetAuthConfig(t *testing.T) {
+	config := &configService{data: map[string]any{}, env: "production"}
+	authConfig, err := config.GetAuthConfig()
+	require.NoError(t, err)
+	assert.Equal(t, _defaultTokenVerifier, authConfig.Verifier, "The section is optional")
+
+	config = &configService{data: map[string]any{"auth": map[string]any{
+		"verifier": "oidc", "issuer": "https://accounts.google.com", "audience": "grupy-site",
+	}}, env: "production"}
+	authConfig, err = config.GetAuthConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "oidc", authConfig.Verifier)
+	assert.Equal(t, "grupy-site", authConfig.Audience)
+	assert.Empty(t, authConfig.JWKSURL, "The keys are discovered from the issuer")
+
+	t.Setenv("TEST_AUTH_HMAC_SECRET", "0123456789abcdef0123456789abcdef")
+	config = &configService{data: map[string]any{"auth": map[string]any{
+		"verifier": "hmac", "secret": "${TEST_AUTH_HMAC_SECRET}",
+	}}, env: "development"}
+	authConfig, err = config.GetAuthConfig()
+	require.NoError(t, err)
+	assert.Equal(t, "0123456789abcdef0123456789abcdef", authConfig.Secret, "Environment variables are expanded")
+}
+
+// TestGetAuthConfig_Invalid tests that unknown verifiers, incomplete ones and development verifiers outside development are rejected
+func TestGetAuthConfig_Invalid(t *testing.T) {
+	for name, test := range map[string]struct {
+		env  string
+		auth map[string]any
+	}{
+		"unknown verifier":    {"development", map[string]any{"verifier": "saml"}},
+		"oidc without issuer": {"production", map[string]any{"verifier": "oidc", "audience": "grupy-site"}},
+		"hmac without secret": {"development", map[string]any{"verifier": "hmac"}},
+		"hmac in production":  {"production", map[string]any{"verifier": "hmac", "secret": "0123456789abcdef0123456789abcdef"}},
+		"fake in prod-local":  {"prod-local", map[string]any{"verifier": "fake"}},
+	} {
+		t.Run(name, func(t *testing.T) {
+			config := &configService{data: map[string]any{"auth": test.auth}, env: test.env}
+			_, err := config.GetAuthConfig()
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,81 @@
+package integration_tests
+
+import (
+	"backend/internal/http/mapper"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAudit_TextLifecycle(t *testing.T) {
+	token := RequireAdminToken(t)
+
+	// Create, update and delete a text
+	resp := MakeRequest(t, "POST", "/texts", mapper.CreateTextRequest{
+		Slug:    GenerateUniqueSlug("audit-test"),
+		Content: "Conteúdo original",
+	})
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var created mapper.TextResponse
+	ParseJSONResponse(t, resp, &created)
+
+	resp = MakeRequest(t, "PUT", "/texts/"+created.ID, mapper.UpdateTextRequest{Content: "Conteúdo revisado"})
+	AssertStatusCode(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	resp = MakeRequest(t, "DELETE", "/texts/"+created.ID, nil)
+	resp.Body.Close()
+
+	// The audit log lists the three mutations, newest first
+	resp = MakeAuthenticatedRequest(t, "GET", "/audit?entity_type=text&entity_id="+created.ID, token, nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var page mapper.AuditPageResponse
+	ParseJSONResponse(t, resp, &page)
+
+	require.Len(t, page.Data, 3)
+	assert.Equal(t, "delete", page.Data[0].Action)
+	assert.Equal(t, "update", page.Data[1].Action)
+	assert.Equal(t, "create", page.Data[2].Action)
+	assert.False(t, page.Meta.HasMore)
+
+	update := page.Data[1]
+	assert.Equal(t, created.ID, update.EntityID)
+	assert.NotEmpty(t, update.RequestID)
+	assert.Equal(t, mapper.AuditChangeResponse{Before: "Conteúdo original", After: "Conteúdo revisado"}, update.Changes["content"])
+	assert.NotContains(t, update.Changes, "updatedAt", "Timestamps are not listed as changes")
+}
+
+func TestAudit_Pagination(t *testing.T) {
+	token := RequireAdminToken(t)
+
+	resp := MakeAuthenticatedRequest(t, "GET", "/audit?limit=1&page=2", token, nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var page mapper.AuditPageResponse
+	ParseJSONResponse(t, resp, &page)
+
+	assert.LessOrEqual(t, len(page.Data), 1)
+	assert.Equal(t, 2, page.Meta.Page)
+	assert.Equal(t, 1, page.Meta.Limit)
+}
+
+func TestAudit_InvalidQuery(t *testing.T) {
+	token := RequireAdminToken(t)
+
+	for _, query := range []string{
+		"action=rename",
+		"entity_type=page",
+		"limit=0",
+		"page=first",
+		"from=yesterday",
+		"from=2025-03-14&to=2025-03-01",
+	} {
+		resp := MakeAuthenticatedRequest(t, "GET", "/audit?"+query, token, nil)
+		AssertStatusCode(t, resp, http.StatusBadRequest)
+		resp.Body.Close()
+	}
+}
@@ -44,4 +44,35 @@ func TestAuthOptional_PostTextWithoutAuth(t *testing.T) {
 		resp := MakeRequest(t, "DELETE", "/texts/"+created.ID, nil)
 		resp.Body.Close()
 	}()
-}
\ No newline at end of file
+}
+func TestAuthOptional_PostTextWithTokenIsCredited(t *testing.T) {
+	config, err := configs.NewConfigService()
+	require.NoError(t, err, "Failed to initialize config service")
+
+	authConfig, err := config.GetAuthConfig()
+	require.NoError(t, err, "Failed to get auth config")
+	if config.GetAuthLevel() != auth.AuthOptional || authConfig.Verifier != "fake" {
+		t.Skip("Requires AUTH_LEVEL=optional and the fake token verifier")
+	}
+	token := auth.FakeToken("integration-optional-editor", auth.RoleEditor)
+
+	// The token is optional, but when one is sent it is verified and its user credited
+	createReq := mapper.CreateTextRequest{Slug: GenerateUniqueSlug("auth-optional-test"), Content: "Test"}
+	resp := MakeAuthenticatedRequest(t, "POST", "/texts", token, createReq)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var created mapper.TextResponse
+	ParseJSONResponse(t, resp, &created)
+
+	defer func() {
+		resp := MakeAuthenticatedRequest(t, "DELETE", "/texts/"+created.ID, token, nil)
+		resp.Body.Close()
+	}()
+
+	assert.Equal(t, "integration-optional-editor", created.CreatedBy)
+	assert.Equal(t, "integration-optional-editor", created.LastUpdatedBy)
+
+	resp = MakeAuthenticatedRequest(t, "PUT", "/texts/"+created.ID, "not-a-valid-token", mapper.UpdateTextRequest{Content: "Revisado"})
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusUnauthorized)
+}
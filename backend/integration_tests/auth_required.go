@@ -1 +0,0 @@
-package integration_tests
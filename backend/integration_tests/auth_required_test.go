@@ -0,0 +1,88 @@
+package integration_tests
+
+import (
+	"backend/configs"
+	"backend/internal/http/mapper"
+	"backend/internal/platform/auth"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// requireFakeAuth skips the test unless the server requires authentication and verifies fake tokens,
+// i.e. it runs with AUTH_LEVEL=required and the fake verifier in the auth section of development.yaml
+func requireFakeAuth(t *testing.T) {
+	config, err := configs.NewConfigService()
+	require.NoError(t, err, "Failed to initialize config service")
+
+	authConfig, err := config.GetAuthConfig()
+	require.NoError(t, err, "Failed to get auth config")
+	if config.GetAuthLevel() != auth.AuthRequired || authConfig.Verifier != "fake" {
+		t.Skip("Requires AUTH_LEVEL=required and the fake token verifier")
+	}
+}
+
+func TestAuthRequired_PostTextWithoutToken(t *testing.T) {
+	requireFakeAuth(t)
+
+	resp := MakeRequest(t, "POST", "/texts", mapper.CreateTextRequest{Slug: GenerateUniqueSlug("auth-required-test"), Content: "Test"})
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusUnauthorized)
+}
+
+func TestAuthRequired_PostTextWithInvalidToken(t *testing.T) {
+	requireFakeAuth(t)
+
+	createReq := mapper.CreateTextRequest{Slug: GenerateUniqueSlug("auth-required-test"), Content: "Test"}
+	resp := MakeAuthenticatedRequest(t, "POST", "/texts", "not-a-valid-token", createReq)
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusUnauthorized)
+}
+
+func TestAuthRequired_PostTextWithoutPermission(t *testing.T) {
+	requireFakeAuth(t)
+
+	createReq := mapper.CreateTextRequest{Slug: GenerateUniqueSlug("auth-required-test"), Content: "Test"}
+	resp := MakeAuthenticatedRequest(t, "POST", "/texts", auth.FakeToken("integration-photographer", auth.RolePhotographer), createReq)
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusForbidden)
+}
+
+func TestAuthRequired_PostTextAsEditor(t *testing.T) {
+	requireFakeAuth(t)
+	token := auth.FakeToken("integration-editor", auth.RoleEditor)
+
+	slug := GenerateUniqueSlug("auth-required-test")
+	createReq := mapper.CreateTextRequest{
+		Slug:    slug,
+		Content: "Test content for auth required test",
+	}
+
+	resp := MakeAuthenticatedRequest(t, "POST", "/texts", token, createReq)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var created mapper.TextResponse
+	ParseJSONResponse(t, resp, &created)
+
+	defer func() {
+		resp := MakeAuthenticatedRequest(t, "DELETE", "/texts/"+created.ID, token, nil)
+		resp.Body.Close()
+	}()
+
+	assert.Equal(t, slug, created.Slug)
+	assert.Equal(t, "integration-editor", created.CreatedBy, "Fake tokens have no email, the uid is credited")
+}
+
+func TestAuthRequired_AdminRoutes(t *testing.T) {
+	requireFakeAuth(t)
+
+	resp := MakeAuthenticatedRequest(t, "GET", "/audit", auth.FakeToken("integration-editor", auth.RoleEditor), nil)
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusForbidden)
+
+	resp = MakeAuthenticatedRequest(t, "GET", "/audit?limit=1", auth.FakeToken("integration-admin", auth.RoleAdmin), nil)
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusOK)
+}
@@ -9,6 +9,9 @@ import (
 	"testing"
 	"time"
 
+	"backend/configs"
+	"backend/internal/platform/auth"
+
 	"github.com/stretchr/testify/require"
 )
 
@@ -28,6 +31,26 @@ var HTTPClient = &http.Client{
 
 // MakeRequest makes an HTTP request and returns the response
 func MakeRequest(t *testing.T, method, path string, body interface{}) *http.Response {
+	return MakeAuthenticatedRequest(t, method, path, "", body)
+}
+
+// RequireAdminToken skips the test unless the server verifies fake tokens and returns the token of an admin
+// The admin routes refuse anonymous callers whatever AUTH_LEVEL is set
+func RequireAdminToken(t *testing.T) string {
+	config, err := configs.NewConfigService()
+	require.NoError(t, err, "Failed to initialize config service")
+
+	authConfig, err := config.GetAuthConfig()
+	require.NoError(t, err, "Failed to get auth config")
+	if authConfig.Verifier != "fake" {
+		t.Skip("Requires the fake token verifier")
+	}
+	return auth.FakeToken("integration-admin", auth.RoleAdmin)
+}
+
+// MakeAuthenticatedRequest makes an HTTP request carrying idToken as Bearer token and returns the response
+// The request is anonymous when idToken is empty
+func MakeAuthenticatedRequest(t *testing.T, method, path, idToken string, body interface{}) *http.Response {
 	var reqBody io.Reader
 	if body != nil {
 		jsonData, err := json.Marshal(body)
@@ -42,6 +65,9 @@ func MakeRequest(t *testing.T, method, path string, body interface{}) *http.Resp
 	if body != nil {
 		req.Header.Set("Content-Type", "application/json")
 	}
+	if idToken != "" {
+		req.Header.Set("Authorization", "Bearer "+idToken)
+	}
 
 	resp, err := HTTPClient.Do(req)
 	require.NoError(t, err, "Failed to make request to %s %s", method, path)
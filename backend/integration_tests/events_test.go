@@ -1,15 +1,20 @@
 package integration_tests
 
 import (
+	"io"
 	"net/http"
+	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // EventResponse represents the API response for an event
 type EventResponse struct {
 	ID                string `json:"id"`
+	Source            string `json:"source"`
 	Identifier        string `json:"identifier"`
 	Name              string `json:"name"`
 	Description       string `json:"description"`
@@ -28,13 +33,23 @@ type EventResponse struct {
 	Link              string `json:"link"`
 }
 
+// EventListResponse represents the API response for a page of events
+type EventListResponse struct {
+	Data []EventResponse `json:"data"`
+	Meta struct {
+		Count         int      `json:"count"`
+		FailedSources []string `json:"failed_sources"`
+	} `json:"meta"`
+}
+
 func TestEvents_GetAll(t *testing.T) {
 	// Get all events (default parameters)
 	resp := MakeRequest(t, "GET", "/events", nil)
 	AssertStatusCode(t, resp, http.StatusOK)
 
-	var events []EventResponse
-	ParseJSONResponse(t, resp, &events)
+	var list EventListResponse
+	ParseJSONResponse(t, resp, &list)
+	events := list.Data
 
 	// We can't assert exact count since it's external data,
 	// but we can verify the response structure
@@ -53,8 +68,9 @@ func TestEvents_WithLimitParameter(t *testing.T) {
 	resp := MakeRequest(t, "GET", "/events?limit=5", nil)
 	AssertStatusCode(t, resp, http.StatusOK)
 
-	var events []EventResponse
-	ParseJSONResponse(t, resp, &events)
+	var list EventListResponse
+	ParseJSONResponse(t, resp, &list)
+	events := list.Data
 
 	// Should have at most 5 events
 	assert.LessOrEqual(t, len(events), 5, "Should respect limit parameter")
@@ -65,8 +81,9 @@ func TestEvents_WithCombinedParameters(t *testing.T) {
 	resp := MakeRequest(t, "GET", "/events?limit=3", nil)
 	AssertStatusCode(t, resp, http.StatusOK)
 
-	var events []EventResponse
-	ParseJSONResponse(t, resp, &events)
+	var list EventListResponse
+	ParseJSONResponse(t, resp, &list)
+	events := list.Data
 
 	// Should respect limit
 	assert.LessOrEqual(t, len(events), 3, "Should respect limit parameter")
@@ -85,8 +102,9 @@ func TestEvents_ResponseStructure(t *testing.T) {
 	resp := MakeRequest(t, "GET", "/events?limit=1", nil)
 	AssertStatusCode(t, resp, http.StatusOK)
 
-	var events []EventResponse
-	ParseJSONResponse(t, resp, &events)
+	var list EventListResponse
+	ParseJSONResponse(t, resp, &list)
+	events := list.Data
 
 	if len(events) > 0 {
 		event := events[0]
@@ -115,13 +133,120 @@ func TestEvents_EmptyResult(t *testing.T) {
 	resp := MakeRequest(t, "GET", "/events?limit=0", nil)
 	AssertStatusCode(t, resp, http.StatusOK)
 
-	var events []EventResponse
-	ParseJSONResponse(t, resp, &events)
+	var list EventListResponse
+	ParseJSONResponse(t, resp, &list)
+	events := list.Data
 
 	// Should handle empty result gracefully
 	assert.NotNil(t, events, "Events array should not be nil")
 }
 
+func TestEvents_MetaCount(t *testing.T) {
+	resp := MakeRequest(t, "GET", "/events?limit=2", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var list EventListResponse
+	ParseJSONResponse(t, resp, &list)
+
+	// The count covers every page, not only the returned one
+	assert.GreaterOrEqual(t, list.Meta.Count, len(list.Data))
+}
+
+func TestEvents_Sources(t *testing.T) {
+	resp := MakeRequest(t, "GET", "/events?limit=20", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var list EventListResponse
+	ParseJSONResponse(t, resp, &list)
+
+	// Every event names the source it was read from, Grupy events keep their own IDs
+	for _, event := range list.Data {
+		assert.NotEmpty(t, event.Source, "Event %s should have a source", event.ID)
+		if event.Source != "grupy" {
+			assert.True(t, strings.HasPrefix(event.ID, event.Source+":"), "Partner event IDs are prefixed by their source")
+		}
+	}
+	assert.NotContains(t, list.Meta.FailedSources, "grupy", "The Grupy API should be available")
+}
+
+func TestEvents_Pagination(t *testing.T) {
+	resp := MakeRequest(t, "GET", "/events?limit=1&page=1&orderBy=starts-at", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+	var first EventListResponse
+	ParseJSONResponse(t, resp, &first)
+
+	if first.Meta.Count < 2 {
+		t.Skip("Not enough events available from external API to test pagination")
+	}
+
+	resp = MakeRequest(t, "GET", "/events?limit=1&page=2&orderBy=starts-at", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+	var second EventListResponse
+	ParseJSONResponse(t, resp, &second)
+
+	require.Len(t, first.Data, 1)
+	require.Len(t, second.Data, 1)
+	assert.NotEqual(t, first.Data[0].ID, second.Data[0].ID, "Pages should hold different events")
+	assert.Equal(t, first.Meta.Count, second.Meta.Count)
+}
+
+func TestEvents_UpcomingAndPast(t *testing.T) {
+	var total EventListResponse
+	resp := MakeRequest(t, "GET", "/events?limit=1", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+	ParseJSONResponse(t, resp, &total)
+
+	var upcoming, past EventListResponse
+	resp = MakeRequest(t, "GET", "/events?limit=1&upcoming=true", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+	ParseJSONResponse(t, resp, &upcoming)
+
+	resp = MakeRequest(t, "GET", "/events?limit=1&past=true", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+	ParseJSONResponse(t, resp, &past)
+
+	// Every event has either ended or not
+	assert.Equal(t, total.Meta.Count, upcoming.Meta.Count+past.Meta.Count)
+}
+
+func TestEvents_DateWindow(t *testing.T) {
+	resp := MakeRequest(t, "GET", "/events?limit=100&from=2024-01-01&to=2024-12-31", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var list struct {
+		Data []struct {
+			ID       string    `json:"id"`
+			StartsAt time.Time `json:"starts_at"`
+		} `json:"data"`
+	}
+	ParseJSONResponse(t, resp, &list)
+
+	// The to date covers the whole day
+	from := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+	to := time.Date(2025, 1, 1, 0, 0, 0, 0, time.UTC)
+	for _, event := range list.Data {
+		assert.False(t, event.StartsAt.Before(from), "Event %s starts before the window", event.ID)
+		assert.True(t, event.StartsAt.Before(to), "Event %s starts after the window", event.ID)
+	}
+}
+
+func TestEvents_InvalidFilters(t *testing.T) {
+	for _, query := range []string{
+		"page=0",
+		"page=abc",
+		"from=yesterday",
+		"to=2024-13-01",
+		"upcoming=maybe",
+		"upcoming=true&past=true",
+		"from=2025-01-01&to=2024-01-01",
+		"state=archived",
+	} {
+		resp := MakeRequest(t, "GET", "/events?"+query, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
 func TestEvents_InvalidParameters(t *testing.T) {
 	// Test with invalid limit (negative)
 	resp := MakeRequest(t, "GET", "/events?limit=-1", nil)
@@ -135,8 +260,9 @@ func TestEvents_LargeLimit(t *testing.T) {
 	resp := MakeRequest(t, "GET", "/events?limit=100", nil)
 	AssertStatusCode(t, resp, http.StatusOK)
 
-	var events []EventResponse
-	ParseJSONResponse(t, resp, &events)
+	var list EventListResponse
+	ParseJSONResponse(t, resp, &list)
+	events := list.Data
 
 	// Should not exceed reasonable limits (API might cap at a max value)
 	assert.LessOrEqual(t, len(events), 100, "Should not exceed requested limit")
@@ -147,8 +273,9 @@ func TestEvents_DateOrdering(t *testing.T) {
 	resp := MakeRequest(t, "GET", "/events?limit=10", nil)
 	AssertStatusCode(t, resp, http.StatusOK)
 
-	var events []EventResponse
-	ParseJSONResponse(t, resp, &events)
+	var list EventListResponse
+	ParseJSONResponse(t, resp, &list)
+	events := list.Data
 
 	// Just verify we got events back
 	// Note: Some events may have empty date fields in the external API
@@ -159,3 +286,141 @@ func TestEvents_DateOrdering(t *testing.T) {
 		// StartsAt and EndsAt may be empty - this is valid for the external API
 	}
 }
+
+// SessionResponse represents the API response for a session of an event
+type SessionResponse struct {
+	ID       string `json:"id"`
+	Title    string `json:"title"`
+	StartsAt string `json:"starts_at"`
+	Track    *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"track"`
+	Speakers []struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"speakers"`
+}
+
+// SpeakerResponse represents the API response for a speaker of an event
+type SpeakerResponse struct {
+	ID         string   `json:"id"`
+	Name       string   `json:"name"`
+	SessionIDs []string `json:"session_ids"`
+}
+
+// firstEventID returns the ID of an event from the external API, skipping the test if there is none
+func firstEventID(t *testing.T) string {
+	resp := MakeRequest(t, "GET", "/events?limit=1", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var list EventListResponse
+	ParseJSONResponse(t, resp, &list)
+	events := list.Data
+	if len(events) == 0 {
+		t.Skip("No events available from external API")
+	}
+	return events[0].ID
+}
+
+func TestEvents_GetByID(t *testing.T) {
+	id := firstEventID(t)
+
+	resp := MakeRequest(t, "GET", "/events/"+id, nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var event struct {
+		ID     string `json:"id"`
+		Name   string `json:"name"`
+		Link   string `json:"link"`
+		Tracks []struct {
+			ID   string `json:"id"`
+			Name string `json:"name"`
+		} `json:"tracks"`
+	}
+	ParseJSONResponse(t, resp, &event)
+
+	assert.Equal(t, id, event.ID)
+	assert.NotEmpty(t, event.Name)
+	assert.NotEmpty(t, event.Link)
+	for _, track := range event.Tracks {
+		assert.NotEmpty(t, track.ID, "Each track should have an ID")
+	}
+}
+
+func TestEvents_GetSessions(t *testing.T) {
+	id := firstEventID(t)
+
+	resp := MakeRequest(t, "GET", "/events/"+id+"/sessions", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var sessions []SessionResponse
+	ParseJSONResponse(t, resp, &sessions)
+
+	assert.NotNil(t, sessions, "Sessions array should not be nil")
+	for _, session := range sessions {
+		assert.NotEmpty(t, session.ID, "Each session should have an ID")
+		assert.NotNil(t, session.Speakers, "Speakers array should not be nil")
+	}
+}
+
+func TestEvents_GetSpeakers(t *testing.T) {
+	id := firstEventID(t)
+
+	resp := MakeRequest(t, "GET", "/events/"+id+"/speakers", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var speakers []SpeakerResponse
+	ParseJSONResponse(t, resp, &speakers)
+
+	assert.NotNil(t, speakers, "Speakers array should not be nil")
+	for _, speaker := range speakers {
+		assert.NotEmpty(t, speaker.ID, "Each speaker should have an ID")
+		assert.NotNil(t, speaker.SessionIDs, "Session IDs array should not be nil")
+	}
+}
+
+func TestEvents_GetByIDNotFound(t *testing.T) {
+	for _, path := range []string{"/events/999999999", "/events/999999999/sessions", "/events/999999999/speakers"} {
+		resp := MakeRequest(t, "GET", path, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, path)
+	}
+}
+
+func TestEvents_CalendarFeed(t *testing.T) {
+	resp := MakeRequest(t, "GET", "/events.ics", nil)
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusOK)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/calendar")
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	feed := string(body)
+
+	assert.True(t, strings.HasPrefix(feed, "BEGIN:VCALENDAR\r\n"), "Feed should be an iCalendar object")
+	assert.True(t, strings.HasSuffix(feed, "END:VCALENDAR\r\n"), "Feed should end the iCalendar object")
+	assert.Contains(t, feed, "VERSION:2.0\r\n")
+	assert.Equal(t, strings.Count(feed, "BEGIN:VEVENT"), strings.Count(feed, "UID:"), "Every event should have a UID")
+}
+
+func TestEvents_EventCalendar(t *testing.T) {
+	id := firstEventID(t)
+
+	resp := MakeRequest(t, "GET", "/events/"+id+"/calendar.ics", nil)
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusOK)
+	assert.Contains(t, resp.Header.Get("Content-Type"), "text/calendar")
+	assert.Contains(t, resp.Header.Get("Content-Disposition"), "attachment")
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	assert.Equal(t, 1, strings.Count(string(body), "BEGIN:VEVENT"))
+	assert.Contains(t, string(body), "@eventos.grupysanca.com.br")
+}
+
+func TestEvents_EventCalendarNotFound(t *testing.T) {
+	resp := MakeRequest(t, "GET", "/events/999999999/calendar.ics", nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
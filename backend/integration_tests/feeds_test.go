@@ -0,0 +1,118 @@
+package integration_tests
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// getFeed downloads a feed, sending the given extra headers
+func getFeed(t *testing.T, name string, headers map[string]string) (*http.Response, []byte) {
+	req, err := http.NewRequest("GET", BaseURL+"/feeds/"+name, nil)
+	require.NoError(t, err)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := HTTPClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
+
+func TestFeeds_Formats(t *testing.T) {
+	formats := map[string]struct {
+		contentType string
+		root        string
+	}{
+		"atom": {contentType: "application/atom+xml", root: "feed"},
+		"rss":  {contentType: "application/rss+xml", root: "rss"},
+	}
+
+	for _, kind := range []string{"timeline", "galery", "events"} {
+		for format, expected := range formats {
+			name := kind + "." + format
+			t.Run(name, func(t *testing.T) {
+				resp, body := getFeed(t, name, nil)
+				require.Equal(t, http.StatusOK, resp.StatusCode, "Body: %s", string(body))
+				assert.Contains(t, resp.Header.Get("Content-Type"), expected.contentType)
+				assert.NotEmpty(t, resp.Header.Get("ETag"))
+
+				var doc struct{ XMLName xml.Name }
+				require.NoError(t, xml.Unmarshal(body, &doc), "Feed should be well-formed XML")
+				assert.Equal(t, expected.root, doc.XMLName.Local)
+			})
+		}
+	}
+}
+
+func TestFeeds_ConditionalRequests(t *testing.T) {
+	resp, _ := getFeed(t, "timeline.atom", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+	lastModified := resp.Header.Get("Last-Modified")
+
+	resp, body := getFeed(t, "timeline.atom", map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	assert.Empty(t, body)
+
+	// An empty feed has no modification date to compare against
+	if lastModified != "" {
+		resp, _ = getFeed(t, "timeline.atom", map[string]string{"If-Modified-Since": lastModified})
+		assert.Equal(t, http.StatusNotModified, resp.StatusCode)
+	}
+
+	resp, _ = getFeed(t, "timeline.atom", map[string]string{"If-None-Match": `"stale"`})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestFeeds_GaleryModifyUpdatesLastModified(t *testing.T) {
+	event := createTestGaleryEvent(t, 1)
+
+	resp, _ := getFeed(t, "galery.atom", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	before, err := http.ParseTime(resp.Header.Get("Last-Modified"))
+	require.NoError(t, err)
+
+	// Last-Modified has a resolution of one second
+	time.Sleep(1100 * time.Millisecond)
+
+	modifyReq := map[string]any{
+		"id":       event.ID,
+		"name":     event.Name + " (renamed)",
+		"location": event.Location,
+		"date":     event.Date,
+	}
+	resp = MakeRequest(t, "PUT", "/galery_events", modifyReq)
+	AssertStatusCode(t, resp, http.StatusOK)
+	var modified GaleryEventResponse
+	ParseJSONResponse(t, resp, &modified)
+	assert.True(t, modified.UpdatedAt.After(event.UpdatedAt), "A modify should date the galery event")
+
+	resp, _ = getFeed(t, "galery.atom", nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	after, err := http.ParseTime(resp.Header.Get("Last-Modified"))
+	require.NoError(t, err)
+	assert.True(t, after.After(before), "A modified galery event should move the feed's Last-Modified")
+
+	// Clients holding the previous date get the new feed
+	resp, _ = getFeed(t, "galery.atom", map[string]string{"If-Modified-Since": before.Format(http.TimeFormat)})
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestFeeds_NotFound(t *testing.T) {
+	for _, name := range []string{"unknown.atom", "timeline.json", "timeline"} {
+		t.Run(name, func(t *testing.T) {
+			resp, _ := getFeed(t, name, nil)
+			assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+		})
+	}
+}
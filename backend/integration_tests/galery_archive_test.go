@@ -0,0 +1,127 @@
+package integration_tests
+
+import (
+	"archive/zip"
+	"bytes"
+	"encoding/json"
+	"io"
+	"net/http"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// archiveManifest represents the manifest.json inside a galery archive
+type archiveManifest struct {
+	Name  string `json:"name"`
+	Items []struct {
+		File    string `json:"file"`
+		ImageID string `json:"image_id"`
+		Caption string `json:"caption"`
+		Credit  string `json:"credit"`
+		Cover   bool   `json:"cover"`
+	} `json:"items"`
+}
+
+// getArchive downloads a galery archive, sending the given extra headers
+func getArchive(t *testing.T, id string, headers map[string]string) (*http.Response, []byte) {
+	req, err := http.NewRequest("GET", BaseURL+"/galery_events/"+id+"/archive", nil)
+	require.NoError(t, err)
+	for key, value := range headers {
+		req.Header.Set(key, value)
+	}
+
+	resp, err := HTTPClient.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := io.ReadAll(resp.Body)
+	require.NoError(t, err)
+	return resp, body
+}
+
+func TestGaleryArchive_Download(t *testing.T) {
+	event := createTestGaleryEvent(t, 2)
+
+	resp, body := getArchive(t, event.ID, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "application/zip", resp.Header.Get("Content-Type"))
+	assert.Equal(t, "bytes", resp.Header.Get("Accept-Ranges"))
+	assert.NotEmpty(t, resp.Header.Get("ETag"))
+	assert.Equal(t, int64(len(body)), resp.ContentLength, "Content-Length should match the streamed archive")
+
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(t, err)
+	require.Len(t, archive.File, 3, "Two images and the manifest")
+
+	manifestFile, err := archive.Open("manifest.json")
+	require.NoError(t, err)
+	defer manifestFile.Close()
+
+	var manifest archiveManifest
+	require.NoError(t, json.NewDecoder(manifestFile).Decode(&manifest))
+	assert.Equal(t, event.Name, manifest.Name)
+	require.Len(t, manifest.Items, 2)
+	assert.Equal(t, event.ImageIDs[0], manifest.Items[0].ImageID)
+	assert.True(t, manifest.Items[0].Cover)
+
+	// Every listed file is in the archive
+	for _, item := range manifest.Items {
+		_, err := archive.Open(item.File)
+		assert.NoError(t, err, "File %s should be in the archive", item.File)
+	}
+}
+
+func TestGaleryArchive_ManyImagesKeepOrder(t *testing.T) {
+	// More images than objects looked up at the same time
+	event := createTestGaleryEvent(t, 12)
+
+	resp, body := getArchive(t, event.ID, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, int64(len(body)), resp.ContentLength, "Content-Length should match the streamed archive")
+
+	archive, err := zip.NewReader(bytes.NewReader(body), int64(len(body)))
+	require.NoError(t, err)
+	require.Len(t, archive.File, 13, "Twelve images and the manifest")
+
+	for i, imageID := range event.ImageIDs {
+		assert.Contains(t, archive.File[i].Name, imageID, "Files keep the order of the images")
+	}
+}
+
+func TestGaleryArchive_Range(t *testing.T) {
+	event := createTestGaleryEvent(t, 1)
+
+	resp, full := getArchive(t, event.ID, nil)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	etag := resp.Header.Get("ETag")
+
+	// Resume after the first 100 bytes
+	resp, rest := getArchive(t, event.ID, map[string]string{
+		"Range":    "bytes=100-",
+		"If-Range": etag,
+	})
+	require.Equal(t, http.StatusPartialContent, resp.StatusCode)
+	assert.Equal(t, full[100:], rest, "Resumed bytes should match the full archive")
+	assert.NotEmpty(t, resp.Header.Get("Content-Range"))
+
+	// A stale validator gets the whole archive again
+	resp, again := getArchive(t, event.ID, map[string]string{
+		"Range":    "bytes=100-",
+		"If-Range": `"stale"`,
+	})
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, full, again)
+
+	// Ranges past the end can't be satisfied
+	resp, _ = getArchive(t, event.ID, map[string]string{
+		"Range": "bytes=999999999-",
+	})
+	assert.Equal(t, http.StatusRequestedRangeNotSatisfiable, resp.StatusCode)
+}
+
+func TestGaleryArchive_NotFound(t *testing.T) {
+	resp, _ := getArchive(t, "non-existent-id-12345", nil)
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
@@ -0,0 +1,236 @@
+package integration_tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// createTestGaleryEvent creates a galery event with the given number of images and registers its cleanup
+func createTestGaleryEvent(t *testing.T, images int) GaleryEventResponse {
+	imagesBase64 := make([]string, images)
+	for i := range imagesBase64 {
+		imagesBase64[i] = TinyPNG
+	}
+
+	createReq := CreateGaleryEventRequest{
+		Name:         GenerateUniqueSlug("galery-images"),
+		Location:     "Test Location",
+		Date:         time.Now().Format(time.RFC3339),
+		ImagesBase64: imagesBase64,
+	}
+
+	resp := MakeRequest(t, "POST", "/galery_events", createReq)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var created GaleryEventResponse
+	ParseJSONResponse(t, resp, &created)
+
+	t.Cleanup(func() {
+		resp := MakeRequest(t, "DELETE", "/galery_events/"+created.ID, nil)
+		resp.Body.Close()
+	})
+
+	return created
+}
+
+func TestGaleryEventImages_Add(t *testing.T) {
+	event := createTestGaleryEvent(t, 1)
+
+	req := map[string]string{
+		"data":    TinyPNG,
+		"name":    "Added later",
+		"caption": "Closing keynote",
+		"credit":  "Jane Doe",
+	}
+
+	resp := MakeRequest(t, "POST", "/galery_events/"+event.ID+"/images", req)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var updated GaleryEventResponse
+	ParseJSONResponse(t, resp, &updated)
+
+	require.Len(t, updated.ImageIDs, 2, "Image should be appended")
+	require.Len(t, updated.ImageURLs, 2)
+	assert.Equal(t, event.ImageIDs[0], updated.ImageIDs[0], "Existing images keep their position")
+	require.Len(t, updated.Items, 2)
+	assert.Equal(t, "Closing keynote", updated.Items[1].Caption)
+	assert.Equal(t, "Jane Doe", updated.Items[1].Credit)
+
+	// The new image is a regular image document
+	resp = MakeRequest(t, "GET", "/images/"+updated.ImageIDs[1], nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var img ImageResponse
+	ParseJSONResponse(t, resp, &img)
+	assert.Equal(t, "Added later", img.Name)
+}
+
+func TestGaleryEventImages_Add_EventNotFound(t *testing.T) {
+	req := map[string]string{
+		"data": TinyPNG,
+	}
+
+	resp := MakeRequest(t, "POST", "/galery_events/non-existent-id-12345/images", req)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestGaleryEventImages_RemoveAndDelete(t *testing.T) {
+	event := createTestGaleryEvent(t, 2)
+	removedID := event.ImageIDs[0]
+
+	resp := MakeRequest(t, "DELETE", "/galery_events/"+event.ID+"/images/"+removedID+"?delete=true", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var updated GaleryEventResponse
+	ParseJSONResponse(t, resp, &updated)
+
+	assert.Equal(t, []string{event.ImageIDs[1]}, updated.ImageIDs)
+	assert.Equal(t, []string{event.ImageURLs[1]}, updated.ImageURLs)
+
+	// The image was deleted as well
+	resp = MakeRequest(t, "GET", "/images/"+removedID, nil)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestGaleryEventImages_Remove_NotInEvent(t *testing.T) {
+	event := createTestGaleryEvent(t, 1)
+
+	resp := MakeRequest(t, "DELETE", "/galery_events/"+event.ID+"/images/non-existent-image", nil)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestGaleryEventImages_Reorder(t *testing.T) {
+	event := createTestGaleryEvent(t, 3)
+
+	newOrder := []string{event.ImageIDs[2], event.ImageIDs[0], event.ImageIDs[1]}
+	req := map[string][]string{
+		"image_ids": newOrder,
+	}
+
+	resp := MakeRequest(t, "PUT", "/galery_events/"+event.ID+"/images/order", req)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var updated GaleryEventResponse
+	ParseJSONResponse(t, resp, &updated)
+
+	assert.Equal(t, newOrder, updated.ImageIDs)
+	assert.Equal(t, []string{event.ImageURLs[2], event.ImageURLs[0], event.ImageURLs[1]}, updated.ImageURLs, "URLs should follow their images")
+}
+
+func TestGaleryEventImages_Reorder_MissingImage(t *testing.T) {
+	event := createTestGaleryEvent(t, 2)
+
+	req := map[string][]string{
+		"image_ids": {event.ImageIDs[0]},
+	}
+
+	resp := MakeRequest(t, "PUT", "/galery_events/"+event.ID+"/images/order", req)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestGaleryEventImages_ItemsAndCover(t *testing.T) {
+	event := createTestGaleryEvent(t, 2)
+
+	// Without a designated cover the first item is used
+	assert.Equal(t, event.ImageIDs[0], event.CoverImageID)
+	require.Len(t, event.Items, 2)
+	assert.Equal(t, event.ImageURLs[0], event.Items[0].ImageURL)
+
+	modifyReq := map[string]any{
+		"id":       event.ID,
+		"name":     event.Name,
+		"location": event.Location,
+		"date":     event.Date,
+		"items": []map[string]string{
+			{"image_id": event.ImageIDs[0], "caption": "Opening", "alt_text": "Speaker on stage"},
+			{"image_id": event.ImageIDs[1], "caption": "Group photo", "credit": "John Doe"},
+		},
+		"cover_image_id": event.ImageIDs[1],
+	}
+
+	resp := MakeRequest(t, "PUT", "/galery_events", modifyReq)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var modified GaleryEventResponse
+	ParseJSONResponse(t, resp, &modified)
+
+	require.Len(t, modified.Items, 2)
+	assert.Equal(t, "Opening", modified.Items[0].Caption)
+	assert.Equal(t, "Speaker on stage", modified.Items[0].AltText)
+	assert.Equal(t, "John Doe", modified.Items[1].Credit)
+	assert.Equal(t, event.ImageURLs[1], modified.Items[1].ImageURL, "Item URLs come from the images")
+	assert.Equal(t, event.ImageIDs[1], modified.CoverImageID)
+	assert.Equal(t, event.ImageURLs[1], modified.CoverImageURL)
+	assert.Equal(t, event.ImageIDs, modified.ImageIDs, "image_ids still mirrors the items")
+}
+
+func TestGaleryEventImages_LegacyRemoveKeepsItems(t *testing.T) {
+	event := createTestGaleryEvent(t, 3)
+
+	modifyReq := map[string]any{
+		"id":       event.ID,
+		"name":     event.Name,
+		"location": event.Location,
+		"date":     event.Date,
+		"items": []map[string]string{
+			{"image_id": event.ImageIDs[0], "caption": "Opening"},
+			{"image_id": event.ImageIDs[1], "caption": "Keynote", "credit": "John Doe", "alt_text": "Speaker on stage"},
+			{"image_id": event.ImageIDs[2], "caption": "Group photo"},
+		},
+		"cover_image_id": event.ImageIDs[2],
+	}
+	resp := MakeRequest(t, "PUT", "/galery_events", modifyReq)
+	AssertStatusCode(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	// Older clients remove a photo by sending the remaining image IDs and URLs only
+	legacyReq := map[string]any{
+		"id":         event.ID,
+		"name":       event.Name,
+		"location":   event.Location,
+		"date":       event.Date,
+		"image_ids":  []string{event.ImageIDs[1], event.ImageIDs[2]},
+		"image_urls": []string{event.ImageURLs[1], event.ImageURLs[2]},
+	}
+	resp = MakeRequest(t, "PUT", "/galery_events", legacyReq)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var modified GaleryEventResponse
+	ParseJSONResponse(t, resp, &modified)
+
+	require.Len(t, modified.Items, 2)
+	assert.Equal(t, "Keynote", modified.Items[0].Caption, "Captions of the remaining photos survive")
+	assert.Equal(t, "John Doe", modified.Items[0].Credit)
+	assert.Equal(t, "Speaker on stage", modified.Items[0].AltText)
+	assert.Equal(t, "Group photo", modified.Items[1].Caption)
+	assert.Equal(t, event.ImageIDs[2], modified.CoverImageID, "The cover is kept when left out")
+}
+
+func TestGaleryEventImages_CoverNotInEvent(t *testing.T) {
+	event := createTestGaleryEvent(t, 1)
+
+	modifyReq := map[string]any{
+		"id":             event.ID,
+		"name":           event.Name,
+		"location":       event.Location,
+		"date":           event.Date,
+		"items":          []map[string]string{{"image_id": event.ImageIDs[0]}},
+		"cover_image_id": "not-an-image-of-the-event",
+	}
+
+	resp := MakeRequest(t, "PUT", "/galery_events", modifyReq)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
@@ -0,0 +1,165 @@
+package integration_tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// LinkedGaleryEventResponse represents a galery event along with its linked Grupy event
+type LinkedGaleryEventResponse struct {
+	GaleryEventResponse
+	GrupyEventID string `json:"grupy_event_id"`
+	GrupyEvent   *struct {
+		ID          string `json:"id"`
+		Name        string `json:"name"`
+		Description string `json:"description"`
+		Link        string `json:"link"`
+		LogoURL     string `json:"logo_url"`
+	} `json:"grupy_event"`
+}
+
+// LinkedGaleryEventRequest represents the request body for creating a galery event linked to a Grupy event
+type LinkedGaleryEventRequest struct {
+	CreateGaleryEventRequest
+	GrupyEventID string `json:"grupy_event_id"`
+}
+
+// firstGrupyEvent returns an event from the events API
+func firstGrupyEvent(t *testing.T) EventResponse {
+	resp := MakeRequest(t, "GET", "/events?limit=1&desc=true", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var events []EventResponse
+	ParseJSONResponse(t, resp, &events)
+	require.NotEmpty(t, events, "events API returned no events")
+	return events[0]
+}
+
+func TestGaleryEventLinks_LinkAndFetchByEvent(t *testing.T) {
+	grupyEvent := firstGrupyEvent(t)
+
+	createReq := LinkedGaleryEventRequest{
+		CreateGaleryEventRequest: CreateGaleryEventRequest{
+			Name:         "Linked Galery Event",
+			Location:     "Test Location",
+			Date:         time.Now().Format(time.RFC3339),
+			ImagesBase64: []string{TinyPNG},
+		},
+		GrupyEventID: grupyEvent.ID,
+	}
+
+	resp := MakeRequest(t, "POST", "/galery_events", createReq)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var created LinkedGaleryEventResponse
+	ParseJSONResponse(t, resp, &created)
+	t.Cleanup(func() {
+		resp := MakeRequest(t, "DELETE", "/galery_events/"+created.ID, nil)
+		resp.Body.Close()
+	})
+
+	assert.Equal(t, grupyEvent.ID, created.GrupyEventID)
+	require.NotNil(t, created.GrupyEvent)
+	assert.Equal(t, grupyEvent.Name, created.GrupyEvent.Name)
+	assert.NotEmpty(t, created.GrupyEvent.Link)
+
+	// The album is found from the event
+	resp = MakeRequest(t, "GET", "/events/"+grupyEvent.ID+"/galery", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var album LinkedGaleryEventResponse
+	ParseJSONResponse(t, resp, &album)
+	assert.Equal(t, created.ID, album.ID)
+	require.NotNil(t, album.GrupyEvent)
+	assert.Equal(t, grupyEvent.Description, album.GrupyEvent.Description)
+
+	// An event has a single album
+	resp = MakeRequest(t, "POST", "/galery_events", createReq)
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusConflict)
+}
+
+func TestGaleryEventLinks_ModifyKeepsLink(t *testing.T) {
+	grupyEvent := firstGrupyEvent(t)
+
+	// Unlink the event from an album left by an earlier run
+	resp := MakeRequest(t, "GET", "/events/"+grupyEvent.ID+"/galery", nil)
+	if resp.StatusCode == http.StatusOK {
+		var stale LinkedGaleryEventResponse
+		ParseJSONResponse(t, resp, &stale)
+		resp = MakeRequest(t, "DELETE", "/galery_events/"+stale.ID, nil)
+	}
+	resp.Body.Close()
+
+	createReq := LinkedGaleryEventRequest{
+		CreateGaleryEventRequest: CreateGaleryEventRequest{
+			Name:         "Linked Galery Event",
+			Location:     "Test Location",
+			Date:         time.Now().Format(time.RFC3339),
+			ImagesBase64: []string{TinyPNG},
+		},
+		GrupyEventID: grupyEvent.ID,
+	}
+
+	resp = MakeRequest(t, "POST", "/galery_events", createReq)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var created LinkedGaleryEventResponse
+	ParseJSONResponse(t, resp, &created)
+	t.Cleanup(func() {
+		resp := MakeRequest(t, "DELETE", "/galery_events/"+created.ID, nil)
+		resp.Body.Close()
+	})
+
+	// The frontend edits albums without sending grupy_event_id
+	modifyReq := map[string]any{
+		"id":         created.ID,
+		"name":       "Renamed Linked Galery Event",
+		"location":   created.Location,
+		"date":       created.Date,
+		"image_ids":  created.ImageIDs,
+		"image_urls": created.ImageURLs,
+	}
+	resp = MakeRequest(t, "PUT", "/galery_events", modifyReq)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var modified LinkedGaleryEventResponse
+	ParseJSONResponse(t, resp, &modified)
+	assert.Equal(t, "Renamed Linked Galery Event", modified.Name)
+	assert.Equal(t, grupyEvent.ID, modified.GrupyEventID, "The link survives updates leaving it out")
+
+	// An empty grupy_event_id unlinks
+	modifyReq["grupy_event_id"] = ""
+	resp = MakeRequest(t, "PUT", "/galery_events", modifyReq)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var unlinked LinkedGaleryEventResponse
+	ParseJSONResponse(t, resp, &unlinked)
+	assert.Empty(t, unlinked.GrupyEventID)
+}
+
+func TestGaleryEventLinks_UnknownGrupyEvent(t *testing.T) {
+	createReq := LinkedGaleryEventRequest{
+		CreateGaleryEventRequest: CreateGaleryEventRequest{
+			Name:         "Badly Linked Galery Event",
+			Location:     "Test Location",
+			Date:         time.Now().Format(time.RFC3339),
+			ImagesBase64: []string{TinyPNG},
+		},
+		GrupyEventID: "non-existent-grupy-event",
+	}
+
+	resp := MakeRequest(t, "POST", "/galery_events", createReq)
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusBadRequest)
+}
+
+func TestGaleryEventLinks_EventWithoutGalery(t *testing.T) {
+	resp := MakeRequest(t, "GET", "/events/non-existent-grupy-event/galery", nil)
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusNotFound)
+}
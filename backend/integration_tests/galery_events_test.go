@@ -16,8 +16,23 @@ type GaleryEventResponse struct {
 	Location  string    `json:"location"`
 	Date      time.Time `json:"date"`
 	ImageURLs []string  `json:"image_urls"`
+	ImageIDs  []string  `json:"image_ids"`
+	Private   bool      `json:"private"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	Items         []GaleryItemResponse `json:"items"`
+	CoverImageID  string               `json:"cover_image_id"`
+	CoverImageURL string               `json:"cover_image_url"`
+}
+
+// GaleryItemResponse represents a photo of a galery event
+type GaleryItemResponse struct {
+	ImageID  string `json:"image_id"`
+	ImageURL string `json:"image_url"`
+	Caption  string `json:"caption"`
+	Credit   string `json:"credit"`
+	AltText  string `json:"alt_text"`
 }
 
 // CreateGaleryEventRequest represents the request body for creating a galery event
@@ -26,6 +41,7 @@ type CreateGaleryEventRequest struct {
 	Location     string   `json:"location"`
 	Date         string   `json:"date"`
 	ImagesBase64 []string `json:"images_base64"`
+	OnDuplicate  string   `json:"on_duplicate,omitempty"`
 }
 
 func TestGaleryEvents_CreateAndGet(t *testing.T) {
@@ -519,8 +535,7 @@ func TestGaleryEvents_Delete(t *testing.T) {
 	var created GaleryEventResponse
 	ParseJSONResponse(t, resp, &created)
 
-	// Store image URLs to verify they remain accessible after deletion
-	imageURLs := created.ImageURLs
+	require.Len(t, created.ImageIDs, 1)
 
 	// Delete the galery event
 	resp = MakeRequest(t, "DELETE", "/galery_events/"+created.ID, nil)
@@ -532,14 +547,102 @@ func TestGaleryEvents_Delete(t *testing.T) {
 	AssertStatusCode(t, resp, http.StatusNotFound)
 	resp.Body.Close()
 
-	// Verify images are NOT deleted (they should still be accessible)
-	for i, imageURL := range imageURLs {
-		objectResp, err := http.Head(imageURL)
-		require.NoError(t, err, "Image URL %d should still be accessible after event deletion", i)
-		defer objectResp.Body.Close()
-		assert.Equal(t, http.StatusOK, objectResp.StatusCode,
-			"Image URL %d should remain accessible after event deletion", i)
-	}
+	// The images uploaded for the event are deleted along with it
+	resp = MakeRequest(t, "GET", "/images/"+created.ImageIDs[0], nil)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestGaleryEvents_DeleteKeepsReusedImages(t *testing.T) {
+	resp := MakeRequest(t, "POST", "/images", CreateImageRequest{
+		Slug: GenerateUniqueSlug("img-reused-by-galery"),
+		Name: "Standalone image",
+		Data: TinyPNG,
+	})
+	AssertStatusCode(t, resp, http.StatusCreated)
+	var standalone ImageResponse
+	ParseJSONResponse(t, resp, &standalone)
+
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/images/"+standalone.ID, nil)
+		resp.Body.Close()
+	}()
+
+	// Both photos reuse an existing image with the same bytes, which is attached once
+	resp = MakeRequest(t, "POST", "/galery_events", CreateGaleryEventRequest{
+		Name:         "Event reusing images",
+		Location:     "Test Location",
+		Date:         time.Now().Format(time.RFC3339),
+		ImagesBase64: []string{TinyPNG, TinyPNG},
+		OnDuplicate:  "reuse",
+	})
+	AssertStatusCode(t, resp, http.StatusCreated)
+	var created GaleryEventResponse
+	ParseJSONResponse(t, resp, &created)
+	require.Len(t, created.Items, 1, "A reused image is attached once")
+	reusedID := created.Items[0].ImageID
+
+	// The single item can be reordered, duplicate items would be rejected
+	resp = MakeRequest(t, "PUT", "/galery_events/"+created.ID+"/images/order", map[string][]string{"image_ids": {reusedID}})
+	AssertStatusCode(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	resp = MakeRequest(t, "DELETE", "/galery_events/"+created.ID, nil)
+	AssertStatusCode(t, resp, http.StatusNoContent)
+	resp.Body.Close()
+
+	// Reused images belong to someone else and survive the event
+	resp = MakeRequest(t, "GET", "/images/"+reusedID, nil)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestGaleryEvents_SetVisibility(t *testing.T) {
+	resp := MakeRequest(t, "POST", "/galery_events", CreateGaleryEventRequest{
+		Name:         "Event changing visibility",
+		Location:     "Test Location",
+		Date:         time.Now().Format(time.RFC3339),
+		ImagesBase64: []string{TinyPNG},
+	})
+	AssertStatusCode(t, resp, http.StatusCreated)
+	var created GaleryEventResponse
+	ParseJSONResponse(t, resp, &created)
+	require.Len(t, created.ImageIDs, 1)
+	assert.False(t, created.Private)
+
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/galery_events/"+created.ID, nil)
+		resp.Body.Close()
+	}()
+
+	// Making the event private cascades to its images
+	resp = MakeRequest(t, "PUT", "/galery_events/"+created.ID+"/visibility", map[string]bool{"private": true})
+	AssertStatusCode(t, resp, http.StatusOK)
+	var updated GaleryEventResponse
+	ParseJSONResponse(t, resp, &updated)
+	assert.True(t, updated.Private)
+
+	// Requests in this suite carry no token, so the event and its image are hidden from them
+	resp = MakeRequest(t, "GET", "/galery_events/"+created.ID, nil)
+	AssertStatusCode(t, resp, http.StatusNotFound)
+	resp.Body.Close()
+
+	resp = MakeRequest(t, "GET", "/images/"+created.ImageIDs[0], nil)
+	AssertStatusCode(t, resp, http.StatusNotFound)
+	resp.Body.Close()
+
+	// And public again
+	resp = MakeRequest(t, "PUT", "/galery_events/"+created.ID+"/visibility", map[string]bool{"private": false})
+	AssertStatusCode(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	resp = MakeRequest(t, "GET", "/galery_events/"+created.ID, nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	resp = MakeRequest(t, "GET", "/images/"+created.ImageIDs[0], nil)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
 }
 
 func TestGaleryEvents_Delete_NotFound(t *testing.T) {
@@ -0,0 +1,195 @@
+package integration_tests
+
+import (
+	"bytes"
+	"encoding/base64"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// ImageUploadResponse represents the API response for a reserved direct upload
+type ImageUploadResponse struct {
+	Key       string            `json:"key"`
+	UploadURL string            `json:"upload_url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers"`
+	ExpiresAt string            `json:"expires_at"`
+}
+
+func TestImageUploads_CreateSignedURL(t *testing.T) {
+	req := map[string]string{
+		"slug":         GenerateUniqueSlug("direct-upload"),
+		"content_type": "image/png",
+	}
+
+	resp := MakeRequest(t, "POST", "/images/uploads", req)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var upload ImageUploadResponse
+	ParseJSONResponse(t, resp, &upload)
+
+	assert.True(t, strings.HasPrefix(upload.Key, "uploads/"), "Key should be reserved under uploads/")
+	assert.True(t, strings.HasSuffix(upload.Key, ".png"), "Key should use the content type extension")
+	assert.NotEmpty(t, upload.UploadURL)
+	assert.Equal(t, "PUT", upload.Method)
+	assert.Equal(t, "image/png", upload.Headers["Content-Type"])
+	assert.NotEmpty(t, upload.ExpiresAt)
+}
+
+func TestImageUploads_UnsupportedContentType(t *testing.T) {
+	req := map[string]string{
+		"content_type": "application/pdf",
+	}
+
+	resp := MakeRequest(t, "POST", "/images/uploads", req)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestImageUploads_FinalizeInvalidKey(t *testing.T) {
+	req := map[string]string{
+		"key":  "galery_events/not-an-upload.jpg",
+		"name": "Invalid key",
+	}
+
+	resp := MakeRequest(t, "POST", "/images/uploads/finalize", req)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestImageUploads_FinalizeMissingObject(t *testing.T) {
+	req := map[string]string{
+		"key":  "uploads/00000000-0000-0000-0000-000000000000/never-uploaded.png",
+		"name": "Missing object",
+	}
+
+	resp := MakeRequest(t, "POST", "/images/uploads/finalize", req)
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestImageUploads_FinalizeTwice(t *testing.T) {
+	resp := MakeRequest(t, "POST", "/images/uploads", map[string]string{
+		"slug":         GenerateUniqueSlug("direct-upload-retry"),
+		"content_type": "image/png",
+	})
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var upload ImageUploadResponse
+	ParseJSONResponse(t, resp, &upload)
+
+	data, err := base64.StdEncoding.DecodeString(TinyPNG)
+	require.NoError(t, err)
+	putSignedUpload(t, upload, data)
+
+	finalize := map[string]string{"key": upload.Key, "name": "Retried finalize"}
+	resp = MakeRequest(t, "POST", "/images/uploads/finalize", finalize)
+	AssertStatusCode(t, resp, http.StatusCreated)
+	var first ImageResponse
+	ParseJSONResponse(t, resp, &first)
+
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/images/"+first.ID, nil)
+		resp.Body.Close()
+	}()
+
+	// A retry returns the image of the first call instead of a duplicate
+	resp = MakeRequest(t, "POST", "/images/uploads/finalize", finalize)
+	AssertStatusCode(t, resp, http.StatusCreated)
+	var second ImageResponse
+	ParseJSONResponse(t, resp, &second)
+	assert.Equal(t, first.ID, second.ID)
+}
+
+func TestImageUploads_FinalizeNotAnImage(t *testing.T) {
+	resp := MakeRequest(t, "POST", "/images/uploads", map[string]string{
+		"slug":         GenerateUniqueSlug("direct-upload-not-an-image"),
+		"content_type": "image/png",
+	})
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var upload ImageUploadResponse
+	ParseJSONResponse(t, resp, &upload)
+
+	// The signed URL only fixes the declared type, the bytes are checked on finalize
+	putSignedUpload(t, upload, []byte("<html><body>not an image</body></html>"))
+
+	resp = MakeRequest(t, "POST", "/images/uploads/finalize", map[string]string{"key": upload.Key, "name": "Not an image"})
+	defer resp.Body.Close()
+
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+// putSignedUpload uploads data straight to the bucket through a signed URL, like the browser does
+func putSignedUpload(t *testing.T, upload ImageUploadResponse, data []byte) {
+	t.Helper()
+
+	put, err := http.NewRequest(upload.Method, upload.UploadURL, bytes.NewReader(data))
+	require.NoError(t, err)
+	for name, value := range upload.Headers {
+		put.Header.Set(name, value)
+	}
+	putResp, err := HTTPClient.Do(put)
+	require.NoError(t, err)
+	putResp.Body.Close()
+	require.Equal(t, http.StatusOK, putResp.StatusCode)
+}
+
+func TestImageUploads_FinalizeSameBytesTwice(t *testing.T) {
+	data, err := base64.StdEncoding.DecodeString(TinyPNG)
+	require.NoError(t, err)
+
+	uploadAndFinalize := func(onDuplicate string) (*http.Response, map[string]string) {
+		resp := MakeRequest(t, "POST", "/images/uploads", map[string]string{
+			"slug":         GenerateUniqueSlug("direct-upload-dup"),
+			"content_type": "image/png",
+		})
+		AssertStatusCode(t, resp, http.StatusCreated)
+
+		var upload ImageUploadResponse
+		ParseJSONResponse(t, resp, &upload)
+		putSignedUpload(t, upload, data)
+
+		finalize := map[string]string{
+			"key":          upload.Key,
+			"name":         "Direct upload duplicate",
+			"on_duplicate": onDuplicate,
+		}
+		return MakeRequest(t, "POST", "/images/uploads/finalize", finalize), finalize
+	}
+
+	resp, _ := uploadAndFinalize("")
+	AssertStatusCode(t, resp, http.StatusCreated)
+	var first ImageResponse
+	ParseJSONResponse(t, resp, &first)
+
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/images/"+first.ID, nil)
+		resp.Body.Close()
+	}()
+
+	// The content hash is recorded, so the second upload of the same bytes is a duplicate
+	resp, _ = uploadAndFinalize("reject")
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+
+	resp, finalize := uploadAndFinalize("reuse")
+	AssertStatusCode(t, resp, http.StatusCreated)
+	var reused ImageResponse
+	ParseJSONResponse(t, resp, &reused)
+	assert.NotEmpty(t, reused.ID, "An existing image with the same bytes is returned")
+
+	// The reservation records the reused image, a retry returns it although the uploaded object is gone
+	resp = MakeRequest(t, "POST", "/images/uploads/finalize", finalize)
+	AssertStatusCode(t, resp, http.StatusCreated)
+	var retried ImageResponse
+	ParseJSONResponse(t, resp, &retried)
+	assert.Equal(t, reused.ID, retried.ID)
+}
@@ -10,25 +10,33 @@ import (
 
 // ImageResponse represents the API response for an image entity
 type ImageResponse struct {
-	ID        string `json:"id"`
-	Slug      string `json:"slug,omitempty"`
-	ObjectURL string `json:"object_url"`
-	Name      string `json:"name"`
-	Text      string `json:"text"`
-	Date      string `json:"date,omitempty"`
-	Location  string `json:"location,omitempty"`
-	CreatedAt string `json:"created_at"`
-	UpdatedAt string `json:"updated_at"`
+	ID            string   `json:"id"`
+	Slug          string   `json:"slug,omitempty"`
+	ObjectURL     string   `json:"object_url"`
+	Name          string   `json:"name"`
+	Text          string   `json:"text"`
+	Date          string   `json:"date,omitempty"`
+	Location      string   `json:"location,omitempty"`
+	Private       bool     `json:"private"`
+	BlurHash      string   `json:"blur_hash,omitempty"`
+	LQIP          string   `json:"lqip,omitempty"`
+	DominantColor string   `json:"dominant_color,omitempty"`
+	Tags          []string `json:"tags,omitempty"`
+	CreatedAt     string   `json:"created_at"`
+	UpdatedAt     string   `json:"updated_at"`
 }
 
 // CreateImageRequest represents the request body for creating an image
 type CreateImageRequest struct {
-	Slug     string `json:"slug,omitempty"`
-	Name     string `json:"name"`
-	Text     string `json:"text"`
-	Date     string `json:"date,omitempty"`
-	Location string `json:"location,omitempty"`
-	Data     string `json:"data"` // Base64 encoded image
+	Slug        string   `json:"slug,omitempty"`
+	Name        string   `json:"name"`
+	Text        string   `json:"text"`
+	Date        string   `json:"date,omitempty"`
+	Location    string   `json:"location,omitempty"`
+	Private     bool     `json:"private,omitempty"`
+	OnDuplicate string   `json:"on_duplicate,omitempty"`
+	Tags        []string `json:"tags,omitempty"`
+	Data        string   `json:"data"` // Base64 encoded image
 }
 
 // UpdateImageRequest represents the request body for updating an image
@@ -366,3 +374,151 @@ func TestImages_ListAll_EmptyResult(t *testing.T) {
 	// Should be a valid array (possibly empty)
 	assert.NotNil(t, images, "Should return valid array")
 }
+
+func TestImages_PrivateHiddenFromAnonymousReaders(t *testing.T) {
+	// Requests in this suite carry no token, so they are anonymous readers
+	createReq := CreateImageRequest{
+		Slug:    GenerateUniqueSlug("img-private"),
+		Name:    "Private Image",
+		Text:    "Only organizers should see this",
+		Private: true,
+		Data:    TinyPNG,
+	}
+
+	resp := MakeRequest(t, "POST", "/images", createReq)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var created ImageResponse
+	ParseJSONResponse(t, resp, &created)
+	assert.True(t, created.Private)
+
+	// Cleanup
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/images/"+created.ID, nil)
+		resp.Body.Close()
+	}()
+
+	// Anonymous readers can't fetch private images
+	resp = MakeRequest(t, "GET", "/images/"+created.ID, nil)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Nor see them listed by slug
+	resp = MakeRequest(t, "GET", "/images/slug/"+createReq.Slug, nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var images []ImageResponse
+	ParseJSONResponse(t, resp, &images)
+	assert.Empty(t, images, "Private images should not be listed for anonymous readers")
+}
+
+func TestImages_RejectDuplicate(t *testing.T) {
+	createReq := CreateImageRequest{
+		Slug: GenerateUniqueSlug("img-dup"),
+		Name: "Original",
+		Data: TinyPNG,
+	}
+
+	resp := MakeRequest(t, "POST", "/images", createReq)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var created ImageResponse
+	ParseJSONResponse(t, resp, &created)
+
+	// Cleanup
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/images/"+created.ID, nil)
+		resp.Body.Close()
+	}()
+
+	// Uploading the same bytes again is refused when asked to
+	createReq.Name = "Duplicate"
+	createReq.OnDuplicate = "reject"
+
+	resp = MakeRequest(t, "POST", "/images", createReq)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusConflict, resp.StatusCode)
+}
+
+func TestImages_ReuseDuplicateKeepsVisibility(t *testing.T) {
+	createReq := CreateImageRequest{
+		Slug: GenerateUniqueSlug("img-dup-visibility"),
+		Name: "Public original",
+		Data: TinyPNG,
+	}
+
+	resp := MakeRequest(t, "POST", "/images", createReq)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var public ImageResponse
+	ParseJSONResponse(t, resp, &public)
+
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/images/"+public.ID, nil)
+		resp.Body.Close()
+	}()
+
+	// A private upload of the same bytes doesn't reuse the public image
+	createReq.Name = "Private copy"
+	createReq.Private = true
+	createReq.OnDuplicate = "reuse"
+
+	resp = MakeRequest(t, "POST", "/images", createReq)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var private ImageResponse
+	ParseJSONResponse(t, resp, &private)
+
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/images/"+private.ID, nil)
+		resp.Body.Close()
+	}()
+
+	assert.NotEqual(t, public.ID, private.ID)
+	assert.True(t, private.Private)
+}
+
+func TestImages_InvalidDuplicatePolicy(t *testing.T) {
+	createReq := CreateImageRequest{
+		Name:        "Invalid policy",
+		OnDuplicate: "overwrite",
+		Data:        TinyPNG,
+	}
+
+	resp := MakeRequest(t, "POST", "/images", createReq)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
+
+func TestImages_Placeholders(t *testing.T) {
+	createReq := CreateImageRequest{
+		Slug: GenerateUniqueSlug("img-placeholder"),
+		Name: "Placeholder Image",
+		Data: TinyPNG,
+	}
+
+	resp := MakeRequest(t, "POST", "/images", createReq)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var created ImageResponse
+	ParseJSONResponse(t, resp, &created)
+
+	// Cleanup
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/images/"+created.ID, nil)
+		resp.Body.Close()
+	}()
+
+	assert.NotEmpty(t, created.BlurHash, "BlurHash should be computed on upload")
+	assert.Contains(t, created.LQIP, "data:image/jpeg;base64,", "LQIP should be an inline JPEG")
+	assert.Equal(t, "#ff0000", created.DominantColor, "TinyPNG is a single red pixel")
+
+	// Placeholders are persisted
+	resp = MakeRequest(t, "GET", "/images/"+created.ID, nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var fetched ImageResponse
+	ParseJSONResponse(t, resp, &fetched)
+	assert.Equal(t, created.BlurHash, fetched.BlurHash)
+	assert.Equal(t, created.DominantColor, fetched.DominantColor)
+}
@@ -0,0 +1,124 @@
+package integration_tests
+
+import (
+	"backend/internal/http/mapper"
+	"net/http"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// postImport sends an import file with the given query and content type, as an admin
+func postImport(t *testing.T, query, contentType, file string) *http.Response {
+	token := RequireAdminToken(t)
+
+	req, err := http.NewRequest("POST", BaseURL+"/import?"+query, strings.NewReader(file))
+	require.NoError(t, err)
+	if contentType != "" {
+		req.Header.Set("Content-Type", contentType)
+	}
+	req.Header.Set("Authorization", "Bearer "+token)
+
+	resp, err := HTTPClient.Do(req)
+	require.NoError(t, err)
+	return resp
+}
+
+func TestImport_TextsUpsertBySlug(t *testing.T) {
+	slug := GenerateUniqueSlug("import-test")
+	file := "slug,content,page_slug\n" + slug + ",Primeira versão,import-page\n"
+
+	resp := postImport(t, "kind=texts&format=csv", "", file)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var report mapper.ImportReportResponse
+	ParseJSONResponse(t, resp, &report)
+	assert.Equal(t, 1, report.Records)
+	assert.Equal(t, 1, report.Created)
+	assert.Empty(t, report.Errors)
+
+	resp = MakeRequest(t, "GET", "/texts/"+slug, nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+	var created mapper.TextResponse
+	ParseJSONResponse(t, resp, &created)
+	assert.Equal(t, "Primeira versão", created.Content)
+
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/texts/"+created.ID, nil)
+		resp.Body.Close()
+	}()
+
+	// Importing the same slug again updates the text
+	markdown := "---\nslug: " + slug + "\n---\nSegunda versão\n"
+	resp = postImport(t, "kind=texts", "text/markdown; charset=utf-8", markdown)
+	AssertStatusCode(t, resp, http.StatusOK)
+	ParseJSONResponse(t, resp, &report)
+	assert.Equal(t, 0, report.Created)
+	assert.Equal(t, 1, report.Updated)
+
+	resp = MakeRequest(t, "GET", "/texts/"+slug, nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+	var updated mapper.TextResponse
+	ParseJSONResponse(t, resp, &updated)
+	assert.Equal(t, created.ID, updated.ID)
+	assert.Equal(t, "Segunda versão", updated.Content)
+	assert.Equal(t, "import-page", updated.PageSlug, "Fields left out keep their value")
+}
+
+func TestImport_DryRun(t *testing.T) {
+	slug := GenerateUniqueSlug("import-dry-run")
+	file := "- slug: " + slug + "\n  content: Nunca gravado\n"
+
+	resp := postImport(t, "kind=texts&format=yaml&dry_run=true", "", file)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var report mapper.ImportReportResponse
+	ParseJSONResponse(t, resp, &report)
+	assert.True(t, report.DryRun)
+	assert.Equal(t, 1, report.Created)
+
+	resp = MakeRequest(t, "GET", "/texts/"+slug, nil)
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Dry runs don't write")
+}
+
+func TestImport_LineErrors(t *testing.T) {
+	file := "name,date,importance\n" +
+		"Sem data,,2\n" +
+		"Importância inválida,2020-05-01,9\n" +
+		"Repetido,2021-01-01,\n" +
+		"repetido,2021-01-01,\n"
+
+	resp := postImport(t, "kind=timeline", "text/csv", file)
+	AssertStatusCode(t, resp, http.StatusUnprocessableEntity)
+
+	var report mapper.ImportReportResponse
+	ParseJSONResponse(t, resp, &report)
+	require.Len(t, report.Errors, 3)
+
+	assert.Equal(t, 2, report.Errors[0].Line)
+	assert.Equal(t, "date", report.Errors[0].Field)
+	assert.Equal(t, 3, report.Errors[1].Line)
+	assert.Equal(t, "importance", report.Errors[1].Field)
+	assert.Equal(t, 5, report.Errors[2].Line)
+	assert.Contains(t, report.Errors[2].Message, "line 4")
+}
+
+func TestImport_BadRequests(t *testing.T) {
+	// Unknown kind
+	resp := postImport(t, "kind=images&format=csv", "", "slug\nx\n")
+	AssertStatusCode(t, resp, http.StatusBadRequest)
+	resp.Body.Close()
+
+	// Format neither given nor implied by the content type
+	resp = postImport(t, "kind=texts", "", "slug\nx\n")
+	AssertStatusCode(t, resp, http.StatusBadRequest)
+	resp.Body.Close()
+
+	// Unreadable file
+	resp = postImport(t, "kind=texts&format=yaml", "", "slug: not a list\n")
+	AssertStatusCode(t, resp, http.StatusBadRequest)
+	resp.Body.Close()
+}
@@ -0,0 +1,99 @@
+package integration_tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// JobResponse represents the API response for a background job
+type JobResponse struct {
+	ID       string `json:"id"`
+	Type     string `json:"type"`
+	State    string `json:"state"`
+	Progress struct {
+		Done  int `json:"done"`
+		Total int `json:"total"`
+	} `json:"progress"`
+	Error  string            `json:"error"`
+	Result map[string]string `json:"result"`
+}
+
+// waitForJob polls a job until it finishes
+func waitForJob(t *testing.T, id string) JobResponse {
+	deadline := time.Now().Add(60 * time.Second)
+	for {
+		resp := MakeRequest(t, "GET", "/jobs/"+id, nil)
+		AssertStatusCode(t, resp, http.StatusOK)
+
+		var job JobResponse
+		ParseJSONResponse(t, resp, &job)
+		if job.State == "succeeded" || job.State == "failed" {
+			return job
+		}
+
+		require.True(t, time.Now().Before(deadline), "job %s still %s", id, job.State)
+		time.Sleep(500 * time.Millisecond)
+	}
+}
+
+func TestJobs_AsyncGaleryEventCreation(t *testing.T) {
+	createReq := CreateGaleryEventRequest{
+		Name:         "Async Galery Event",
+		Location:     "Test Location",
+		Date:         time.Now().Format(time.RFC3339),
+		ImagesBase64: []string{TinyPNG, TinyPNG},
+	}
+
+	resp := MakeRequest(t, "POST", "/galery_events?async=true", createReq)
+	AssertStatusCode(t, resp, http.StatusAccepted)
+
+	var queued JobResponse
+	ParseJSONResponse(t, resp, &queued)
+	require.NotEmpty(t, queued.ID)
+	assert.Equal(t, "create_galery_event", queued.Type)
+	assert.Equal(t, 2, queued.Progress.Total)
+	assert.Equal(t, "/api/v1/jobs/"+queued.ID, resp.Header.Get("Location"))
+
+	job := waitForJob(t, queued.ID)
+	require.Equal(t, "succeeded", job.State, "job failed: %s", job.Error)
+	assert.Equal(t, 2, job.Progress.Done)
+
+	eventID := job.Result["galery_event_id"]
+	require.NotEmpty(t, eventID)
+	t.Cleanup(func() {
+		resp := MakeRequest(t, "DELETE", "/galery_events/"+eventID, nil)
+		resp.Body.Close()
+	})
+
+	resp = MakeRequest(t, "GET", "/galery_events/"+eventID, nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var event GaleryEventResponse
+	ParseJSONResponse(t, resp, &event)
+	assert.Equal(t, createReq.Name, event.Name)
+	assert.Len(t, event.Items, 2)
+}
+
+func TestJobs_AsyncInvalidBase64(t *testing.T) {
+	createReq := CreateGaleryEventRequest{
+		Name:         "Async Invalid Event",
+		Location:     "Test Location",
+		Date:         time.Now().Format(time.RFC3339),
+		ImagesBase64: []string{"not-base64!"},
+	}
+
+	// Input is validated before the job is queued
+	resp := MakeRequest(t, "POST", "/galery_events?async=true", createReq)
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusBadRequest)
+}
+
+func TestJobs_NotFound(t *testing.T) {
+	resp := MakeRequest(t, "GET", "/jobs/non-existent-job-id", nil)
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusNotFound)
+}
@@ -0,0 +1,43 @@
+package integration_tests
+
+import (
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// DeadLetterResponse represents the API response for an undelivered notification
+type DeadLetterResponse struct {
+	ID             string    `json:"id"`
+	Webhook        string    `json:"webhook"`
+	NotificationID string    `json:"notification_id"`
+	Kind           string    `json:"kind"`
+	EventID        string    `json:"event_id"`
+	EventName      string    `json:"event_name"`
+	Attempts       int       `json:"attempts"`
+	Error          string    `json:"error"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+func TestNotifications_ListDeadLetters(t *testing.T) {
+	token := RequireAdminToken(t)
+
+	resp := MakeAuthenticatedRequest(t, "GET", "/notifications/dead_letters", token, nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var letters []DeadLetterResponse
+	ParseJSONResponse(t, resp, &letters)
+	assert.NotNil(t, letters, "An empty log is an empty list")
+
+	for i, letter := range letters {
+		assert.NotEmpty(t, letter.ID)
+		assert.NotEmpty(t, letter.Webhook)
+		assert.Contains(t, []string{"event.created", "event.published", "event.rescheduled", "event.cancelled"}, letter.Kind)
+		assert.Positive(t, letter.Attempts)
+		if i > 0 {
+			assert.False(t, letter.CreatedAt.After(letters[i-1].CreatedAt), "Newest dead letters come first")
+		}
+	}
+}
@@ -0,0 +1,158 @@
+package integration_tests
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TagCountResponse represents the API response for a tag
+type TagCountResponse struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+// BulkTagRequest represents the request body for tagging several images at once
+type BulkTagRequest struct {
+	ImageIDs []string `json:"image_ids"`
+	Tags     []string `json:"tags"`
+}
+
+// createTaggedImage creates an image with tags and deletes it when the test ends
+func createTaggedImage(t *testing.T, tags ...string) ImageResponse {
+	createReq := CreateImageRequest{
+		Slug: GenerateUniqueSlug("img-tag"),
+		Name: "Tagged Image",
+		Tags: tags,
+		Data: TinyPNG,
+	}
+
+	resp := MakeRequest(t, "POST", "/images", createReq)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var created ImageResponse
+	ParseJSONResponse(t, resp, &created)
+	t.Cleanup(func() {
+		resp := MakeRequest(t, "DELETE", "/images/"+created.ID, nil)
+		resp.Body.Close()
+	})
+	return created
+}
+
+// imagesWithTag lists the IDs of the images carrying a tag
+func imagesWithTag(t *testing.T, tag string) []string {
+	resp := MakeRequest(t, "GET", "/images?tag="+url.QueryEscape(tag), nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var images []ImageResponse
+	ParseJSONResponse(t, resp, &images)
+
+	ids := make([]string, len(images))
+	for i, img := range images {
+		ids[i] = img.ID
+	}
+	return ids
+}
+
+func TestTags_NormalizedOnCreate(t *testing.T) {
+	tag := GenerateUniqueSlug("python-brasil")
+	created := createTaggedImage(t, "  "+tag+" ", tag, "Meetup  Night")
+
+	assert.Equal(t, []string{"meetup-night", tag}, created.Tags)
+	assert.Contains(t, imagesWithTag(t, tag), created.ID)
+	assert.Contains(t, imagesWithTag(t, "MEETUP NIGHT"), created.ID)
+}
+
+func TestTags_ListWithCounts(t *testing.T) {
+	tag := GenerateUniqueSlug("tag-count")
+	createTaggedImage(t, tag)
+	createTaggedImage(t, tag)
+
+	resp := MakeRequest(t, "GET", "/tags", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var tags []TagCountResponse
+	ParseJSONResponse(t, resp, &tags)
+	assert.Contains(t, tags, TagCountResponse{Tag: tag, Count: 2})
+}
+
+func TestTags_BulkTagAndUntag(t *testing.T) {
+	tag := GenerateUniqueSlug("bulk-tag")
+	first := createTaggedImage(t)
+	second := createTaggedImage(t)
+
+	resp := MakeRequest(t, "POST", "/images/tags", BulkTagRequest{
+		ImageIDs: []string{first.ID, second.ID},
+		Tags:     []string{tag},
+	})
+	resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusNoContent)
+	assert.ElementsMatch(t, []string{first.ID, second.ID}, imagesWithTag(t, tag))
+
+	resp = MakeRequest(t, "POST", "/images/tags/remove", BulkTagRequest{
+		ImageIDs: []string{first.ID},
+		Tags:     []string{tag},
+	})
+	resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusNoContent)
+	assert.Equal(t, []string{second.ID}, imagesWithTag(t, tag))
+}
+
+func TestTags_BulkTagKeepsTagsSorted(t *testing.T) {
+	created := createTaggedImage(t, "zeta")
+
+	// Tags are stored sorted and without repeats, whatever the order they are added in
+	resp := MakeRequest(t, "POST", "/images/tags", BulkTagRequest{
+		ImageIDs: []string{created.ID, created.ID},
+		Tags:     []string{"Zeta", "alpha"},
+	})
+	resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusNoContent)
+
+	resp = MakeRequest(t, "GET", "/images/"+created.ID, nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+	var tagged ImageResponse
+	ParseJSONResponse(t, resp, &tagged)
+	assert.Equal(t, []string{"alpha", "zeta"}, tagged.Tags)
+}
+
+func TestTags_BulkTagMissingImage(t *testing.T) {
+	tag := GenerateUniqueSlug("bulk-missing")
+	created := createTaggedImage(t)
+
+	// The batch is all or nothing
+	resp := MakeRequest(t, "POST", "/images/tags", BulkTagRequest{
+		ImageIDs: []string{created.ID, "non-existent-image-id"},
+		Tags:     []string{tag},
+	})
+	defer resp.Body.Close()
+	require.Equal(t, http.StatusNotFound, resp.StatusCode)
+	assert.Empty(t, imagesWithTag(t, tag))
+}
+
+func TestTags_BulkTagValidation(t *testing.T) {
+	resp := MakeRequest(t, "POST", "/images/tags", BulkTagRequest{
+		ImageIDs: []string{"some-image-id"},
+		Tags:     []string{"   "},
+	})
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusBadRequest)
+}
+
+func TestTags_BulkTagTooManyImages(t *testing.T) {
+	ids := make([]string, 501)
+	for i := range ids {
+		ids[i] = fmt.Sprintf("image-%d", i)
+	}
+
+	resp := MakeRequest(t, "POST", "/images/tags", BulkTagRequest{
+		ImageIDs: ids,
+		Tags:     []string{"python"},
+	})
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusBadRequest)
+}
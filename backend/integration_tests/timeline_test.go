@@ -2,6 +2,7 @@ package integration_tests
 
 import (
 	"backend/internal/http/mapper"
+	"backend/internal/platform/auth"
 	"net/http"
 	"testing"
 
@@ -274,3 +275,337 @@ func TestTimeline_ChronologicalOrder(t *testing.T) {
 	}
 	assert.Equal(t, 3, foundCount, "Should find all 3 entries with different dates")
 }
+
+func TestTimeline_DraftHiddenFromAnonymousReaders(t *testing.T) {
+	// Requests in this suite carry no token, so they are anonymous readers
+	createReq := mapper.CreateTimelineEntryRequest{
+		Name:     "Draft Entry",
+		Text:     "Waiting for an editor",
+		Location: "São Carlos, SP",
+		Date:     time.Date(2023, 5, 20, 19, 0, 0, 0, time.UTC).Format(time.RFC3339),
+	}
+
+	resp := MakeRequest(t, "POST", "/timelineentries", createReq)
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+
+	var created mapper.TimelineEntryResponse
+	ParseJSONResponse(t, resp, &created)
+	assert.False(t, created.Draft, "Entries written by editors are published")
+
+	// Cleanup
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/timelineentries/"+created.ID, nil)
+		resp.Body.Close()
+	}()
+
+	// Turn it into a draft
+	resp = MakeRequest(t, "PUT", "/timelineentries/"+created.ID+"/draft", mapper.SetTimelineEntryDraftRequest{Draft: true})
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var draft mapper.TimelineEntryResponse
+	ParseJSONResponse(t, resp, &draft)
+	assert.True(t, draft.Draft)
+
+	// Anonymous readers can't fetch drafts
+	resp = MakeRequest(t, "GET", "/timelineentries/"+created.ID, nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+
+	// Nor see them listed
+	resp = MakeRequest(t, "GET", "/timelineentries", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var entries []mapper.TimelineEntryResponse
+	ParseJSONResponse(t, resp, &entries)
+	for _, entry := range entries {
+		assert.NotEqual(t, created.ID, entry.ID, "Drafts should not be listed for anonymous readers")
+	}
+
+	// Publishing makes it visible again
+	resp = MakeRequest(t, "PUT", "/timelineentries/"+created.ID+"/draft", mapper.SetTimelineEntryDraftRequest{Draft: false})
+	AssertStatusCode(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	resp = MakeRequest(t, "GET", "/timelineentries/"+created.ID, nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+	resp.Body.Close()
+}
+
+func TestTimeline_DraftsOnlyShownToTimelineEditors(t *testing.T) {
+	adminToken := RequireAdminToken(t)
+
+	resp := MakeAuthenticatedRequest(t, "POST", "/timelineentries", adminToken, mapper.CreateTimelineEntryRequest{
+		Name:     "Draft for editors",
+		Text:     "Waiting for an editor",
+		Location: "São Carlos, SP",
+		Date:     time.Date(2023, 5, 21, 19, 0, 0, 0, time.UTC).Format(time.RFC3339),
+	})
+	require.Equal(t, http.StatusCreated, resp.StatusCode)
+	var created mapper.TimelineEntryResponse
+	ParseJSONResponse(t, resp, &created)
+
+	defer func() {
+		resp := MakeAuthenticatedRequest(t, "DELETE", "/timelineentries/"+created.ID, adminToken, nil)
+		resp.Body.Close()
+	}()
+
+	resp = MakeAuthenticatedRequest(t, "PUT", "/timelineentries/"+created.ID+"/draft", adminToken, mapper.SetTimelineEntryDraftRequest{Draft: true})
+	AssertStatusCode(t, resp, http.StatusOK)
+	resp.Body.Close()
+
+	// Signed in users whose role can't edit the timeline don't see drafts
+	for _, role := range []auth.Role{auth.RoleViewer, auth.RolePhotographer} {
+		resp = MakeAuthenticatedRequest(t, "GET", "/timelineentries/"+created.ID, auth.FakeToken("integration-"+string(role), role), nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusNotFound, resp.StatusCode, "Drafts should be hidden from %s users", role)
+	}
+
+	// Editors do
+	resp = MakeAuthenticatedRequest(t, "GET", "/timelineentries/"+created.ID, auth.FakeToken("integration-editor", auth.RoleEditor), nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusOK, resp.StatusCode)
+}
+
+func TestTimeline_SetDraftNotFound(t *testing.T) {
+	resp := MakeRequest(t, "PUT", "/timelineentries/non-existent-id/draft", mapper.SetTimelineEntryDraftRequest{Draft: false})
+	defer resp.Body.Close()
+	assert.Equal(t, http.StatusNotFound, resp.StatusCode)
+}
+
+func TestTimeline_CategoryAndImportance(t *testing.T) {
+	createReq := mapper.CreateTimelineEntryRequest{
+		Name:       "Python Brasil Award",
+		Text:       "Recognized by the Python community",
+		Date:       time.Date(2019, 10, 25, 12, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		Category:   "award",
+		Importance: 3,
+	}
+
+	resp := MakeRequest(t, "POST", "/timelineentries", createReq)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var created mapper.TimelineEntryResponse
+	ParseJSONResponse(t, resp, &created)
+	assert.Equal(t, "award", created.Category)
+	assert.Equal(t, 3, created.Importance)
+
+	// Cleanup
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/timelineentries/"+created.ID, nil)
+		resp.Body.Close()
+	}()
+
+	// Importance defaults to normal
+	plain := mapper.CreateTimelineEntryRequest{
+		Name: "Plain Entry",
+		Text: "No category",
+		Date: time.Date(2019, 3, 1, 12, 0, 0, 0, time.UTC).Format(time.RFC3339),
+	}
+	resp = MakeRequest(t, "POST", "/timelineentries", plain)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var plainCreated mapper.TimelineEntryResponse
+	ParseJSONResponse(t, resp, &plainCreated)
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/timelineentries/"+plainCreated.ID, nil)
+		resp.Body.Close()
+	}()
+	assert.Equal(t, 2, plainCreated.Importance)
+	assert.Empty(t, plainCreated.Category)
+
+	// Unknown categories and importance out of range are rejected
+	for _, invalid := range []mapper.CreateTimelineEntryRequest{
+		{Name: "Bad", Text: "Bad", Date: createReq.Date, Category: "party"},
+		{Name: "Bad", Text: "Bad", Date: createReq.Date, Importance: 9},
+	} {
+		resp = MakeRequest(t, "POST", "/timelineentries", invalid)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	}
+}
+
+func TestTimeline_FilterByRangeAndCategory(t *testing.T) {
+	var createdIDs []string
+	for _, req := range []mapper.CreateTimelineEntryRequest{
+		{Name: "Range Meetup 1998", Text: "Before the range", Date: "1998-06-01T12:00:00Z", Category: "meetup"},
+		{Name: "Range Meetup 1999", Text: "In the range", Date: "1999-06-01T12:00:00Z", Category: "meetup"},
+		{Name: "Range Conference 1999", Text: "In the range", Date: "1999-09-01T12:00:00Z", Category: "conference"},
+	} {
+		resp := MakeRequest(t, "POST", "/timelineentries", req)
+		AssertStatusCode(t, resp, http.StatusCreated)
+
+		var created mapper.TimelineEntryResponse
+		ParseJSONResponse(t, resp, &created)
+		createdIDs = append(createdIDs, created.ID)
+	}
+
+	// Cleanup
+	defer func() {
+		for _, id := range createdIDs {
+			resp := MakeRequest(t, "DELETE", "/timelineentries/"+id, nil)
+			resp.Body.Close()
+		}
+	}()
+
+	resp := MakeRequest(t, "GET", "/timelineentries?from=1999-01-01&to=1999-12-31&category=meetup", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var entries []mapper.TimelineEntryResponse
+	ParseJSONResponse(t, resp, &entries)
+	require.Len(t, entries, 1)
+	assert.Equal(t, createdIDs[1], entries[0].ID)
+
+	// Invalid filters
+	for _, query := range []string{"from=yesterday", "from=2000-01-01&to=1999-01-01", "category=party"} {
+		resp := MakeRequest(t, "GET", "/timelineentries?"+query, nil)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, query)
+	}
+}
+
+func TestTimeline_Grouped(t *testing.T) {
+	var createdIDs []string
+	for _, date := range []string{"1987-02-01T12:00:00Z", "1987-08-01T12:00:00Z", "1989-05-01T12:00:00Z"} {
+		req := mapper.CreateTimelineEntryRequest{Name: "Grouped " + date, Text: "Grouped entry", Date: date, Category: "milestone"}
+		resp := MakeRequest(t, "POST", "/timelineentries", req)
+		AssertStatusCode(t, resp, http.StatusCreated)
+
+		var created mapper.TimelineEntryResponse
+		ParseJSONResponse(t, resp, &created)
+		createdIDs = append(createdIDs, created.ID)
+	}
+
+	// Cleanup
+	defer func() {
+		for _, id := range createdIDs {
+			resp := MakeRequest(t, "DELETE", "/timelineentries/"+id, nil)
+			resp.Body.Close()
+		}
+	}()
+
+	t.Run("by year", func(t *testing.T) {
+		resp := MakeRequest(t, "GET", "/timelineentries/grouped?from=1980-01-01&to=1989-12-31", nil)
+		AssertStatusCode(t, resp, http.StatusOK)
+
+		var grouped mapper.TimelineGroupsResponse
+		ParseJSONResponse(t, resp, &grouped)
+		assert.Equal(t, "year", grouped.By, "Grouped by year by default")
+		assert.Equal(t, 3, grouped.Count)
+		require.Len(t, grouped.Groups, 2, "Years without entries are left out")
+		assert.Equal(t, "1987", grouped.Groups[0].Key)
+		assert.Equal(t, 2, grouped.Groups[0].Count)
+		assert.Len(t, grouped.Groups[0].Entries, 2)
+		assert.Equal(t, "1989", grouped.Groups[1].Key)
+	})
+
+	t.Run("by decade", func(t *testing.T) {
+		resp := MakeRequest(t, "GET", "/timelineentries/grouped?by=decade&from=1980-01-01&to=1989-12-31", nil)
+		AssertStatusCode(t, resp, http.StatusOK)
+
+		var grouped mapper.TimelineGroupsResponse
+		ParseJSONResponse(t, resp, &grouped)
+		require.Len(t, grouped.Groups, 1)
+		assert.Equal(t, "1980s", grouped.Groups[0].Key)
+		assert.Equal(t, 3, grouped.Groups[0].Count)
+	})
+
+	t.Run("unknown grouping", func(t *testing.T) {
+		resp := MakeRequest(t, "GET", "/timelineentries/grouped?by=month", nil)
+		defer resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+	})
+}
+
+func TestTimeline_ImagesAndLinks(t *testing.T) {
+	// Create the photo of the entry
+	resp := MakeRequest(t, "POST", "/images", CreateImageRequest{
+		Slug: GenerateUniqueSlug("timeline-photo"),
+		Name: "Timeline Photo",
+		Text: "Photo of a milestone",
+		Data: TinyPNG,
+	})
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var image ImageResponse
+	ParseJSONResponse(t, resp, &image)
+	imageDeleted := false
+	defer func() {
+		if !imageDeleted {
+			resp := MakeRequest(t, "DELETE", "/images/"+image.ID, nil)
+			resp.Body.Close()
+		}
+	}()
+
+	createReq := mapper.CreateTimelineEntryRequest{
+		Name:     "First PyLadies Meetup",
+		Text:     "With photos and slides",
+		Date:     time.Date(2018, 3, 8, 19, 0, 0, 0, time.UTC).Format(time.RFC3339),
+		ImageIDs: []string{image.ID},
+		Links:    []mapper.TimelineLink{{Title: "Slides", URL: "https://example.com/slides"}},
+	}
+	resp = MakeRequest(t, "POST", "/timelineentries", createReq)
+	AssertStatusCode(t, resp, http.StatusCreated)
+
+	var created mapper.TimelineEntryResponse
+	ParseJSONResponse(t, resp, &created)
+	defer func() {
+		resp := MakeRequest(t, "DELETE", "/timelineentries/"+created.ID, nil)
+		resp.Body.Close()
+	}()
+	assert.Equal(t, []string{image.ID}, created.ImageIDs)
+	assert.Equal(t, createReq.Links, created.Links)
+	assert.Nil(t, created.Images, "Images are only expanded on request")
+
+	// Expanded into full images
+	resp = MakeRequest(t, "GET", "/timelineentries/"+created.ID+"?expand=images", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var expanded mapper.TimelineEntryResponse
+	ParseJSONResponse(t, resp, &expanded)
+	require.Len(t, expanded.Images, 1)
+	assert.Equal(t, image.ID, expanded.Images[0].ID)
+	assert.NotEmpty(t, expanded.Images[0].ObjectURL)
+
+	resp = MakeRequest(t, "GET", "/timelineentries?expand=images&from=2018-03-08&to=2018-03-08", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var listed []mapper.TimelineEntryResponse
+	ParseJSONResponse(t, resp, &listed)
+	for _, entry := range listed {
+		if entry.ID == created.ID {
+			assert.Len(t, entry.Images, 1)
+		}
+	}
+
+	// Deleting the image removes its reference
+	resp = MakeRequest(t, "DELETE", "/images/"+image.ID, nil)
+	resp.Body.Close()
+	require.Equal(t, http.StatusNoContent, resp.StatusCode)
+	imageDeleted = true
+
+	resp = MakeRequest(t, "GET", "/timelineentries/"+created.ID+"?expand=images", nil)
+	AssertStatusCode(t, resp, http.StatusOK)
+
+	var cleaned mapper.TimelineEntryResponse
+	ParseJSONResponse(t, resp, &cleaned)
+	assert.Empty(t, cleaned.ImageIDs)
+	assert.NotNil(t, cleaned.Images)
+	assert.Empty(t, cleaned.Images)
+}
+
+func TestTimeline_ImagesAndLinksValidation(t *testing.T) {
+	date := time.Date(2018, 1, 1, 12, 0, 0, 0, time.UTC).Format(time.RFC3339)
+
+	for name, req := range map[string]mapper.CreateTimelineEntryRequest{
+		"missing image": {Name: "Bad", Text: "Bad", Date: date, ImageIDs: []string{"non-existent-image"}},
+		"invalid link":  {Name: "Bad", Text: "Bad", Date: date, Links: []mapper.TimelineLink{{Title: "Bad", URL: "javascript:alert(1)"}}},
+	} {
+		resp := MakeRequest(t, "POST", "/timelineentries", req)
+		resp.Body.Close()
+		assert.Equal(t, http.StatusBadRequest, resp.StatusCode, name)
+	}
+
+	resp := MakeRequest(t, "GET", "/timelineentries?expand=tracks", nil)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusBadRequest, resp.StatusCode)
+}
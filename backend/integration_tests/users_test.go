@@ -0,0 +1,33 @@
+package integration_tests
+
+import (
+	"backend/internal/http/mapper"
+	"net/http"
+	"testing"
+)
+
+func TestUsers_SetUnknownRole(t *testing.T) {
+	token := RequireAdminToken(t)
+
+	resp := MakeAuthenticatedRequest(t, "PUT", "/users/any-uid/role", token, mapper.SetUserRoleRequest{Role: "owner"})
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusBadRequest)
+}
+
+func TestUsers_NotFound(t *testing.T) {
+	token := RequireAdminToken(t)
+
+	resp := MakeAuthenticatedRequest(t, "GET", "/users/non-existent-uid-12345", token, nil)
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusNotFound)
+
+	resp = MakeAuthenticatedRequest(t, "PUT", "/users/non-existent-uid-12345/role", token, mapper.SetUserRoleRequest{Role: "editor"})
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusNotFound)
+}
+
+func TestUsers_AnonymousCallerCantSetRole(t *testing.T) {
+	resp := MakeRequest(t, "PUT", "/users/any-uid/role", mapper.SetUserRoleRequest{Role: "admin"})
+	defer resp.Body.Close()
+	AssertStatusCode(t, resp, http.StatusUnauthorized)
+}
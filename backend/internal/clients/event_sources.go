@@ -0,0 +1,312 @@
+package clients
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"backend/configs"
+	"backend/internal/entities"
+	"backend/internal/platform/ical"
+	"backend/internal/server"
+)
+
+// Compile-time interface checks
+var (
+	_ server.EventSourcePort = (*openEventSource)(nil)
+	_ server.EventSourcePort = (*icalEventSource)(nil)
+	_ server.EventSourcePort = (*yamlEventSource)(nil)
+)
+
+// EventSourceFactory creates an event source from its configuration
+type EventSourceFactory func(cfg configs.EventSourceConfig) (server.EventSourcePort, error)
+
+var (
+	eventSourceFactoriesMu sync.RWMutex
+	eventSourceFactories   = map[string]EventSourceFactory{
+		"openevent": newOpenEventSource,
+		"ical":      newICalEventSource,
+		"yaml":      newYAMLEventSource,
+	}
+)
+
+// RegisterEventSourceType makes a new type of event source available to the configuration
+// Registering a type twice replaces the previous factory
+func RegisterEventSourceType(sourceType string, factory EventSourceFactory) {
+	eventSourceFactoriesMu.Lock()
+	defer eventSourceFactoriesMu.Unlock()
+	eventSourceFactories[sourceType] = factory
+}
+
+// NewEventSource creates the event source described by cfg using the factory registered for its type
+func NewEventSource(cfg configs.EventSourceConfig) (server.EventSourcePort, error) {
+	eventSourceFactoriesMu.RLock()
+	factory, ok := eventSourceFactories[cfg.Type]
+	eventSourceFactoriesMu.RUnlock()
+
+	if !ok {
+		return nil, fmt.Errorf("event source %s has unknown type %q", cfg.Name, cfg.Type)
+	}
+	return factory(cfg)
+}
+
+// sourceTimeout returns the time allowed to read a source, 10 seconds when it isn't configured
+func sourceTimeout(cfg configs.EventSourceConfig) time.Duration {
+	if cfg.TimeoutSeconds <= 0 {
+		return 10 * time.Second
+	}
+	return time.Duration(cfg.TimeoutSeconds) * time.Second
+}
+
+// queryEvents applies the filters, order and page of query to events read in full
+func queryEvents(events []entities.Event, query entities.EventQuery, now time.Time) ([]entities.Event, int) {
+	matching := make([]entities.Event, 0, len(events))
+	for _, event := range events {
+		if query.Matches(event, now) {
+			matching = append(matching, event)
+		}
+	}
+	entities.SortEvents(matching, query.OrderBy, query.Desc)
+
+	page := max(query.Page, 1)
+	start := min((page-1)*query.Limit, len(matching))
+	end := len(matching)
+	if query.Limit > 0 {
+		end = min(start+query.Limit, len(matching))
+	}
+	return matching[start:end], len(matching)
+}
+
+// =======================
+// OPEN EVENT
+// =======================
+
+// openEventSource reads the events of another Open Event server, e.g. a partner community instance
+type openEventSource struct {
+	name   string
+	client *eventsClient
+	webURL string
+}
+
+func newOpenEventSource(cfg configs.EventSourceConfig) (server.EventSourcePort, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("open event source %s needs the url of its API", cfg.Name)
+	}
+
+	return &openEventSource{
+		name: cfg.Name,
+		client: &eventsClient{
+			httpClient: &http.Client{Timeout: sourceTimeout(cfg)},
+			baseURL:    strings.TrimSuffix(cfg.URL, "/"),
+		},
+		webURL: strings.TrimSuffix(cfg.WebURL, "/"),
+	}, nil
+}
+
+func (s *openEventSource) Name() string {
+	return s.name
+}
+
+// GetEvents fetches a page of events from the Open Event API, linking them to the event pages of its site
+func (s *openEventSource) GetEvents(ctx context.Context, query entities.EventQuery) ([]entities.Event, int, error) {
+	events, count, err := s.client.GetEvents(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	if s.webURL != "" {
+		for i := range events {
+			events[i].Link = fmt.Sprintf("%s/e/%s", s.webURL, events[i].ID)
+		}
+	}
+	return events, count, nil
+}
+
+// =======================
+// ICAL
+// =======================
+
+// icalEventSource reads the events of an iCalendar feed
+type icalEventSource struct {
+	name       string
+	url        string
+	httpClient *http.Client
+}
+
+func newICalEventSource(cfg configs.EventSourceConfig) (server.EventSourcePort, error) {
+	if cfg.URL == "" {
+		return nil, fmt.Errorf("ical source %s needs the url of its feed", cfg.Name)
+	}
+
+	return &icalEventSource{
+		name:       cfg.Name,
+		url:        cfg.URL,
+		httpClient: &http.Client{Timeout: sourceTimeout(cfg)},
+	}, nil
+}
+
+func (s *icalEventSource) Name() string {
+	return s.name
+}
+
+// GetEvents downloads the feed and returns a page of its events matching query
+func (s *icalEventSource) GetEvents(ctx context.Context, query entities.EventQuery) ([]entities.Event, int, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Accept", "text/calendar")
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to fetch calendar: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("calendar returned status %d", resp.StatusCode)
+	}
+
+	cal, err := ical.Decode(resp.Body)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to decode calendar: %w", err)
+	}
+
+	events := make([]entities.Event, 0, len(cal.Events))
+	for _, ev := range cal.Events {
+		// Events without UID or start can't be deduplicated nor placed in the list
+		if ev.UID == "" || ev.Start.IsZero() {
+			continue
+		}
+		events = append(events, mapICalEvent(ev))
+	}
+
+	page, count := queryEvents(events, query, time.Now())
+	return page, count, nil
+}
+
+// mapICalEvent maps a VEVENT to our Event entity, published feeds only hold published public events
+func mapICalEvent(ev ical.Event) entities.Event {
+	event := entities.Event{
+		ID:           ev.UID,
+		Identifier:   ev.UID,
+		Name:         ev.Summary,
+		Description:  ev.Description,
+		StartsAt:     ev.Start,
+		EndsAt:       ev.End,
+		LocationName: ev.Location,
+		Privacy:      "public",
+		State:        "published",
+		CreatedAt:    ev.Stamp,
+		Link:         ev.URL,
+	}
+	if loc := ev.Start.Location(); loc != time.UTC && loc != time.Local {
+		event.Timezone = loc.String()
+	}
+	return event
+}
+
+// =======================
+// YAML
+// =======================
+
+// yamlEventSource reads events listed by hand in a YAML file
+// The file is read on every request, edits are served without a restart
+type yamlEventSource struct {
+	name string
+	path string
+}
+
+// yamlEventFile is the layout of a YAML event source
+//
+//	events:
+//	  - id: pybr-2025
+//	    name: Python Brasil 2025
+//	    starts_at: 2025-10-21T09:00:00-03:00
+//	    ends_at: 2025-10-25T18:00:00-03:00
+//	    timezone: America/Sao_Paulo
+//	    location: São Paulo
+//	    link: https://2025.pythonbrasil.org.br
+type yamlEventFile struct {
+	Events []yamlEvent `yaml:"events"`
+}
+
+type yamlEvent struct {
+	ID          string    `yaml:"id"`
+	Name        string    `yaml:"name"`
+	Description string    `yaml:"description"`
+	StartsAt    time.Time `yaml:"starts_at"`
+	EndsAt      time.Time `yaml:"ends_at"`
+	Timezone    string    `yaml:"timezone"`
+	Location    string    `yaml:"location"`
+	Link        string    `yaml:"link"`
+	LogoURL     string    `yaml:"logo_url"`
+	State       string    `yaml:"state"` // Defaults to "published"
+}
+
+func newYAMLEventSource(cfg configs.EventSourceConfig) (server.EventSourcePort, error) {
+	if cfg.Path == "" {
+		return nil, fmt.Errorf("yaml source %s needs the path of its file", cfg.Name)
+	}
+
+	return &yamlEventSource{name: cfg.Name, path: cfg.Path}, nil
+}
+
+func (s *yamlEventSource) Name() string {
+	return s.name
+}
+
+// GetEvents reads the file and returns a page of its events matching query
+func (s *yamlEventSource) GetEvents(ctx context.Context, query entities.EventQuery) ([]entities.Event, int, error) {
+	data, err := os.ReadFile(s.path)
+	if err != nil {
+		return nil, 0, fmt.Errorf("failed to read events file: %w", err)
+	}
+
+	var file yamlEventFile
+	if err := yaml.Unmarshal(data, &file); err != nil {
+		return nil, 0, fmt.Errorf("failed to parse events file %s: %w", s.path, err)
+	}
+
+	events := make([]entities.Event, 0, len(file.Events))
+	for i, ev := range file.Events {
+		if ev.ID == "" || ev.Name == "" || ev.StartsAt.IsZero() {
+			return nil, 0, fmt.Errorf("event %d of %s needs an id, a name and a start", i, s.path)
+		}
+		events = append(events, mapYAMLEvent(ev))
+	}
+
+	page, count := queryEvents(events, query, time.Now())
+	return page, count, nil
+}
+
+// mapYAMLEvent maps an event of a YAML source to our Event entity, events without end last until they start
+func mapYAMLEvent(ev yamlEvent) entities.Event {
+	event := entities.Event{
+		ID:           ev.ID,
+		Identifier:   ev.ID,
+		Name:         ev.Name,
+		Description:  ev.Description,
+		StartsAt:     ev.StartsAt,
+		EndsAt:       ev.EndsAt,
+		Timezone:     ev.Timezone,
+		LocationName: ev.Location,
+		LogoURL:      ev.LogoURL,
+		Privacy:      "public",
+		State:        ev.State,
+		Link:         ev.Link,
+	}
+	if event.EndsAt.IsZero() {
+		event.EndsAt = event.StartsAt
+	}
+	if event.State == "" {
+		event.State = "published"
+	}
+	return event
+}
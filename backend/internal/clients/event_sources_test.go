@@ -0,0 +1,166 @@
+package clients
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"backend/configs"
+	"backend/internal/entities"
+	"backend/internal/server"
+)
+
+// TestNewEventSource creates sources through the registered factories
+func TestNewEventSource(t *testing.T) {
+	source, err := NewEventSource(configs.EventSourceConfig{Name: "pybr", Type: "ical", URL: "https://python.org.br/events.ics"})
+	require.NoError(t, err)
+	assert.Equal(t, "pybr", source.Name())
+
+	_, err = NewEventSource(configs.EventSourceConfig{Name: "pybr", Type: "meetup"})
+	assert.ErrorContains(t, err, "unknown type")
+
+	for _, sourceType := range []string{"openevent", "ical", "yaml"} {
+		_, err = NewEventSource(configs.EventSourceConfig{Name: "empty", Type: sourceType})
+		assert.Error(t, err, "%s source without url or path", sourceType)
+	}
+}
+
+// TestRegisterEventSourceType plugs a new type of source
+func TestRegisterEventSourceType(t *testing.T) {
+	RegisterEventSourceType("test-static", func(cfg configs.EventSourceConfig) (server.EventSourcePort, error) {
+		return &yamlEventSource{name: cfg.Name, path: cfg.Path}, nil
+	})
+
+	source, err := NewEventSource(configs.EventSourceConfig{Name: "static", Type: "test-static"})
+	require.NoError(t, err)
+	assert.Equal(t, "static", source.Name())
+}
+
+// TestICalEventSource_GetEvents reads a recorded iCal feed
+func TestICalEventSource_GetEvents(t *testing.T) {
+	fake := httptest.NewServer(http.FileServer(http.Dir(filepath.Join("testdata", "event_sources"))))
+	defer fake.Close()
+
+	source, err := NewEventSource(configs.EventSourceConfig{Name: "pybr", Type: "ical", URL: fake.URL + "/pybr.ics"})
+	require.NoError(t, err)
+
+	t.Run("all events", func(t *testing.T) {
+		events, count, err := source.GetEvents(context.Background(), entities.EventQuery{Limit: 10, Page: 1})
+		require.NoError(t, err)
+		assert.Equal(t, 3, count, "Events without UID are skipped")
+		require.Len(t, events, 3)
+
+		// Sorted by start
+		assert.Equal(t, "PyLadies Day", events[0].Name)
+		assert.Equal(t, "Django Girls Campinas", events[1].Name)
+
+		pybr := events[2]
+		assert.Equal(t, "pybr-2025@python.org.br", pybr.ID)
+		assert.Equal(t, "Python Brasil 2025", pybr.Name)
+		assert.Equal(t, "São Paulo", pybr.LocationName)
+		assert.Equal(t, "https://2025.pythonbrasil.org.br", pybr.Link)
+		assert.Equal(t, "America/Sao_Paulo", pybr.Timezone)
+		assert.Equal(t, "published", pybr.State)
+		assert.True(t, parseTime(t, "2025-10-21T12:00:00Z").Equal(pybr.StartsAt))
+	})
+
+	t.Run("filtered page", func(t *testing.T) {
+		events, count, err := source.GetEvents(context.Background(), entities.EventQuery{
+			Limit:  1,
+			Page:   2,
+			Desc:   true,
+			From:   parseTime(t, "2025-04-01T00:00:00Z"),
+			Search: "PYTHON",
+		})
+		require.NoError(t, err)
+		assert.Equal(t, 1, count)
+		assert.Empty(t, events, "The only match is on the first page")
+	})
+
+	t.Run("unavailable", func(t *testing.T) {
+		missing, err := NewEventSource(configs.EventSourceConfig{Name: "pybr", Type: "ical", URL: fake.URL + "/missing.ics"})
+		require.NoError(t, err)
+
+		_, _, err = missing.GetEvents(context.Background(), entities.EventQuery{Limit: 10, Page: 1})
+		assert.ErrorContains(t, err, "status 404")
+	})
+}
+
+// TestYAMLEventSource_GetEvents reads events listed in a YAML file
+func TestYAMLEventSource_GetEvents(t *testing.T) {
+	source, err := NewEventSource(configs.EventSourceConfig{
+		Name: "partners",
+		Type: "yaml",
+		Path: filepath.Join("testdata", "event_sources", "partners.yaml"),
+	})
+	require.NoError(t, err)
+
+	events, count, err := source.GetEvents(context.Background(), entities.EventQuery{Limit: 10, Page: 1, State: "published"})
+	require.NoError(t, err)
+	assert.Equal(t, 2, count)
+	require.Len(t, events, 2)
+
+	assert.Equal(t, "pyrp-meetup", events[0].ID)
+	assert.True(t, events[0].EndsAt.Equal(events[0].StartsAt), "Events without end last until they start")
+
+	caipira := events[1]
+	assert.Equal(t, "PyCaipira 2025", caipira.Name)
+	assert.Equal(t, "Campinas", caipira.LocationName)
+	assert.Equal(t, "https://pycaipira.org", caipira.Link)
+	assert.True(t, caipira.StartsAt.Equal(time.Date(2025, 8, 16, 12, 0, 0, 0, time.UTC)))
+
+	// Every event of the file is in the past
+	upcoming, count, err := source.GetEvents(context.Background(), entities.EventQuery{Limit: 10, Page: 1, Upcoming: true})
+	require.NoError(t, err)
+	assert.Zero(t, count)
+	assert.Empty(t, upcoming)
+}
+
+// TestYAMLEventSource_Invalid reports unreadable files and incomplete events
+func TestYAMLEventSource_Invalid(t *testing.T) {
+	for name, path := range map[string]string{
+		"missing file":     filepath.Join("testdata", "event_sources", "missing.yaml"),
+		"incomplete event": filepath.Join("testdata", "event_sources", "invalid.yaml"),
+	} {
+		t.Run(name, func(t *testing.T) {
+			source, err := NewEventSource(configs.EventSourceConfig{Name: "partners", Type: "yaml", Path: path})
+			require.NoError(t, err)
+
+			_, _, err = source.GetEvents(context.Background(), entities.EventQuery{Limit: 10, Page: 1})
+			assert.Error(t, err)
+		})
+	}
+}
+
+// TestOpenEventSource_GetEvents links the events of another Open Event server to its site
+func TestOpenEventSource_GetEvents(t *testing.T) {
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/events", r.URL.Path)
+
+		w.Header().Set("Content-Type", jsonAPIAccept)
+		w.Write([]byte(`{"meta": {"count": 1}, "data": [{"type": "event", "id": "7", "attributes": {
+			"name": "PyCon Amazônia", "starts-at": "2025-09-06T12:00:00Z", "ends-at": "2025-09-07T21:00:00Z",
+			"timezone": "America/Manaus", "identifier": "c0ffee", "privacy": "public", "state": "published"}}]}`))
+	}))
+	defer fake.Close()
+
+	source, err := NewEventSource(configs.EventSourceConfig{
+		Name:   "pyamazonia",
+		Type:   "openevent",
+		URL:    fake.URL + "/api/v1/",
+		WebURL: "https://eventos.pyamazonia.org/",
+	})
+	require.NoError(t, err)
+
+	events, count, err := source.GetEvents(context.Background(), entities.EventQuery{Limit: 10, Page: 1})
+	require.NoError(t, err)
+	assert.Equal(t, 1, count)
+	require.Len(t, events, 1)
+	assert.Equal(t, "https://eventos.pyamazonia.org/e/7", events[0].Link)
+}
@@ -1,6 +1,7 @@
 package clients
 
 import (
+	"bytes"
 	"context"
 	"encoding/json"
 	"fmt"
@@ -10,12 +11,16 @@ import (
 	"time"
 
 	"backend/internal/entities"
+	customerrors "backend/internal/platform/errors"
 	"backend/internal/server"
 )
 
 const (
 	grupyBaseURL  = "https://eventos.grupysanca.com.br/api/v1"
 	jsonAPIAccept = "application/vnd.api+json"
+
+	// maxPageSize is the largest page the Grupy API serves
+	maxPageSize = 100
 )
 
 // Compile-time interface check
@@ -25,7 +30,7 @@ var _ server.GrupyEventsPort = (*eventsClient)(nil)
 // Example: {"name":"starts-at","op":"lt","val":"2025-10-03T21:00:00Z"}
 type Filter struct {
 	Name string `json:"name"` // Field name (e.g., "starts-at", "ends-at", "name")
-	Op   string `json:"op"`   // Operator (e.g., "eq", "ne", "lt", "le", "gt", "ge", "like", "in")
+	Op   string `json:"op"`   // Operator (e.g., "eq", "ne", "lt", "le", "gt", "ge", "like", "ilike", "in")
 	Val  string `json:"val"`  // Value to compare against
 }
 
@@ -45,6 +50,11 @@ type jsonAPIResponse struct {
 	Data []jsonAPIEventData `json:"data"`
 }
 
+// jsonAPIEventResponse is the JSON:API document holding a single event
+type jsonAPIEventResponse struct {
+	Data jsonAPIEventData `json:"data"`
+}
+
 type jsonAPIEventData struct {
 	Type       string            `json:"type"`
 	ID         string            `json:"id"`
@@ -69,6 +79,112 @@ type jsonAPIEventAttrs struct {
 	CreatedAt         string  `json:"created-at"`
 }
 
+// jsonAPIDocument is a JSON:API document holding a page of a collection and the resources it includes
+type jsonAPIDocument struct {
+	Meta struct {
+		Count int `json:"count"`
+	} `json:"meta"`
+	Data     []jsonAPIResource `json:"data"`
+	Included []jsonAPIResource `json:"included"`
+}
+
+// jsonAPIResource is a resource of any type, its attributes are decoded once the type is known
+type jsonAPIResource struct {
+	Type          string                         `json:"type"`
+	ID            string                         `json:"id"`
+	Attributes    json.RawMessage                `json:"attributes"`
+	Relationships map[string]jsonAPIRelationship `json:"relationships"`
+}
+
+// jsonAPIRelationship holds the linkage of a relationship: null, one identifier or a list of them
+type jsonAPIRelationship struct {
+	Data json.RawMessage `json:"data"`
+}
+
+type jsonAPIIdentifier struct {
+	Type string `json:"type"`
+	ID   string `json:"id"`
+}
+
+// identifiers decodes the linkage of a relationship
+// Relationships left out of the include parameter have no linkage
+func (r jsonAPIRelationship) identifiers() []jsonAPIIdentifier {
+	raw := bytes.TrimSpace(r.Data)
+	if len(raw) == 0 || bytes.Equal(raw, []byte("null")) {
+		return nil
+	}
+
+	if raw[0] == '[' {
+		var ids []jsonAPIIdentifier
+		if err := json.Unmarshal(raw, &ids); err != nil {
+			return nil
+		}
+		return ids
+	}
+
+	var id jsonAPIIdentifier
+	if err := json.Unmarshal(raw, &id); err != nil {
+		return nil
+	}
+	return []jsonAPIIdentifier{id}
+}
+
+// jsonAPICollection is every page of a collection with the resources they include
+type jsonAPICollection struct {
+	Data     []jsonAPIResource
+	Included map[string]jsonAPIResource // Keyed by type and ID
+}
+
+// related returns the included resources a relationship of res points to
+func (c jsonAPICollection) related(res jsonAPIResource, name string) []jsonAPIResource {
+	var related []jsonAPIResource
+	for _, id := range res.Relationships[name].identifiers() {
+		if inc, ok := c.Included[id.Type+"/"+id.ID]; ok {
+			related = append(related, inc)
+		}
+	}
+	return related
+}
+
+type jsonAPITrackAttrs struct {
+	Name        string  `json:"name"`
+	Description *string `json:"description"`
+	Color       string  `json:"color"`
+}
+
+type jsonAPIMicrolocationAttrs struct {
+	Name  string `json:"name"`
+	Floor *int   `json:"floor"`
+}
+
+type jsonAPISessionAttrs struct {
+	Title         string  `json:"title"`
+	Subtitle      *string `json:"subtitle"`
+	ShortAbstract *string `json:"short-abstract"`
+	LongAbstract  *string `json:"long-abstract"`
+	StartsAt      *string `json:"starts-at"`
+	EndsAt        *string `json:"ends-at"`
+	Language      *string `json:"language"`
+	Level         *string `json:"level"`
+	State         string  `json:"state"`
+	SlidesURL     *string `json:"slides-url"`
+	VideoURL      *string `json:"video-url"`
+}
+
+type jsonAPISpeakerAttrs struct {
+	Name           string  `json:"name"`
+	PhotoURL       *string `json:"photo-url"`
+	ShortBiography *string `json:"short-biography"`
+	LongBiography  *string `json:"long-biography"`
+	Organisation   *string `json:"organisation"`
+	Position       *string `json:"position"`
+	Country        *string `json:"country"`
+	Website        *string `json:"website"`
+	Twitter        *string `json:"twitter"`
+	Github         *string `json:"github"`
+	Linkedin       *string `json:"linkedin"`
+}
+
 type eventsClient struct {
 	httpClient *http.Client
 	baseURL    string
@@ -82,24 +198,26 @@ func NewEventsClient() server.GrupyEventsPort {
 	}
 }
 
-// GetEvents fetches events from Grupy Sanca API
-func (c *eventsClient) GetEvents(ctx context.Context, limit int, orderBy string, desc bool) ([]entities.Event, error) {
+// GetEvents fetches a page of events from Grupy Sanca API and the number of events matching the query
+func (c *eventsClient) GetEvents(ctx context.Context, query entities.EventQuery) ([]entities.Event, int, error) {
 	// Build query parameters
 	params := queryParams{
-		Sort:     c.buildSortParam(orderBy, desc),
-		PageSize: limit,
+		Sort:       c.buildSortParam(query.OrderBy, query.Desc),
+		PageSize:   query.Limit,
+		PageNumber: query.Page,
+		Filters:    c.buildFilters(query, time.Now()),
 	}
 
 	// Build URL with query parameters
 	apiURL, err := c.buildEventsURL(params)
 	if err != nil {
-		return nil, fmt.Errorf("failed to build URL: %w", err)
+		return nil, 0, fmt.Errorf("failed to build URL: %w", err)
 	}
 
 	// Create request with context
 	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
 	if err != nil {
-		return nil, fmt.Errorf("failed to create request: %w", err)
+		return nil, 0, fmt.Errorf("failed to create request: %w", err)
 	}
 
 	// Set JSON:API headers
@@ -108,19 +226,19 @@ func (c *eventsClient) GetEvents(ctx context.Context, limit int, orderBy string,
 	// Execute request
 	resp, err := c.httpClient.Do(req)
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch events: %w", err)
+		return nil, 0, fmt.Errorf("failed to fetch events: %w", err)
 	}
 	defer resp.Body.Close()
 
 	// Check status code
 	if resp.StatusCode != http.StatusOK {
-		return nil, fmt.Errorf("API returned status %d", resp.StatusCode)
+		return nil, 0, fmt.Errorf("API returned status %d", resp.StatusCode)
 	}
 
 	// Parse JSON:API response
 	var apiResp jsonAPIResponse
 	if err := json.NewDecoder(resp.Body).Decode(&apiResp); err != nil {
-		return nil, fmt.Errorf("failed to decode response: %w", err)
+		return nil, 0, fmt.Errorf("failed to decode response: %w", err)
 	}
 
 	// Map to entities
@@ -134,7 +252,140 @@ func (c *eventsClient) GetEvents(ctx context.Context, limit int, orderBy string,
 		events = append(events, event)
 	}
 
-	return events, nil
+	return events, apiResp.Meta.Count, nil
+}
+
+// GetEventByID fetches a single event from Grupy Sanca API
+func (c *eventsClient) GetEventByID(ctx context.Context, id string) (entities.Event, error) {
+	apiURL := c.baseURL + "/events/" + url.PathEscape(id)
+
+	var apiResp jsonAPIEventResponse
+	if err := c.getJSONAPI(ctx, apiURL, "event with id "+id, &apiResp); err != nil {
+		return entities.Event{}, err
+	}
+
+	return c.mapToEntity(apiResp.Data)
+}
+
+// GetEventTracks fetches the tracks of an event from Grupy Sanca API
+func (c *eventsClient) GetEventTracks(ctx context.Context, eventID string) ([]entities.Track, error) {
+	collection, err := c.getEventCollection(ctx, eventID, "tracks", "")
+	if err != nil {
+		return nil, err
+	}
+
+	tracks := make([]entities.Track, 0, len(collection.Data))
+	for _, res := range collection.Data {
+		track, err := c.mapToTrack(res)
+		if err != nil {
+			continue
+		}
+		tracks = append(tracks, track)
+	}
+
+	return tracks, nil
+}
+
+// GetEventSessions fetches the sessions of an event with their track, room and speakers
+func (c *eventsClient) GetEventSessions(ctx context.Context, eventID string) ([]entities.Session, error) {
+	collection, err := c.getEventCollection(ctx, eventID, "sessions", "track,microlocation,speakers")
+	if err != nil {
+		return nil, err
+	}
+
+	sessions := make([]entities.Session, 0, len(collection.Data))
+	for _, res := range collection.Data {
+		session, err := c.mapToSession(res, collection)
+		if err != nil {
+			// Skip sessions with malformed data, like GetEvents does for events
+			continue
+		}
+		sessions = append(sessions, session)
+	}
+
+	return sessions, nil
+}
+
+// GetEventSpeakers fetches the speakers of an event with the IDs of their sessions
+func (c *eventsClient) GetEventSpeakers(ctx context.Context, eventID string) ([]entities.Speaker, error) {
+	collection, err := c.getEventCollection(ctx, eventID, "speakers", "sessions")
+	if err != nil {
+		return nil, err
+	}
+
+	speakers := make([]entities.Speaker, 0, len(collection.Data))
+	for _, res := range collection.Data {
+		speaker, err := c.mapToSpeaker(res)
+		if err != nil {
+			continue
+		}
+		speakers = append(speakers, speaker)
+	}
+
+	return speakers, nil
+}
+
+// getEventCollection fetches every page of a collection nested under an event
+func (c *eventsClient) getEventCollection(ctx context.Context, eventID, name, include string) (jsonAPICollection, error) {
+	collection := jsonAPICollection{Included: make(map[string]jsonAPIResource)}
+
+	for page := 1; ; page++ {
+		query := url.Values{}
+		query.Set("page[size]", strconv.Itoa(maxPageSize))
+		query.Set("page[number]", strconv.Itoa(page))
+		if include != "" {
+			query.Set("include", include)
+		}
+		apiURL := fmt.Sprintf("%s/events/%s/%s?%s", c.baseURL, url.PathEscape(eventID), name, query.Encode())
+
+		var doc jsonAPIDocument
+		if err := c.getJSONAPI(ctx, apiURL, "event with id "+eventID, &doc); err != nil {
+			return jsonAPICollection{}, err
+		}
+
+		collection.Data = append(collection.Data, doc.Data...)
+		for _, res := range doc.Included {
+			collection.Included[res.Type+"/"+res.ID] = res
+		}
+
+		if len(doc.Data) == 0 || len(collection.Data) >= doc.Meta.Count {
+			return collection, nil
+		}
+	}
+}
+
+// getJSONAPI fetches a JSON:API document and decodes it into out
+// A 404 is reported as ErrNotFound for the resource described by what
+func (c *eventsClient) getJSONAPI(ctx context.Context, apiURL, what string, out any) error {
+	// Create request with context
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, apiURL, nil)
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+
+	// Set JSON:API headers
+	req.Header.Set("Accept", jsonAPIAccept)
+
+	// Execute request
+	resp, err := c.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to fetch %s: %w", what, err)
+	}
+	defer resp.Body.Close()
+
+	// Check status code
+	if resp.StatusCode == http.StatusNotFound {
+		return fmt.Errorf("%s not found: %w", what, customerrors.ErrNotFound)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("API returned status %d", resp.StatusCode)
+	}
+
+	// Parse JSON:API response
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("failed to decode response: %w", err)
+	}
+	return nil
 }
 
 // buildSortParam converts orderBy field and desc flag to API sort parameter
@@ -152,6 +403,35 @@ func (c *eventsClient) buildSortParam(orderBy string, desc bool) string {
 	return orderBy
 }
 
+// buildFilters translates the conditions of an event query to Grupy API filters, now decides what is upcoming
+func (c *eventsClient) buildFilters(query entities.EventQuery, now time.Time) []Filter {
+	var filters []Filter
+
+	if !query.From.IsZero() {
+		filters = append(filters, Filter{Name: "starts-at", Op: "ge", Val: query.From.UTC().Format(time.RFC3339)})
+	}
+	if !query.To.IsZero() {
+		filters = append(filters, Filter{Name: "starts-at", Op: "lt", Val: query.To.UTC().Format(time.RFC3339)})
+	}
+
+	// Events being held count as upcoming until they end
+	if query.Upcoming {
+		filters = append(filters, Filter{Name: "ends-at", Op: "ge", Val: now.UTC().Format(time.RFC3339)})
+	}
+	if query.Past {
+		filters = append(filters, Filter{Name: "ends-at", Op: "lt", Val: now.UTC().Format(time.RFC3339)})
+	}
+
+	if query.Search != "" {
+		filters = append(filters, Filter{Name: "name", Op: "ilike", Val: "%" + query.Search + "%"})
+	}
+	if query.State != "" {
+		filters = append(filters, Filter{Name: "state", Op: "eq", Val: query.State})
+	}
+
+	return filters
+}
+
 // buildEventsURL constructs the API URL with query parameters
 func (c *eventsClient) buildEventsURL(params queryParams) (string, error) {
 	endpoint := c.baseURL + "/events"
@@ -174,7 +454,7 @@ func (c *eventsClient) buildEventsURL(params queryParams) (string, error) {
 	// Add pagination
 	if params.PageSize > 0 {
 		if params.PageSize > 100 {
-			params.PageSize = 100 // API max limit
+			params.PageSize = maxPageSize // API max limit
 		}
 		queryParams.Set("page[size]", strconv.Itoa(params.PageSize))
 	}
@@ -249,4 +529,132 @@ func (c *eventsClient) mapToEntity(data jsonAPIEventData) (entities.Event, error
 	}
 
 	return event, nil
-}
\ No newline at end of file
+}
+
+// mapToTrack maps a JSON:API track resource to our Track entity
+func (c *eventsClient) mapToTrack(res jsonAPIResource) (entities.Track, error) {
+	var attrs jsonAPITrackAttrs
+	if err := json.Unmarshal(res.Attributes, &attrs); err != nil {
+		return entities.Track{}, fmt.Errorf("invalid track %s: %w", res.ID, err)
+	}
+
+	return entities.Track{
+		ID:          res.ID,
+		Name:        attrs.Name,
+		Description: stringValue(attrs.Description),
+		Color:       attrs.Color,
+	}, nil
+}
+
+// mapToMicrolocation maps a JSON:API microlocation resource to our Microlocation entity
+func (c *eventsClient) mapToMicrolocation(res jsonAPIResource) (entities.Microlocation, error) {
+	var attrs jsonAPIMicrolocationAttrs
+	if err := json.Unmarshal(res.Attributes, &attrs); err != nil {
+		return entities.Microlocation{}, fmt.Errorf("invalid microlocation %s: %w", res.ID, err)
+	}
+
+	room := entities.Microlocation{ID: res.ID, Name: attrs.Name}
+	if attrs.Floor != nil {
+		room.Floor = *attrs.Floor
+	}
+	return room, nil
+}
+
+// mapToSession maps a JSON:API session resource to our Session entity
+// Its track, microlocation and speakers are resolved from the resources included in the collection
+func (c *eventsClient) mapToSession(res jsonAPIResource, collection jsonAPICollection) (entities.Session, error) {
+	var attrs jsonAPISessionAttrs
+	if err := json.Unmarshal(res.Attributes, &attrs); err != nil {
+		return entities.Session{}, fmt.Errorf("invalid session %s: %w", res.ID, err)
+	}
+
+	session := entities.Session{
+		ID:            res.ID,
+		Title:         attrs.Title,
+		Subtitle:      stringValue(attrs.Subtitle),
+		ShortAbstract: stringValue(attrs.ShortAbstract),
+		LongAbstract:  stringValue(attrs.LongAbstract),
+		Language:      stringValue(attrs.Language),
+		Level:         stringValue(attrs.Level),
+		State:         attrs.State,
+		SlidesURL:     stringValue(attrs.SlidesURL),
+		VideoURL:      stringValue(attrs.VideoURL),
+	}
+
+	// Unscheduled sessions have no times
+	if attrs.StartsAt != nil {
+		startsAt, err := time.Parse(time.RFC3339, *attrs.StartsAt)
+		if err != nil {
+			return entities.Session{}, fmt.Errorf("invalid starts-at: %w", err)
+		}
+		session.StartsAt = startsAt
+	}
+	if attrs.EndsAt != nil {
+		endsAt, err := time.Parse(time.RFC3339, *attrs.EndsAt)
+		if err != nil {
+			return entities.Session{}, fmt.Errorf("invalid ends-at: %w", err)
+		}
+		session.EndsAt = endsAt
+	}
+
+	if related := collection.related(res, "track"); len(related) > 0 {
+		if track, err := c.mapToTrack(related[0]); err == nil {
+			session.Track = &track
+		}
+	}
+	if related := collection.related(res, "microlocation"); len(related) > 0 {
+		if room, err := c.mapToMicrolocation(related[0]); err == nil {
+			session.Microlocation = &room
+		}
+	}
+	for _, related := range collection.related(res, "speakers") {
+		speaker, err := c.mapToSpeaker(related)
+		if err != nil {
+			continue
+		}
+		session.Speakers = append(session.Speakers, entities.Speaker{
+			ID:       speaker.ID,
+			Name:     speaker.Name,
+			PhotoURL: speaker.PhotoURL,
+		})
+	}
+
+	return session, nil
+}
+
+// mapToSpeaker maps a JSON:API speaker resource to our Speaker entity
+func (c *eventsClient) mapToSpeaker(res jsonAPIResource) (entities.Speaker, error) {
+	var attrs jsonAPISpeakerAttrs
+	if err := json.Unmarshal(res.Attributes, &attrs); err != nil {
+		return entities.Speaker{}, fmt.Errorf("invalid speaker %s: %w", res.ID, err)
+	}
+
+	speaker := entities.Speaker{
+		ID:             res.ID,
+		Name:           attrs.Name,
+		PhotoURL:       stringValue(attrs.PhotoURL),
+		ShortBiography: stringValue(attrs.ShortBiography),
+		LongBiography:  stringValue(attrs.LongBiography),
+		Organisation:   stringValue(attrs.Organisation),
+		Position:       stringValue(attrs.Position),
+		Country:        stringValue(attrs.Country),
+		Website:        stringValue(attrs.Website),
+		Twitter:        stringValue(attrs.Twitter),
+		GitHub:         stringValue(attrs.Github),
+		LinkedIn:       stringValue(attrs.Linkedin),
+	}
+
+	for _, id := range res.Relationships["sessions"].identifiers() {
+		speaker.SessionIDs = append(speaker.SessionIDs, id.ID)
+	}
+
+	return speaker, nil
+}
+
+// stringValue dereferences an optional JSON:API attribute, null becomes empty
+func stringValue(p *string) string {
+	if p == nil {
+		return ""
+	}
+	return *p
+}
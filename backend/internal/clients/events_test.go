@@ -2,6 +2,11 @@ package clients
 
 import (
 	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
 	"testing"
 	"time"
 
@@ -9,6 +14,7 @@ import (
 	"github.com/stretchr/testify/require"
 
 	"backend/internal/entities"
+	customerrors "backend/internal/platform/errors"
 )
 
 // TestEventsClient_GetEvents_ContractValidation validates the contract with the real Grupy API
@@ -27,10 +33,11 @@ func TestEventsClient_GetEvents_ContractValidation(t *testing.T) {
 	defer cancel()
 
 	// Act
-	events, err := client.GetEvents(ctx, 10, "starts-at", false)
+	events, count, err := client.GetEvents(ctx, entities.EventQuery{Limit: 10, OrderBy: "starts-at"})
 
 	// Assert - Critical checks that should stop the test
 	require.NoError(t, err, "API call should not fail")
+	assert.GreaterOrEqual(t, count, len(events), "meta count should cover the returned page")
 	require.NotEmpty(t, events, "API should return at least one event - empty response indicates contract break")
 
 	// Validate structure of all events
@@ -132,7 +139,7 @@ func TestEventsClient_GetEvents_DifferentQueries(t *testing.T) {
 			ctx, cancel := context.WithTimeout(context.Background(), 15*time.Second)
 			defer cancel()
 
-			events, err := client.GetEvents(ctx, tt.limit, tt.orderBy, tt.desc)
+			events, _, err := client.GetEvents(ctx, entities.EventQuery{Limit: tt.limit, OrderBy: tt.orderBy, Desc: tt.desc})
 
 			if tt.wantErr {
 				assert.Error(t, err)
@@ -341,6 +348,251 @@ func TestEventsClient_BuildSortParam(t *testing.T) {
 	}
 }
 
+// TestEventsClient_BuildFilters tests the translation of event queries to Grupy API filters
+func TestEventsClient_BuildFilters(t *testing.T) {
+	client := &eventsClient{}
+	now := parseTime(t, "2025-06-01T12:00:00-03:00")
+
+	tests := []struct {
+		name  string
+		query entities.EventQuery
+		want  []Filter
+	}{
+		{
+			name:  "no conditions",
+			query: entities.EventQuery{Limit: 10, OrderBy: "starts-at"},
+			want:  nil,
+		},
+		{
+			name: "date window",
+			query: entities.EventQuery{
+				From: parseTime(t, "2025-01-01T00:00:00Z"),
+				To:   parseTime(t, "2025-07-01T00:00:00-03:00"),
+			},
+			want: []Filter{
+				{Name: "starts-at", Op: "ge", Val: "2025-01-01T00:00:00Z"},
+				{Name: "starts-at", Op: "lt", Val: "2025-07-01T03:00:00Z"},
+			},
+		},
+		{
+			name:  "upcoming",
+			query: entities.EventQuery{Upcoming: true},
+			want:  []Filter{{Name: "ends-at", Op: "ge", Val: "2025-06-01T15:00:00Z"}},
+		},
+		{
+			name:  "past",
+			query: entities.EventQuery{Past: true},
+			want:  []Filter{{Name: "ends-at", Op: "lt", Val: "2025-06-01T15:00:00Z"}},
+		},
+		{
+			name:  "name search and state",
+			query: entities.EventQuery{Search: "pylestras", State: "published"},
+			want: []Filter{
+				{Name: "name", Op: "ilike", Val: "%pylestras%"},
+				{Name: "state", Op: "eq", Val: "published"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.want, client.buildFilters(tt.query, now))
+		})
+	}
+}
+
+// TestEventsClient_GetEvents_Query checks the request sent for a filtered page and the returned count
+func TestEventsClient_GetEvents_Query(t *testing.T) {
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		query := r.URL.Query()
+		assert.Equal(t, "/events", r.URL.Path)
+		assert.Equal(t, "-starts-at", query.Get("sort"))
+		assert.Equal(t, "5", query.Get("page[size]"))
+		assert.Equal(t, "3", query.Get("page[number]"))
+		assert.JSONEq(t, `[{"name": "name", "op": "ilike", "val": "%python%"}]`, query.Get("filter"))
+
+		w.Header().Set("Content-Type", jsonAPIAccept)
+		w.Write([]byte(`{"meta": {"count": 12}, "data": [{"type": "event", "id": "42", "attributes": {
+			"name": "Python Sanca", "starts-at": "2025-03-15T19:00:00Z", "ends-at": "2025-03-15T22:00:00Z",
+			"timezone": "America/Sao_Paulo", "identifier": "b8324ae2", "privacy": "public", "state": "published"}}]}`))
+	}))
+	defer fake.Close()
+
+	client := &eventsClient{httpClient: fake.Client(), baseURL: fake.URL}
+
+	events, count, err := client.GetEvents(context.Background(), entities.EventQuery{
+		Limit:  5,
+		Page:   3,
+		Desc:   true,
+		Search: "python",
+	})
+	require.NoError(t, err)
+	require.Len(t, events, 1)
+	assert.Equal(t, "42", events[0].ID)
+	assert.Equal(t, 12, count)
+}
+
+// TestEventsClient_GetEventByID fetches a single event from a fake Grupy API
+func TestEventsClient_GetEventByID(t *testing.T) {
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, jsonAPIAccept, r.Header.Get("Accept"))
+
+		switch r.URL.Path {
+		case "/events/42":
+			w.Header().Set("Content-Type", jsonAPIAccept)
+			w.Write([]byte(`{"data": {"type": "event", "id": "42", "attributes": {
+				"name": "Pylestras", "description": "Palestras sobre Python",
+				"starts-at": "2025-03-15T19:00:00Z", "ends-at": "2025-03-15T22:00:00Z",
+				"timezone": "America/Sao_Paulo", "identifier": "b8324ae2",
+				"logo-url": "https://example.com/logo.png", "privacy": "public", "state": "published"}}}`))
+		case "/events/500":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer fake.Close()
+
+	client := &eventsClient{httpClient: fake.Client(), baseURL: fake.URL}
+
+	t.Run("found", func(t *testing.T) {
+		event, err := client.GetEventByID(context.Background(), "42")
+		require.NoError(t, err)
+		assert.Equal(t, "42", event.ID)
+		assert.Equal(t, "b8324ae2", event.Identifier)
+		assert.Equal(t, "Pylestras", event.Name)
+		assert.Equal(t, "Palestras sobre Python", event.Description)
+		assert.Equal(t, "https://example.com/logo.png", event.LogoURL)
+		assert.True(t, parseTime(t, "2025-03-15T19:00:00Z").Equal(event.StartsAt))
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.GetEventByID(context.Background(), "missing")
+		assert.ErrorIs(t, err, customerrors.ErrNotFound)
+	})
+
+	t.Run("api error", func(t *testing.T) {
+		_, err := client.GetEventByID(context.Background(), "500")
+		require.Error(t, err)
+		assert.NotErrorIs(t, err, customerrors.ErrNotFound)
+	})
+}
+
+// newRecordedGrupyServer serves the Grupy API responses recorded under testdata/grupy
+// A request for /events/42/sessions is answered with events_42_sessions.json, pages after the first
+// with a _pageN suffix. Paths without a recording are answered with 404, like the real API
+func newRecordedGrupyServer(t *testing.T) *httptest.Server {
+	t.Helper()
+
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, jsonAPIAccept, r.Header.Get("Accept"))
+
+		name := strings.ReplaceAll(strings.Trim(r.URL.Path, "/"), "/", "_")
+		if page := r.URL.Query().Get("page[number]"); page != "" && page != "1" {
+			name += "_page" + page
+		}
+
+		recorded, err := os.ReadFile(filepath.Join("testdata", "grupy", name+".json"))
+		if err != nil {
+			w.WriteHeader(http.StatusNotFound)
+			return
+		}
+
+		w.Header().Set("Content-Type", jsonAPIAccept)
+		w.Write(recorded)
+	}))
+	t.Cleanup(fake.Close)
+
+	return fake
+}
+
+// TestEventsClient_GetEventTracks maps the tracks of a recorded event
+func TestEventsClient_GetEventTracks(t *testing.T) {
+	fake := newRecordedGrupyServer(t)
+	client := &eventsClient{httpClient: fake.Client(), baseURL: fake.URL}
+
+	tracks, err := client.GetEventTracks(context.Background(), "42")
+	require.NoError(t, err)
+
+	assert.Equal(t, []entities.Track{
+		{ID: "7", Name: "Web", Color: "#3572A5"},
+		{ID: "8", Name: "Dados", Description: "Ciência de dados e aprendizado de máquina", Color: "#FFD43B"},
+	}, tracks)
+}
+
+// TestEventsClient_GetEventSessions maps recorded sessions and resolves their included resources
+func TestEventsClient_GetEventSessions(t *testing.T) {
+	fake := newRecordedGrupyServer(t)
+	client := &eventsClient{httpClient: fake.Client(), baseURL: fake.URL}
+
+	t.Run("found", func(t *testing.T) {
+		sessions, err := client.GetEventSessions(context.Background(), "42")
+		require.NoError(t, err)
+		require.Len(t, sessions, 3)
+
+		scheduled := sessions[0]
+		assert.Equal(t, "301", scheduled.ID)
+		assert.Equal(t, "APIs assíncronas com FastAPI", scheduled.Title)
+		assert.Equal(t, "Construindo APIs rápidas com asyncio", scheduled.ShortAbstract)
+		assert.Equal(t, "Intermediate", scheduled.Level)
+		assert.Equal(t, "confirmed", scheduled.State)
+		assert.Equal(t, "https://example.com/slides/fastapi.pdf", scheduled.SlidesURL)
+		assert.True(t, parseTime(t, "2025-09-20T14:00:00Z").Equal(scheduled.StartsAt))
+		assert.True(t, parseTime(t, "2025-09-20T14:45:00Z").Equal(scheduled.EndsAt))
+		assert.Equal(t, &entities.Track{ID: "7", Name: "Web", Color: "#3572A5"}, scheduled.Track)
+		assert.Equal(t, &entities.Microlocation{ID: "11", Name: "Auditório Fernão Stella"}, scheduled.Microlocation)
+		assert.Equal(t, []entities.Speaker{
+			{ID: "501", Name: "Maria Silva", PhotoURL: "https://example.com/photos/maria.png"},
+			{ID: "502", Name: "João Souza"},
+		}, scheduled.Speakers)
+
+		unscheduled := sessions[1]
+		assert.Equal(t, "Dicas para dataframes grandes", unscheduled.Subtitle)
+		assert.True(t, unscheduled.StartsAt.IsZero())
+		assert.True(t, unscheduled.EndsAt.IsZero())
+		assert.Equal(t, "8", unscheduled.Track.ID)
+		assert.Nil(t, unscheduled.Microlocation)
+
+		keynote := sessions[2]
+		assert.Nil(t, keynote.Track)
+		assert.Empty(t, keynote.Speakers)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		_, err := client.GetEventSessions(context.Background(), "missing")
+		assert.ErrorIs(t, err, customerrors.ErrNotFound)
+	})
+}
+
+// TestEventsClient_GetEventSpeakers maps recorded speakers across pages
+func TestEventsClient_GetEventSpeakers(t *testing.T) {
+	fake := newRecordedGrupyServer(t)
+	client := &eventsClient{httpClient: fake.Client(), baseURL: fake.URL}
+
+	speakers, err := client.GetEventSpeakers(context.Background(), "42")
+	require.NoError(t, err)
+	require.Len(t, speakers, 3, "the second page should be fetched")
+
+	assert.Equal(t, entities.Speaker{
+		ID:             "501",
+		Name:           "Maria Silva",
+		PhotoURL:       "https://example.com/photos/maria.png",
+		ShortBiography: "Desenvolvedora Python",
+		LongBiography:  "Desenvolvedora Python há dez anos e organizadora do Grupy Sanca.",
+		Organisation:   "Grupy Sanca",
+		Position:       "Organizadora",
+		Country:        "Brazil",
+		Website:        "https://example.com",
+		Twitter:        "https://twitter.com/mariasilva",
+		SessionIDs:     []string{"301"},
+	}, speakers[1])
+	assert.Equal(t, []string{"301", "302"}, speakers[0].SessionIDs)
+	assert.Equal(t, "https://github.com/joaosouza", speakers[0].GitHub)
+	assert.Equal(t, "503", speakers[2].ID)
+	assert.Equal(t, "https://linkedin.com/in/anapereira", speakers[2].LinkedIn)
+	assert.Empty(t, speakers[2].SessionIDs)
+}
+
 // Helper functions
 
 func stringPtr(s string) *string {
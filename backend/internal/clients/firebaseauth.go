@@ -3,9 +3,16 @@ package clients
 import (
 	"context"
 	"errors"
+	"fmt"
+	"maps"
 
 	firebase "firebase.google.com/go/v4"
 	"firebase.google.com/go/v4/auth"
+
+	"backend/internal/entities"
+	authcfg "backend/internal/platform/auth"
+	customerrors "backend/internal/platform/errors"
+	"backend/internal/server"
 )
 
 func NewFirebaseAuthClient(ctx context.Context, fbApp *firebase.App) (*auth.Client, error) {
@@ -15,3 +22,82 @@ func NewFirebaseAuthClient(ctx context.Context, fbApp *firebase.App) (*auth.Clie
 
 	return fbApp.Auth(ctx)
 }
+
+// Compile-time interface checks
+var (
+	_ server.UserDirectoryPort = (*userDirectory)(nil)
+	_ authcfg.TokenVerifier    = (*firebaseVerifier)(nil)
+)
+
+// firebaseVerifier verifies the ID tokens of the Firebase accounts
+type firebaseVerifier struct {
+	client *auth.Client
+}
+
+// NewFirebaseTokenVerifier creates the token verifier of a Firebase Auth client
+func NewFirebaseTokenVerifier(client *auth.Client) authcfg.TokenVerifier {
+	return &firebaseVerifier{client: client}
+}
+
+func (v *firebaseVerifier) VerifyIDToken(ctx context.Context, idToken string) (*authcfg.Token, error) {
+	token, err := v.client.VerifyIDToken(ctx, idToken)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", authcfg.ErrInvalidToken, err)
+	}
+	return &authcfg.Token{UID: token.UID, Claims: token.Claims}, nil
+}
+
+// userDirectory manages the Firebase accounts of the editors, roles are kept in their custom claims
+type userDirectory struct {
+	client *auth.Client
+}
+
+// NewUserDirectory creates the user directory of a Firebase Auth client
+func NewUserDirectory(client *auth.Client) server.UserDirectoryPort {
+	return &userDirectory{client: client}
+}
+
+func (d *userDirectory) GetUser(ctx context.Context, uid string) (entities.User, error) {
+	record, err := d.client.GetUser(ctx, uid)
+	if err != nil {
+		if auth.IsUserNotFound(err) {
+			return entities.User{}, fmt.Errorf("user %s not found: %w", uid, customerrors.ErrNotFound)
+		}
+		return entities.User{}, fmt.Errorf("failed to get user: %w", err)
+	}
+	return userFromRecord(record), nil
+}
+
+// SetUserRole replaces the role claim of an account, its other custom claims are kept
+func (d *userDirectory) SetUserRole(ctx context.Context, uid string, role string) (entities.User, error) {
+	record, err := d.client.GetUser(ctx, uid)
+	if err != nil {
+		if auth.IsUserNotFound(err) {
+			return entities.User{}, fmt.Errorf("user %s not found: %w", uid, customerrors.ErrNotFound)
+		}
+		return entities.User{}, fmt.Errorf("failed to get user: %w", err)
+	}
+
+	claims := maps.Clone(record.CustomClaims)
+	if claims == nil {
+		claims = map[string]any{}
+	}
+	claims[authcfg.RoleClaim] = role
+
+	if err := d.client.SetCustomUserClaims(ctx, uid, claims); err != nil {
+		return entities.User{}, fmt.Errorf("failed to set user claims: %w", err)
+	}
+
+	record.CustomClaims = claims
+	return userFromRecord(record), nil
+}
+
+func userFromRecord(record *auth.UserRecord) entities.User {
+	role, _ := record.CustomClaims[authcfg.RoleClaim].(string)
+	return entities.User{
+		UID:         record.UID,
+		Email:       record.Email,
+		DisplayName: record.DisplayName,
+		Role:        role,
+	}
+}
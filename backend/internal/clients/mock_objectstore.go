@@ -3,38 +3,109 @@ package clients
 import (
 	"context"
 	"fmt"
+	"mime"
+	"path"
+	"strings"
+	"sync"
+	"time"
 
+	"backend/internal/entities"
+	customerrors "backend/internal/platform/errors"
 	"backend/internal/server"
 )
 
 // Compile-time check that mockObjectStore implements server.ObjectStorePort
 var _ server.ObjectStorePort = (*mockObjectStore)(nil)
 
-// mockObjectStore is a no-op implementation of ObjectStorePort for testing
-type mockObjectStore struct{}
+// mockObjectStore is an in-memory implementation of ObjectStorePort for testing
+type mockObjectStore struct {
+	mu      sync.Mutex
+	objects map[string][]byte
+}
 
-// NewMockObjectStore creates a new mock object store that doesn't actually store anything
-// It returns fake URLs and does no-op operations, useful for testing without real storage
+// NewMockObjectStore creates a new mock object store keeping the objects in memory
+// It returns fake URLs, useful for testing without real storage
 func NewMockObjectStore() server.ObjectStorePort {
-	return &mockObjectStore{}
+	return &mockObjectStore{objects: make(map[string][]byte)}
 }
 
-// PutObject returns a mock URL without actually storing data
+// PutObject keeps a copy of data and returns a mock URL
 func (m *mockObjectStore) PutObject(ctx context.Context, key string, data []byte) (publicURL string, err error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.objects[key] = append([]byte(nil), data...)
+
 	// Return a fake URL that includes the key for debugging
 	mockURL := fmt.Sprintf("https://mock-storage.example.com/%s", key)
 	return mockURL, nil
 }
 
-// DeleteObject is a no-op, always succeeds
+// PutPrivateObject stores data like PutObject
+func (m *mockObjectStore) PutPrivateObject(ctx context.Context, key string, data []byte) (string, error) {
+	return m.PutObject(ctx, key, data)
+}
+
+// SetObjectPrivate is a no-op, always succeeds
+func (m *mockObjectStore) SetObjectPrivate(ctx context.Context, key string, private bool) error {
+	return nil
+}
+
+// DeleteObject forgets the object, always succeeds
 func (m *mockObjectStore) DeleteObject(ctx context.Context, key string) error {
-	// No-op: pretend we deleted it
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	delete(m.objects, key)
 	return nil
 }
 
+// GetObject returns the stored data, ErrNotFound for unknown keys
+func (m *mockObjectStore) GetObject(ctx context.Context, key string) ([]byte, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.objects[key]
+	if !ok {
+		return nil, fmt.Errorf("%w: object %s", customerrors.ErrNotFound, key)
+	}
+	return append([]byte(nil), data...), nil
+}
+
 // SignedURL returns a fake signed URL
 func (m *mockObjectStore) SignedURL(ctx context.Context, key string) (string, error) {
 	// Return a fake signed URL
 	mockSignedURL := fmt.Sprintf("https://mock-storage.example.com/%s?signed=true", key)
 	return mockSignedURL, nil
 }
+
+// SignedUploadURL returns a fake signed upload URL
+func (m *mockObjectStore) SignedUploadURL(ctx context.Context, key, contentType string, private bool) (entities.SignedUpload, error) {
+	return entities.SignedUpload{
+		Key:       key,
+		URL:       fmt.Sprintf("https://mock-storage.example.com/%s?signed=true&method=PUT", key),
+		Method:    "PUT",
+		Headers:   map[string]string{"Content-Type": contentType},
+		ExpiresAt: time.Now().Add(15 * time.Minute),
+	}, nil
+}
+
+// KeyFromURL returns the key of a mock URL
+func (m *mockObjectStore) KeyFromURL(url string) string {
+	return strings.TrimPrefix(url, "https://mock-storage.example.com/")
+}
+
+// StatObject describes a stored object typed by its extension, ErrNotFound for unknown keys
+// Signed uploads never reach the mock, so their keys are unknown until stored with PutObject
+func (m *mockObjectStore) StatObject(ctx context.Context, key string) (entities.ObjectInfo, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	data, ok := m.objects[key]
+	if !ok {
+		return entities.ObjectInfo{}, fmt.Errorf("%w: object %s", customerrors.ErrNotFound, key)
+	}
+
+	return entities.ObjectInfo{
+		Key:         key,
+		URL:         fmt.Sprintf("https://mock-storage.example.com/%s", key),
+		Size:        int64(len(data)),
+		ContentType: mime.TypeByExtension(path.Ext(key)),
+	}, nil
+}
@@ -0,0 +1,32 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	customerrors "backend/internal/platform/errors"
+)
+
+func TestMockObjectStore_StatObject(t *testing.T) {
+	ctx := context.Background()
+	store := NewMockObjectStore()
+
+	_, err := store.StatObject(ctx, "uploads/never-uploaded.png")
+	assert.True(t, errors.Is(err, customerrors.ErrNotFound), "Unknown keys must not be reported as uploaded")
+
+	_, err = store.PutObject(ctx, "uploads/stored.png", []byte("\x89PNG"))
+	require.NoError(t, err)
+
+	info, err := store.StatObject(ctx, "uploads/stored.png")
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), info.Size)
+	assert.Equal(t, "image/png", info.ContentType)
+
+	require.NoError(t, store.DeleteObject(ctx, "uploads/stored.png"))
+	_, err = store.StatObject(ctx, "uploads/stored.png")
+	assert.True(t, errors.Is(err, customerrors.ErrNotFound), "Deleted objects are gone")
+}
@@ -3,6 +3,7 @@ package clients
 import (
 	"context"
 
+	"backend/internal/entities"
 	"backend/internal/server"
 )
 
@@ -10,8 +11,14 @@ import (
 // This allows the client to wrap any gateway implementation (GCS, S3, etc.)
 type ObjectStoreGateway interface {
 	PutObject(ctx context.Context, key string, data []byte) (string, error)
+	PutPrivateObject(ctx context.Context, key string, data []byte) (string, error)
+	SetObjectPrivate(ctx context.Context, key string, private bool) error
 	DeleteObject(ctx context.Context, key string) error
+	GetObject(ctx context.Context, key string) ([]byte, error)
 	SignedURL(ctx context.Context, key string) (string, error)
+	SignedUploadURL(ctx context.Context, key, contentType string, private bool) (entities.SignedUpload, error)
+	StatObject(ctx context.Context, key string) (entities.ObjectInfo, error)
+	KeyFromURL(url string) string
 	Close() error
 }
 
@@ -34,16 +41,46 @@ func (c *objectClient) PutObject(ctx context.Context, key string, data []byte) (
 	return c.gateway.PutObject(ctx, key, data)
 }
 
+// PutPrivateObject uploads an object without public access via the gateway
+func (c *objectClient) PutPrivateObject(ctx context.Context, key string, data []byte) (string, error) {
+	return c.gateway.PutPrivateObject(ctx, key, data)
+}
+
+// SetObjectPrivate changes the visibility of an object via the gateway
+func (c *objectClient) SetObjectPrivate(ctx context.Context, key string, private bool) error {
+	return c.gateway.SetObjectPrivate(ctx, key, private)
+}
+
 // DeleteObject deletes an object via the gateway
 func (c *objectClient) DeleteObject(ctx context.Context, key string) error {
 	return c.gateway.DeleteObject(ctx, key)
 }
 
+// GetObject downloads an object via the gateway
+func (c *objectClient) GetObject(ctx context.Context, key string) ([]byte, error) {
+	return c.gateway.GetObject(ctx, key)
+}
+
 // SignedURL generates a signed URL via the gateway
 func (c *objectClient) SignedURL(ctx context.Context, key string) (string, error) {
 	return c.gateway.SignedURL(ctx, key)
 }
 
+// SignedUploadURL generates a signed upload URL via the gateway
+func (c *objectClient) SignedUploadURL(ctx context.Context, key, contentType string, private bool) (entities.SignedUpload, error) {
+	return c.gateway.SignedUploadURL(ctx, key, contentType, private)
+}
+
+// StatObject retrieves object attributes via the gateway
+func (c *objectClient) StatObject(ctx context.Context, key string) (entities.ObjectInfo, error) {
+	return c.gateway.StatObject(ctx, key)
+}
+
+// KeyFromURL resolves the key of a stored object URL via the gateway
+func (c *objectClient) KeyFromURL(url string) string {
+	return c.gateway.KeyFromURL(url)
+}
+
 // Close closes the underlying gateway connection
 func (c *objectClient) Close() error {
 	if c.gateway != nil {
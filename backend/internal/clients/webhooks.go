@@ -0,0 +1,239 @@
+package clients
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/configs"
+	"backend/internal/entities"
+	"backend/internal/server"
+)
+
+const (
+	telegramBaseURL = "https://api.telegram.org"
+
+	// webhookTimeout is the time allowed to a receiver to answer a delivery
+	webhookTimeout = 10 * time.Second
+
+	// Headers of the JSON webhook deliveries
+	webhookEventHeader     = "X-Grupy-Event"
+	webhookDeliveryHeader  = "X-Grupy-Delivery"
+	webhookTimestampHeader = "X-Grupy-Timestamp"
+	webhookSignatureHeader = "X-Grupy-Signature-256"
+)
+
+// Compile-time interface check
+var _ server.WebhookPort = (*webhook)(nil)
+
+// webhook posts event notifications to a receiver, the payload depends on its type
+type webhook struct {
+	name       string
+	url        string
+	secret     string
+	httpClient *http.Client
+	encode     func(entities.Notification) ([]byte, error)
+}
+
+// NewWebhook creates the webhook described by cfg
+func NewWebhook(cfg configs.WebhookConfig) (server.WebhookPort, error) {
+	hook := &webhook{
+		name:       cfg.Name,
+		url:        cfg.URL,
+		httpClient: &http.Client{Timeout: webhookTimeout},
+	}
+
+	switch cfg.Type {
+	case "json":
+		hook.secret = cfg.Secret
+		hook.encode = encodeJSONNotification
+	case "discord":
+		hook.encode = encodeDiscordNotification
+	case "telegram":
+		if cfg.Token == "" || cfg.ChatID == "" {
+			return nil, fmt.Errorf("telegram webhook %s needs a token and a chat_id", cfg.Name)
+		}
+		baseURL := strings.TrimSuffix(cfg.URL, "/")
+		if baseURL == "" {
+			baseURL = telegramBaseURL
+		}
+		hook.url = fmt.Sprintf("%s/bot%s/sendMessage", baseURL, cfg.Token)
+		hook.encode = func(n entities.Notification) ([]byte, error) {
+			return encodeTelegramNotification(n, cfg.ChatID)
+		}
+	default:
+		return nil, fmt.Errorf("webhook %s has unknown type %q", cfg.Name, cfg.Type)
+	}
+
+	if hook.url == "" {
+		return nil, fmt.Errorf("webhook %s needs an url", cfg.Name)
+	}
+	return hook, nil
+}
+
+func (h *webhook) Name() string {
+	return h.name
+}
+
+// Deliver posts a notification once. Client errors other than 429 mean the receiver won't ever
+// accept it and wrap server.ErrWebhookRejected
+func (h *webhook) Deliver(ctx context.Context, notification entities.Notification) error {
+	body, err := h.encode(notification)
+	if err != nil {
+		return fmt.Errorf("failed to encode notification: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, h.url, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("failed to create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set(webhookEventHeader, string(notification.Kind))
+	req.Header.Set(webhookDeliveryHeader, notification.ID)
+
+	if h.secret != "" {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(webhookTimestampHeader, timestamp)
+		req.Header.Set(webhookSignatureHeader, SignWebhookPayload(h.secret, timestamp, body))
+	}
+
+	resp, err := h.httpClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to deliver notification: %w", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 200 && resp.StatusCode < 300 {
+		return nil
+	}
+
+	detail, _ := io.ReadAll(io.LimitReader(resp.Body, 512))
+	err = fmt.Errorf("webhook returned status %d: %s", resp.StatusCode, strings.TrimSpace(string(detail)))
+	if resp.StatusCode >= 400 && resp.StatusCode < 500 && resp.StatusCode != http.StatusTooManyRequests {
+		return fmt.Errorf("%w: %w", server.ErrWebhookRejected, err)
+	}
+	return err
+}
+
+// SignWebhookPayload returns the signature of a JSON delivery: the hex HMAC-SHA256 of
+// "<timestamp>.<body>" keyed by the secret, prefixed by "sha256="
+// Receivers compute it again to check the payload came from this server and wasn't replayed
+func SignWebhookPayload(secret, timestamp string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// =======================
+// PAYLOADS
+// =======================
+
+// webhookPayload is the body of a JSON delivery
+type webhookPayload struct {
+	ID         string               `json:"id"`
+	Type       string               `json:"type"`
+	OccurredAt time.Time            `json:"occurred_at"`
+	Event      webhookEventPayload  `json:"event"`
+	Previous   *webhookEventPayload `json:"previous,omitempty"`
+}
+
+type webhookEventPayload struct {
+	ID       string    `json:"id,omitempty"`
+	Name     string    `json:"name"`
+	State    string    `json:"state"`
+	StartsAt time.Time `json:"starts_at"`
+	EndsAt   time.Time `json:"ends_at"`
+	Timezone string    `json:"timezone,omitempty"`
+	Location string    `json:"location_name,omitempty"`
+	Link     string    `json:"link,omitempty"`
+}
+
+func encodeJSONNotification(n entities.Notification) ([]byte, error) {
+	payload := webhookPayload{
+		ID:         n.ID,
+		Type:       string(n.Kind),
+		OccurredAt: n.OccurredAt,
+		Event: webhookEventPayload{
+			ID:       n.Event.ID,
+			Name:     n.Event.Name,
+			State:    n.Event.State,
+			StartsAt: n.Event.StartsAt,
+			EndsAt:   n.Event.EndsAt,
+			Timezone: n.Event.Timezone,
+			Location: n.Event.LocationName,
+			Link:     n.Event.Link,
+		},
+	}
+	if n.Previous != nil {
+		payload.Previous = &webhookEventPayload{
+			Name:     n.Previous.Name,
+			State:    n.Previous.State,
+			StartsAt: n.Previous.StartsAt,
+			EndsAt:   n.Previous.EndsAt,
+			Link:     n.Previous.Link,
+		}
+	}
+	return json.Marshal(payload)
+}
+
+func encodeDiscordNotification(n entities.Notification) ([]byte, error) {
+	return json.Marshal(map[string]string{"content": notificationMessage(n)})
+}
+
+func encodeTelegramNotification(n entities.Notification, chatID string) ([]byte, error) {
+	return json.Marshal(map[string]string{"chat_id": chatID, "text": notificationMessage(n)})
+}
+
+// notificationMessage describes a notification to the community chats
+func notificationMessage(n entities.Notification) string {
+	var title string
+	switch n.Kind {
+	case entities.NotificationEventCreated:
+		title = "Novo evento"
+	case entities.NotificationEventPublished:
+		title = "Evento publicado"
+	case entities.NotificationEventRescheduled:
+		title = "Evento remarcado"
+	case entities.NotificationEventCancelled:
+		title = "Evento cancelado"
+	default:
+		title = "Evento atualizado"
+	}
+
+	var msg strings.Builder
+	fmt.Fprintf(&msg, "%s: %s\n", title, n.Event.Name)
+	if n.Kind == entities.NotificationEventRescheduled && n.Previous != nil {
+		fmt.Fprintf(&msg, "Antes: %s\n", formatEventDate(n.Previous.StartsAt, n.Event.Timezone))
+		fmt.Fprintf(&msg, "Agora: %s\n", formatEventDate(n.Event.StartsAt, n.Event.Timezone))
+	} else {
+		fmt.Fprintf(&msg, "Quando: %s\n", formatEventDate(n.Event.StartsAt, n.Event.Timezone))
+	}
+	if n.Event.LocationName != "" {
+		fmt.Fprintf(&msg, "Onde: %s\n", n.Event.LocationName)
+	}
+	if n.Event.Link != "" {
+		msg.WriteString(n.Event.Link)
+	}
+	return strings.TrimSpace(msg.String())
+}
+
+// formatEventDate formats the start of an event in its timezone, or in UTC when it's unknown
+func formatEventDate(t time.Time, timezone string) string {
+	if loc, err := time.LoadLocation(timezone); err == nil && timezone != "" {
+		t = t.In(loc)
+	} else {
+		t = t.UTC()
+	}
+	return t.Format("02/01/2006 15:04 MST")
+}
@@ -0,0 +1,128 @@
+package clients
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"backend/configs"
+	"backend/internal/entities"
+	"backend/internal/server"
+)
+
+func testNotification() entities.Notification {
+	startsAt := time.Date(2025, 10, 21, 12, 0, 0, 0, time.UTC)
+	return entities.Notification{
+		ID:   "d2f1c1e0",
+		Kind: entities.NotificationEventRescheduled,
+		Event: entities.Event{
+			ID:           "42",
+			Name:         "Python Brasil 2025",
+			State:        "published",
+			StartsAt:     startsAt,
+			EndsAt:       startsAt.Add(4 * 24 * time.Hour),
+			Timezone:     "America/Sao_Paulo",
+			LocationName: "São Paulo",
+			Link:         "https://eventos.grupysanca.com.br/e/42",
+		},
+		Previous: &entities.EventSnapshot{
+			Name:     "Python Brasil 2025",
+			State:    "published",
+			StartsAt: startsAt.Add(-24 * time.Hour),
+			EndsAt:   startsAt.Add(3 * 24 * time.Hour),
+		},
+		OccurredAt: startsAt.Add(-30 * 24 * time.Hour),
+	}
+}
+
+// TestNewWebhook validates the configuration of every type
+func TestNewWebhook(t *testing.T) {
+	hook, err := NewWebhook(configs.WebhookConfig{Name: "site", Type: "json", URL: "https://example.com/hook"})
+	require.NoError(t, err)
+	assert.Equal(t, "site", hook.Name())
+
+	for name, cfg := range map[string]configs.WebhookConfig{
+		"unknown type":       {Name: "slack", Type: "slack", URL: "https://example.com"},
+		"json without url":   {Name: "site", Type: "json"},
+		"telegram w/o token": {Name: "telegram", Type: "telegram", ChatID: "-100"},
+	} {
+		_, err := NewWebhook(cfg)
+		assert.Error(t, err, name)
+	}
+}
+
+// TestWebhook_DeliverSigned posts a signed JSON payload
+func TestWebhook_DeliverSigned(t *testing.T) {
+	notification := testNotification()
+
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+
+		assert.Equal(t, "event.rescheduled", r.Header.Get("X-Grupy-Event"))
+		assert.Equal(t, notification.ID, r.Header.Get("X-Grupy-Delivery"))
+		timestamp := r.Header.Get("X-Grupy-Timestamp")
+		assert.Equal(t, SignWebhookPayload("s3cret", timestamp, body), r.Header.Get("X-Grupy-Signature-256"))
+
+		var payload map[string]any
+		require.NoError(t, json.Unmarshal(body, &payload))
+		assert.Equal(t, "event.rescheduled", payload["type"])
+		assert.Equal(t, "Python Brasil 2025", payload["event"].(map[string]any)["name"])
+		assert.Equal(t, "2025-10-20T12:00:00Z", payload["previous"].(map[string]any)["starts_at"])
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer fake.Close()
+
+	hook, err := NewWebhook(configs.WebhookConfig{Name: "site", Type: "json", URL: fake.URL, Secret: "s3cret"})
+	require.NoError(t, err)
+	assert.NoError(t, hook.Deliver(context.Background(), notification))
+}
+
+// TestWebhook_DeliverErrors tells rejected notifications from failures worth a retry
+func TestWebhook_DeliverErrors(t *testing.T) {
+	for status, rejected := range map[int]bool{
+		http.StatusBadRequest:          true,
+		http.StatusGone:                true,
+		http.StatusTooManyRequests:     false,
+		http.StatusInternalServerError: false,
+	} {
+		fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			w.WriteHeader(status)
+		}))
+
+		hook, err := NewWebhook(configs.WebhookConfig{Name: "site", Type: "json", URL: fake.URL})
+		require.NoError(t, err)
+
+		err = hook.Deliver(context.Background(), testNotification())
+		assert.Error(t, err, "status %d", status)
+		assert.Equal(t, rejected, errors.Is(err, server.ErrWebhookRejected), "status %d", status)
+		fake.Close()
+	}
+}
+
+// TestWebhook_DeliverTelegram sends a message through the Bot API
+func TestWebhook_DeliverTelegram(t *testing.T) {
+	fake := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/botabc:123/sendMessage", r.URL.Path)
+
+		var msg map[string]string
+		require.NoError(t, json.NewDecoder(r.Body).Decode(&msg))
+		assert.Equal(t, "-100", msg["chat_id"])
+		assert.Contains(t, msg["text"], "Evento remarcado: Python Brasil 2025")
+		assert.Contains(t, msg["text"], "Agora: 21/10/2025 09:00 -03")
+		w.Write([]byte(`{"ok": true}`))
+	}))
+	defer fake.Close()
+
+	hook, err := NewWebhook(configs.WebhookConfig{Name: "telegram", Type: "telegram", URL: fake.URL, Token: "abc:123", ChatID: "-100"})
+	require.NoError(t, err)
+	assert.NoError(t, hook.Deliver(context.Background(), testNotification()))
+}
@@ -0,0 +1,23 @@
+package entities
+
+import "time"
+
+// Archive describes a downloadable ZIP archive, its content is streamed on demand
+type Archive struct {
+	Name    string    // File name offered to the client
+	ETag    string    // Changes whenever the content of the archive may change
+	ModTime time.Time // Modification time of every file in the archive
+	Size    int64     // Exact size of the ZIP in bytes
+	Files   []ArchiveFile
+}
+
+// ArchiveFile is a file of an archive, either an object from the object store or inline content
+type ArchiveFile struct {
+	Name       string
+	Key        string // Object key, empty for inline files
+	Size       int64
+	Generation int64  // Generation of the object
+	CRC32      uint32 // IEEE checksum of the object when recorded at upload, 0 when unknown
+	End        int64  // Offset in the archive right after the content of the file
+	Content    []byte // Inline content, e.g., the manifest
+}
@@ -0,0 +1,62 @@
+package entities
+
+import "time"
+
+// AuditAction is the kind of mutation recorded by the audit log
+type AuditAction string
+
+const (
+	AuditCreate AuditAction = "create"
+	AuditUpdate AuditAction = "update"
+	AuditDelete AuditAction = "delete"
+)
+
+// AuditEntityType is the kind of content an audit record is about
+type AuditEntityType string
+
+const (
+	AuditText          AuditEntityType = "text"
+	AuditImage         AuditEntityType = "image"
+	AuditTimelineEntry AuditEntityType = "timeline_entry"
+	AuditGaleryEvent   AuditEntityType = "galery_event"
+	AuditUser          AuditEntityType = "user" // Role changes
+)
+
+// AuditRecord is an entry of the append-only audit log, written after every content mutation
+type AuditRecord struct {
+	ID         string                 `firestore:"id"`
+	Actor      string                 `firestore:"actor"` // Email or uid of the caller, empty for system writes and while authentication is disabled
+	Action     AuditAction            `firestore:"action"`
+	EntityType AuditEntityType        `firestore:"entity_type"`
+	EntityID   string                 `firestore:"entity_id"`
+	Changes    map[string]AuditChange `firestore:"changes"`    // Fields that changed, keyed by their stored name
+	RequestID  string                 `firestore:"request_id"` // Empty for mutations made outside a request, e.g. by jobs
+	OccurredAt time.Time              `firestore:"occurred_at"`
+}
+
+// AuditChange is the value of a field before and after a mutation
+type AuditChange struct {
+	Before any `firestore:"before"` // Nil when the field was empty
+	After  any `firestore:"after"`  // Nil when the field was emptied
+}
+
+// AuditQuery selects a page of the audit log, newest first
+// Conditions left at their zero value don't filter
+type AuditQuery struct {
+	Actor      string
+	Action     AuditAction
+	EntityType AuditEntityType
+	EntityID   string
+	From       time.Time // Records at or after From
+	To         time.Time // Records before To
+	Limit      int       // Page size
+	Page       int       // 1-based page number
+}
+
+// AuditPage is a page of the audit log
+type AuditPage struct {
+	Records []AuditRecord
+	Page    int  // 1-based page number
+	Limit   int  // Page size
+	HasMore bool // Whether a next page holds more records
+}
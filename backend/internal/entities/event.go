@@ -1,10 +1,15 @@
 package entities
 
-import "time"
+import (
+	"sort"
+	"strings"
+	"time"
+)
 
-// Event represents an external community event from Grupy Sanca API (proxy only, no persistence)
+// Event represents an external community event from Grupy Sanca API or a partner source (proxy only, no persistence)
 type Event struct {
 	ID                string
+	Source            string // Name of the event source, e.g. "grupy"
 	Identifier        string // Event's unique string identifier (e.g., "b8324ae2")
 	Name              string
 	Description       string
@@ -20,5 +25,135 @@ type Event struct {
 	Privacy           string // e.g., "public"
 	State             string // e.g., "draft", "published"
 	CreatedAt         time.Time
-	Link              string //link to the event on the main grupy events page
+	Link              string  //link to the event on the main grupy events page
+	Tracks            []Track // Only filled for single event detail
+}
+
+// EventQuery selects a page of events from the Grupy API
+// Conditions left at their zero value don't filter
+type EventQuery struct {
+	Limit    int       // Page size
+	Page     int       // 1-based page number
+	OrderBy  string    // Grupy API field name, e.g. "starts-at"
+	Desc     bool      // Descending order
+	From     time.Time // Events starting at or after From
+	To       time.Time // Events starting before To
+	Upcoming bool      // Events that haven't ended yet
+	Past     bool      // Events that have already ended
+	Search   string    // Part of the event name, case insensitive
+	State    string    // e.g., "published"
+}
+
+// Matches reports whether event meets the conditions of the query, now decides what is upcoming
+// Used by sources that filter their events in memory
+func (q EventQuery) Matches(event Event, now time.Time) bool {
+	switch {
+	case !q.From.IsZero() && event.StartsAt.Before(q.From):
+		return false
+	case !q.To.IsZero() && !event.StartsAt.Before(q.To):
+		return false
+	case q.Upcoming && event.EndsAt.Before(now):
+		return false
+	case q.Past && !event.EndsAt.Before(now):
+		return false
+	case q.Search != "" && !strings.Contains(strings.ToLower(event.Name), strings.ToLower(q.Search)):
+		return false
+	case q.State != "" && event.State != q.State:
+		return false
+	}
+	return true
+}
+
+// SortEvents sorts events in place by a Grupy API field name, "starts-at" when orderBy is unknown
+// Ties are broken by start date and name
+func SortEvents(events []Event, orderBy string, desc bool) {
+	key := func(e Event) time.Time {
+		switch orderBy {
+		case "ends-at":
+			return e.EndsAt
+		case "created-at":
+			return e.CreatedAt
+		default:
+			return e.StartsAt
+		}
+	}
+
+	compare := func(a, b Event) int {
+		if orderBy == "name" {
+			if c := strings.Compare(strings.ToLower(a.Name), strings.ToLower(b.Name)); c != 0 {
+				return c
+			}
+		} else if c := key(a).Compare(key(b)); c != 0 {
+			return c
+		}
+		if c := a.StartsAt.Compare(b.StartsAt); c != 0 {
+			return c
+		}
+		return strings.Compare(a.Name, b.Name)
+	}
+
+	sort.SliceStable(events, func(i, j int) bool {
+		if desc {
+			return compare(events[j], events[i]) < 0
+		}
+		return compare(events[i], events[j]) < 0
+	})
+}
+
+// EventPage is a page of events merged from the event sources
+type EventPage struct {
+	Events        []Event
+	Count         int      // Number of matching events over all pages
+	FailedSources []string // Sources left out because they couldn't be read
+}
+
+// Track groups the sessions of an event by theme
+type Track struct {
+	ID          string
+	Name        string
+	Description string
+	Color       string // Hex color used by the event page, e.g. "#3572A5"
+}
+
+// Microlocation is the room or stage where a session takes place
+type Microlocation struct {
+	ID    string
+	Name  string
+	Floor int
+}
+
+// Session is a talk, tutorial or keynote of an event
+type Session struct {
+	ID            string
+	Title         string
+	Subtitle      string
+	ShortAbstract string
+	LongAbstract  string
+	StartsAt      time.Time // Zero while the session is not scheduled
+	EndsAt        time.Time
+	Language      string
+	Level         string
+	State         string // e.g., "accepted", "confirmed"
+	SlidesURL     string
+	VideoURL      string
+	Track         *Track
+	Microlocation *Microlocation
+	Speakers      []Speaker // Only ID, Name and PhotoURL are filled
+}
+
+// Speaker is a person presenting sessions at an event
+type Speaker struct {
+	ID             string
+	Name           string
+	PhotoURL       string
+	ShortBiography string
+	LongBiography  string
+	Organisation   string
+	Position       string
+	Country        string
+	Website        string
+	Twitter        string
+	GitHub         string
+	LinkedIn       string
+	SessionIDs     []string
 }
@@ -0,0 +1,28 @@
+package entities
+
+import "time"
+
+// FeedKind identifies the content published by a feed
+type FeedKind string
+
+const (
+	FeedTimeline FeedKind = "timeline" // Newest history entries
+	FeedGalery   FeedKind = "galery"   // Newest public albums
+	FeedEvents   FeedKind = "events"   // Upcoming Grupy events
+)
+
+// FeedFormat is the syndication format of a feed document
+type FeedFormat string
+
+const (
+	FeedAtom FeedFormat = "atom" // Atom 1.0
+	FeedRSS  FeedFormat = "rss"  // RSS 2.0
+)
+
+// RenderedFeed is a feed document ready to be served
+type RenderedFeed struct {
+	Format  FeedFormat
+	Content []byte
+	ETag    string    // Changes whenever the content changes
+	ModTime time.Time // Last update of the feed content
+}
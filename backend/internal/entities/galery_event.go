@@ -4,12 +4,72 @@ import "time"
 
 // GaleryEvent represents a gallery event with associated images
 type GaleryEvent struct {
-	ID        string    `firestore:"id"`
-	Name      string    `firestore:"name"`
-	Location  string    `firestore:"location"`
-	Date      time.Time `firestore:"date"`
-	ImageURLs []string  `firestore:"image_urls"` // URLs from object storage
-	ImageIDs  []string  `firestore:"image_ids"`  // Firestore IDs of associated Image documents
-	CreatedAt time.Time `firestore:"created_at"`
-	UpdatedAt time.Time `firestore:"updated_at"`
+	ID           string       `firestore:"id"`
+	Name         string       `firestore:"name"`
+	Location     string       `firestore:"location"`
+	Date         time.Time    `firestore:"date"`
+	Items        []GaleryItem `firestore:"items"`          // Ordered photos of the event
+	CoverImageID string       `firestore:"cover_image_id"` // Image shown as the event cover, the first item when empty
+	ImageURLs    []string     `firestore:"image_urls"`     // Derived from Items, kept for queries and older clients
+	ImageIDs     []string     `firestore:"image_ids"`      // Derived from Items, kept for array-contains queries
+	Private      bool         `firestore:"private"`        // Private events and their images are only served to authenticated readers
+	GrupyEventID string       `firestore:"grupy_event_id"` // Optional Grupy event the photos were taken at
+	// CreatedImageIDs lists the images uploaded for the event, the only ones deleted along with it.
+	// Reused images belong to someone else, events created before it was recorded keep all their images
+	CreatedImageIDs []string  `firestore:"created_image_ids"`
+	CreatedAt       time.Time `firestore:"created_at"`
+	UpdatedAt       time.Time `firestore:"updated_at"`
+	CreatedBy       string    `firestore:"created_by"`
+	LastUpdatedBy   string    `firestore:"last_updated_by"`
+
+	// SignedImageURLs holds short-lived URLs matching Items for private events, never persisted
+	SignedImageURLs []string `firestore:"-"`
+
+	// GrupyEvent holds the details of the linked Grupy event when they could be fetched, never persisted
+	GrupyEvent *Event `firestore:"-"`
+
+	// LinksGrupyEvent tells an update to replace GrupyEventID, the stored link is kept otherwise. Never persisted
+	LinksGrupyEvent bool `firestore:"-"`
+}
+
+// GaleryItem is a photo of a galery event
+type GaleryItem struct {
+	ImageID     string           `firestore:"image_id"`
+	ImageURL    string           `firestore:"image_url"`
+	Caption     string           `firestore:"caption"`
+	Credit      string           `firestore:"credit"` // Photographer
+	AltText     string           `firestore:"alt_text"`
+	Placeholder ImagePlaceholder `firestore:"placeholder"` // Copied from the Image document
+}
+
+// GaleryItemFromImage creates a galery item showing img
+func GaleryItemFromImage(img Image) GaleryItem {
+	return GaleryItem{
+		ImageID:     img.ID,
+		ImageURL:    img.ObjectURL,
+		Placeholder: img.Placeholder(),
+	}
+}
+
+// SyncImageArrays rebuilds ImageIDs and ImageURLs from Items
+func (e *GaleryEvent) SyncImageArrays() {
+	e.ImageIDs = make([]string, len(e.Items))
+	e.ImageURLs = make([]string, len(e.Items))
+	for i, item := range e.Items {
+		e.ImageIDs[i] = item.ImageID
+		e.ImageURLs[i] = item.ImageURL
+	}
+}
+
+// Cover returns the index of the cover item, or -1 if the event has no items
+func (e GaleryEvent) Cover() int {
+	if len(e.Items) == 0 {
+		return -1
+	}
+	for i, item := range e.Items {
+		if item.ImageID == e.CoverImageID {
+			return i
+		}
+	}
+	return 0
 }
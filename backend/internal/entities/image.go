@@ -11,7 +11,61 @@ type Image struct {
 	Text          string    `json:"text" firestore:"text"` // Description
 	Date          time.Time `json:"date,omitempty" firestore:"date,omitempty"`
 	Location      string    `json:"location,omitempty" firestore:"location,omitempty"`
+	Private       bool      `json:"private,omitempty" firestore:"private,omitempty"`             // Private images are only served through signed URLs
+	SHA256        string    `json:"sha256,omitempty" firestore:"sha256,omitempty"`               // Hex encoded hash of the image bytes, used for deduplication
+	SignedURL     string    `json:"-" firestore:"-"`                                             // Short-lived URL for private images, never persisted
+	BlurHash      string    `json:"blurHash,omitempty" firestore:"blurHash,omitempty"`           // Placeholder shown while the image loads
+	LQIP          string    `json:"lqip,omitempty" firestore:"lqip,omitempty"`                   // Tiny base64 preview as a data URI
+	DominantColor string    `json:"dominantColor,omitempty" firestore:"dominantColor,omitempty"` // Hex color, e.g. "#a1b2c3"
+	Tags          []string  `json:"tags,omitempty" firestore:"tags,omitempty"`                   // Normalized tag names, sorted
 	CreatedAt     time.Time `json:"createdAt" firestore:"createdAt"`
 	UpdatedAt     time.Time `json:"updatedAt" firestore:"updatedAt"`
+	CreatedBy     string    `json:"createdBy,omitempty" firestore:"createdBy,omitempty"`
 	LastUpdatedBy string    `json:"lastUpdatedBy,omitempty" firestore:"lastUpdatedBy,omitempty"`
 }
+
+// Placeholder returns the loading placeholders of the image
+func (i Image) Placeholder() ImagePlaceholder {
+	return ImagePlaceholder{
+		BlurHash:      i.BlurHash,
+		LQIP:          i.LQIP,
+		DominantColor: i.DominantColor,
+	}
+}
+
+// ImagePlaceholder holds the lightweight previews shown while an image loads
+type ImagePlaceholder struct {
+	BlurHash      string `firestore:"blur_hash"`
+	LQIP          string `firestore:"lqip"`
+	DominantColor string `firestore:"dominant_color"`
+}
+
+// PlaceholderBackfillReport summarizes a run of the placeholder backfill
+type PlaceholderBackfillReport struct {
+	Scanned       int      // Images looked at
+	Updated       int      // Images that got placeholders
+	Skipped       int      // Images that already had placeholders
+	Failed        []string // IDs of images whose placeholders couldn't be computed
+	GaleryUpdated int      // Galery events whose placeholders were refreshed
+}
+
+// TagCount is a tag along with the number of images carrying it
+type TagCount struct {
+	Tag   string
+	Count int
+}
+
+// DuplicatePolicy determines what happens when an uploaded image has the same content as an existing one
+type DuplicatePolicy string
+
+const (
+	DuplicateAllow  DuplicatePolicy = "allow"  // Store the duplicate as a new image (default)
+	DuplicateReuse  DuplicatePolicy = "reuse"  // Return the existing image and its object instead of uploading
+	DuplicateReject DuplicatePolicy = "reject" // Fail the upload with a conflict
+)
+
+// DuplicateCluster groups images that share the same content hash
+type DuplicateCluster struct {
+	SHA256 string
+	Images []Image
+}
@@ -0,0 +1,31 @@
+package entities
+
+// ImportKind is the kind of content created by a bulk import
+type ImportKind string
+
+const (
+	ImportTimeline ImportKind = "timeline" // Timeline entries, upserted by name and date
+	ImportTexts    ImportKind = "texts"    // Texts, upserted by slug
+)
+
+// Valid reports whether k is a known kind
+func (k ImportKind) Valid() bool {
+	return k == ImportTimeline || k == ImportTexts
+}
+
+// ImportError is a problem found in a record of an import file
+type ImportError struct {
+	Line    int    // Line of the file where the record starts
+	Field   string // Field at fault, empty when the whole record is
+	Message string
+}
+
+// ImportReport summarizes a bulk import, nothing is written when it holds errors
+type ImportReport struct {
+	Kind    ImportKind
+	DryRun  bool
+	Records int // Records read from the file
+	Created int
+	Updated int // Existing texts or entries overwritten by a record
+	Errors  []ImportError
+}
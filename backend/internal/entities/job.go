@@ -0,0 +1,48 @@
+package entities
+
+import "time"
+
+// JobType identifies the work a background job performs
+type JobType string
+
+const (
+	// JobTypeCreateGaleryEvent uploads the images of a galery event and creates it
+	JobTypeCreateGaleryEvent JobType = "create_galery_event"
+)
+
+// JobState is the lifecycle state of a background job
+type JobState string
+
+const (
+	JobQueued    JobState = "queued"    // Waiting for a worker
+	JobRunning   JobState = "running"   // Picked by a worker
+	JobSucceeded JobState = "succeeded" // Done, Result is set
+	JobFailed    JobState = "failed"    // Done, Error is set
+)
+
+// Finished reports whether a job in this state will never run again
+func (s JobState) Finished() bool {
+	return s == JobSucceeded || s == JobFailed
+}
+
+// Job is a persisted unit of background work
+type Job struct {
+	ID         string            `firestore:"id"`
+	Type       JobType           `firestore:"type"`
+	State      JobState          `firestore:"state"`
+	Progress   JobProgress       `firestore:"progress"`
+	Error      string            `firestore:"error"`
+	Result     map[string]string `firestore:"result"`     // IDs of the resources the job produced, keyed by kind
+	Payload    []byte            `firestore:"payload"`    // JSON input of the job, its shape depends on Type
+	CreatedBy  string            `firestore:"created_by"` // Caller who queued the job, the author of what it produces
+	CreatedAt  time.Time         `firestore:"created_at"`
+	UpdatedAt  time.Time         `firestore:"updated_at"`
+	StartedAt  time.Time         `firestore:"started_at"`
+	FinishedAt time.Time         `firestore:"finished_at"`
+}
+
+// JobProgress counts the steps of a job
+type JobProgress struct {
+	Done  int `firestore:"done"`
+	Total int `firestore:"total"`
+}
@@ -0,0 +1,10 @@
+package entities
+
+import "time"
+
+// Lease lets a single backend instance run a periodic task, the others skip it until the lease expires
+type Lease struct {
+	Name      string    `firestore:"-"`
+	Holder    string    `firestore:"holder"` // Instance holding the lease
+	ExpiresAt time.Time `firestore:"expires_at"`
+}
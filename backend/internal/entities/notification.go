@@ -0,0 +1,61 @@
+package entities
+
+import "time"
+
+// NotificationKind identifies a change of a Grupy event announced to the webhooks
+type NotificationKind string
+
+const (
+	NotificationEventCreated     NotificationKind = "event.created"     // A new event was listed
+	NotificationEventPublished   NotificationKind = "event.published"   // A draft event was published
+	NotificationEventRescheduled NotificationKind = "event.rescheduled" // The start or end of an event moved
+	NotificationEventCancelled   NotificationKind = "event.cancelled"   // An event was cancelled or removed before it ended
+)
+
+// Notification announces a change of a Grupy event
+type Notification struct {
+	ID         string // Unique, receivers may use it to ignore repeated deliveries
+	Kind       NotificationKind
+	Event      Event          // Event after the change, only the snapshot fields for removed events
+	Previous   *EventSnapshot // Event before the change, nil for created events
+	OccurredAt time.Time      // When the change was noticed
+}
+
+// EventSnapshot holds the fields of a Grupy event whose changes are announced
+type EventSnapshot struct {
+	Name     string    `firestore:"name"`
+	State    string    `firestore:"state"`
+	StartsAt time.Time `firestore:"starts_at"`
+	EndsAt   time.Time `firestore:"ends_at"`
+	Link     string    `firestore:"link"`
+}
+
+// SnapshotOf returns the announced fields of an event
+func SnapshotOf(event Event) EventSnapshot {
+	return EventSnapshot{
+		Name:     event.Name,
+		State:    event.State,
+		StartsAt: event.StartsAt,
+		EndsAt:   event.EndsAt,
+		Link:     event.Link,
+	}
+}
+
+// EventWatch is the last seen state of the upcoming Grupy events, persisted between polls
+type EventWatch struct {
+	Events   map[string]EventSnapshot `firestore:"events"` // Keyed by event ID
+	PolledAt time.Time                `firestore:"polled_at"`
+}
+
+// DeadLetter records a notification a webhook didn't accept after every attempt
+type DeadLetter struct {
+	ID             string           `firestore:"id"`
+	Webhook        string           `firestore:"webhook"` // Name of the webhook
+	NotificationID string           `firestore:"notification_id"`
+	Kind           NotificationKind `firestore:"kind"`
+	EventID        string           `firestore:"event_id"`
+	EventName      string           `firestore:"event_name"`
+	Attempts       int              `firestore:"attempts"`
+	Error          string           `firestore:"error"` // Error of the last attempt
+	CreatedAt      time.Time        `firestore:"created_at"`
+}
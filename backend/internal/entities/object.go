@@ -0,0 +1,33 @@
+package entities
+
+import "time"
+
+// ObjectInfo describes an object stored in object storage
+type ObjectInfo struct {
+	Key         string
+	URL         string // Public URL of the object
+	Size        int64
+	ContentType string
+	Generation  int64  // Changes whenever the object is replaced, even by one of the same size
+	CRC32       uint32 // IEEE checksum of the content recorded at upload, 0 when unknown
+}
+
+// SignedUpload holds the data a client needs to upload an object directly to object storage
+type SignedUpload struct {
+	Key       string
+	URL       string
+	Method    string
+	Headers   map[string]string // Headers the client must send along with the upload request
+	ExpiresAt time.Time
+}
+
+// UploadReservation records who reserved a direct upload key, only they may finalize it before it expires
+type UploadReservation struct {
+	ID          string    `firestore:"id"`
+	Key         string    `firestore:"key"`
+	UID         string    `firestore:"uid"` // Empty while authentication is disabled
+	ContentType string    `firestore:"content_type"`
+	ExpiresAt   time.Time `firestore:"expires_at"`
+	ImageID     string    `firestore:"image_id"` // Image the upload was finalized into, empty until then
+	CreatedAt   time.Time `firestore:"created_at"`
+}
@@ -11,5 +11,6 @@ type Text struct {
 	PageSlug      string    `json:"pageSlug,omitempty" firestore:"pageSlug,omitempty"` // Optional
 	CreatedAt     time.Time `json:"createdAt" firestore:"createdAt"`
 	UpdatedAt     time.Time `json:"updatedAt" firestore:"updatedAt"`
+	CreatedBy     string    `json:"createdBy,omitempty" firestore:"createdBy,omitempty"`
 	LastUpdatedBy string    `json:"lastUpdatedBy,omitempty" firestore:"lastUpdatedBy,omitempty"`
 }
@@ -4,12 +4,113 @@ import "time"
 
 // TimelineEntry represents a timeline event
 type TimelineEntry struct {
-	ID            string    `json:"id" firestore:"-"` // Document ID is stored separately, not in document data
-	Name          string    `json:"name" firestore:"name"`
-	Text          string    `json:"text" firestore:"text"`
-	Location      string    `json:"location,omitempty" firestore:"location,omitempty"`
-	Date          time.Time `json:"date" firestore:"date"`
-	CreatedAt     time.Time `json:"createdAt" firestore:"createdAt"`
-	UpdatedAt     time.Time `json:"updatedAt" firestore:"updatedAt"`
-	LastUpdatedBy string    `json:"lastUpdatedBy,omitempty" firestore:"lastUpdatedBy,omitempty"`
+	ID            string           `json:"id" firestore:"-"` // Document ID is stored separately, not in document data
+	Name          string           `json:"name" firestore:"name"`
+	Text          string           `json:"text" firestore:"text"`
+	Location      string           `json:"location,omitempty" firestore:"location,omitempty"`
+	Date          time.Time        `json:"date" firestore:"date"`
+	Category      TimelineCategory `json:"category,omitempty" firestore:"category,omitempty"`
+	Importance    int              `json:"importance,omitempty" firestore:"importance,omitempty"` // From TimelineImportanceLow to TimelineImportanceHigh
+	ImageIDs      []string         `json:"imageIds,omitempty" firestore:"imageIds,omitempty"`     // Photos of the entry, in display order
+	Links         []TimelineLink   `json:"links,omitempty" firestore:"links,omitempty"`           // External pages about the entry, e.g. slides or news
+	Images        []Image          `json:"-" firestore:"-"`                                       // Expanded from ImageIDs on request, never stored
+	Draft         bool             `json:"draft,omitempty" firestore:"draft,omitempty"`           // Hidden from anonymous readers until an editor publishes it
+	Source        string           `json:"source,omitempty" firestore:"source,omitempty"`         // Origin of generated entries, e.g. "grupy:123"
+	SyncedAt      time.Time        `json:"syncedAt,omitzero" firestore:"syncedAt,omitempty"`      // Last write by the sync, later updates are edits
+	CreatedAt     time.Time        `json:"createdAt" firestore:"createdAt"`
+	UpdatedAt     time.Time        `json:"updatedAt" firestore:"updatedAt"`
+	CreatedBy     string           `json:"createdBy,omitempty" firestore:"createdBy,omitempty"`
+	LastUpdatedBy string           `json:"lastUpdatedBy,omitempty" firestore:"lastUpdatedBy,omitempty"`
+}
+
+// TimelineLink is an external page about a timeline entry
+type TimelineLink struct {
+	Title string `json:"title" firestore:"title"`
+	URL   string `json:"url" firestore:"url"`
+}
+
+// TimelineCategory classifies a timeline entry, the History page filters by it
+type TimelineCategory string
+
+const (
+	TimelineMeetup     TimelineCategory = "meetup"     // A community meetup or workshop
+	TimelineConference TimelineCategory = "conference" // A conference organized or attended by the community
+	TimelineMilestone  TimelineCategory = "milestone"  // A landmark in the history of the community
+	TimelineAward      TimelineCategory = "award"      // An award or recognition
+)
+
+// Valid reports whether c is a known category
+func (c TimelineCategory) Valid() bool {
+	switch c {
+	case TimelineMeetup, TimelineConference, TimelineMilestone, TimelineAward:
+		return true
+	}
+	return false
+}
+
+// Importance of a timeline entry, important entries are highlighted on the History page
+const (
+	TimelineImportanceLow    = 1
+	TimelineImportanceNormal = 2 // Default of entries created without importance
+	TimelineImportanceHigh   = 3
+)
+
+// TimelineQuery filters the timeline entries
+type TimelineQuery struct {
+	From     time.Time        // Entries dated at or after From
+	To       time.Time        // Entries dated before To
+	Category TimelineCategory // Entries of a category, any when empty
+
+	ExpandImages bool // Fill the Images of the entries
+}
+
+// Matches reports whether entry meets the conditions of the query
+func (q TimelineQuery) Matches(entry TimelineEntry) bool {
+	switch {
+	case !q.From.IsZero() && entry.Date.Before(q.From):
+		return false
+	case !q.To.IsZero() && !entry.Date.Before(q.To):
+		return false
+	case q.Category != "" && entry.Category != q.Category:
+		return false
+	}
+	return true
+}
+
+// TimelineGrouping is the size of the buckets of a grouped timeline
+type TimelineGrouping string
+
+const (
+	TimelineByYear   TimelineGrouping = "year"
+	TimelineByDecade TimelineGrouping = "decade"
+)
+
+// TimelineGroup is a bucket of timeline entries dated in the same year or decade
+type TimelineGroup struct {
+	Key     string    // e.g. "2024" or "2020s"
+	Start   time.Time // First instant of the bucket
+	Count   int
+	Entries []TimelineEntry // Ordered by date
+}
+
+// Edited reports whether an editor has changed a generated entry since the sync last wrote it
+func (e TimelineEntry) Edited() bool {
+	return e.UpdatedAt.After(e.SyncedAt)
+}
+
+// TimelineSyncReport summarizes a sync of timeline entries from past events
+type TimelineSyncReport struct {
+	Scanned   int // Past events looked at
+	Created   int // Draft entries created
+	Updated   int // Untouched entries refreshed from their event
+	Edited    int // Entries left alone since an editor changed them
+	Unchanged int // Entries already matching their event
+	Dismissed int // Events whose entry an editor deleted, never created again
+}
+
+// TimelineTombstone records a generated timeline entry an editor deleted, so the sync doesn't create it again
+type TimelineTombstone struct {
+	Source    string    `firestore:"-"` // Source of the deleted entry, the ID of the document
+	DeletedAt time.Time `firestore:"deleted_at"`
+	DeletedBy string    `firestore:"deleted_by"`
 }
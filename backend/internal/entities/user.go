@@ -0,0 +1,9 @@
+package entities
+
+// User is the account of an editor, kept by the identity provider
+type User struct {
+	UID         string
+	Email       string
+	DisplayName string
+	Role        string // From the custom claims of the account, empty when never assigned
+}
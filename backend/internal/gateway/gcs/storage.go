@@ -4,20 +4,28 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"hash/crc32"
 	"io"
+	"net/http"
+	"strconv"
 	"strings"
 	"time"
 
 	"cloud.google.com/go/storage"
+	"google.golang.org/api/googleapi"
 	"google.golang.org/api/option"
 
 	"backend/configs"
+	"backend/internal/entities"
+	customerrors "backend/internal/platform/errors"
 )
 
 const (
 	_defaultExpiryInMinutes = 15
 	_publicReadACL          = "publicRead"
+	_publicReadHeaderACL    = "public-read"              // Canned ACL name used by the XML API (x-goog-acl header)
 	_cacheControlImmutable  = "public, max-age=31536000" // 1 year cache for immutable content
+	_crc32MetadataKey       = "crc32"                    // Custom metadata holding the IEEE CRC-32 of the content, hex encoded
 )
 
 // GCSGateway implements object storage operations using Google Cloud Storage
@@ -99,6 +107,46 @@ func NewGCSGatewayWithProvider(ctx context.Context, provider configs.ConfigClien
 
 // PutObject uploads a file to GCS and returns its public URL
 func (g *GCSGateway) PutObject(ctx context.Context, key string, data []byte) (string, error) {
+	return g.putObject(ctx, key, data, g.makePublic)
+}
+
+// PutPrivateObject uploads a file to GCS without the public ACL and returns its URL
+// The URL is only reachable through a signed URL
+func (g *GCSGateway) PutPrivateObject(ctx context.Context, key string, data []byte) (string, error) {
+	return g.putObject(ctx, key, data, false)
+}
+
+// SetObjectPrivate changes the ACL of an existing object
+// Making an object non-private only grants public read access if the gateway is configured to make objects public
+func (g *GCSGateway) SetObjectPrivate(ctx context.Context, key string, private bool) error {
+	// Prepend base path if configured
+	fullKey := g.buildFullKey(key)
+	acl := g.bucket.Object(fullKey).ACL()
+
+	if private {
+		if err := acl.Delete(ctx, storage.AllUsers); err != nil {
+			var apiErr *googleapi.Error
+			if errors.As(err, &apiErr) && apiErr.Code == http.StatusNotFound {
+				// No public ACL entry to remove
+				return nil
+			}
+			return fmt.Errorf("failed to remove public ACL: %w", err)
+		}
+		return nil
+	}
+
+	if !g.makePublic {
+		return nil
+	}
+
+	if err := acl.Set(ctx, storage.AllUsers, storage.RoleReader); err != nil {
+		return fmt.Errorf("failed to set public ACL: %w", err)
+	}
+	return nil
+}
+
+// putObject uploads a file to GCS, optionally setting the public ACL, and returns its public URL
+func (g *GCSGateway) putObject(ctx context.Context, key string, data []byte, public bool) (string, error) {
 	// Prepend base path if configured
 	fullKey := g.buildFullKey(key)
 
@@ -112,8 +160,11 @@ func (g *GCSGateway) PutObject(ctx context.Context, key string, data []byte) (st
 	// Set cache control for long-term caching (immutable content)
 	writer.CacheControl = _cacheControlImmutable
 
-	// Set ACL to public during upload if configured (no separate network call needed)
-	if g.makePublic {
+	// Record the checksum ZIP archives need, so resumed downloads can skip the object
+	writer.Metadata = map[string]string{_crc32MetadataKey: fmt.Sprintf("%08x", crc32.ChecksumIEEE(data))}
+
+	// Set ACL to public during upload if requested (no separate network call needed)
+	if public {
 		writer.PredefinedACL = _publicReadACL
 	}
 
@@ -158,15 +209,85 @@ func (g *GCSGateway) SignedURL(ctx context.Context, key string) (string, error)
 	// Prepend base path if configured
 	fullKey := g.buildFullKey(key)
 
-	// Calculate expiry time
-	expires := time.Now().Add(time.Duration(g.signedURLExpiryMinutes) * time.Minute)
-
 	// Generate signed URL
 	opts := &storage.SignedURLOptions{
 		Method:  "GET",
-		Expires: expires,
+		Expires: g.signedURLExpiry(),
+	}
+
+	return g.signURL(fullKey, opts)
+}
+
+// SignedUploadURL generates a temporary signed URL that lets a client PUT an object directly into the bucket
+// The returned headers are part of the signature and must be sent by the client along with the upload
+func (g *GCSGateway) SignedUploadURL(ctx context.Context, key, contentType string, private bool) (entities.SignedUpload, error) {
+	// Prepend base path if configured
+	fullKey := g.buildFullKey(key)
+	expires := g.signedURLExpiry()
+
+	headers := map[string]string{
+		"Content-Type":  contentType,
+		"Cache-Control": _cacheControlImmutable,
+	}
+	extensionHeaders := []string{"Cache-Control:" + _cacheControlImmutable}
+
+	// Same ACL behaviour as PutObject, enforced through a signed header
+	if g.makePublic && !private {
+		headers["x-goog-acl"] = _publicReadHeaderACL
+		extensionHeaders = append(extensionHeaders, "x-goog-acl:"+_publicReadHeaderACL)
+	}
+
+	opts := &storage.SignedURLOptions{
+		Method:      "PUT",
+		Expires:     expires,
+		ContentType: contentType,
+		Headers:     extensionHeaders,
+		Scheme:      storage.SigningSchemeV4,
 	}
 
+	url, err := g.signURL(fullKey, opts)
+	if err != nil {
+		return entities.SignedUpload{}, err
+	}
+
+	return entities.SignedUpload{
+		Key:       key,
+		URL:       url,
+		Method:    opts.Method,
+		Headers:   headers,
+		ExpiresAt: expires,
+	}, nil
+}
+
+// StatObject returns the attributes of a stored object
+// Returns an error wrapping ErrNotFound if the object doesn't exist
+func (g *GCSGateway) StatObject(ctx context.Context, key string) (entities.ObjectInfo, error) {
+	// Prepend base path if configured
+	fullKey := g.buildFullKey(key)
+
+	attrs, err := g.bucket.Object(fullKey).Attrs(ctx)
+	if err != nil {
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return entities.ObjectInfo{}, fmt.Errorf("%w: object %s", customerrors.ErrNotFound, key)
+		}
+		return entities.ObjectInfo{}, fmt.Errorf("failed to get object attributes: %w", err)
+	}
+
+	// Objects uploaded without checksum, e.g. directly by clients, leave it unknown
+	checksum, _ := strconv.ParseUint(attrs.Metadata[_crc32MetadataKey], 16, 32)
+
+	return entities.ObjectInfo{
+		Key:         key,
+		URL:         g.getPublicURL(fullKey),
+		Size:        attrs.Size,
+		ContentType: attrs.ContentType,
+		Generation:  attrs.Generation,
+		CRC32:       uint32(checksum),
+	}, nil
+}
+
+// signURL signs a URL for the given full key using the bucket credentials
+func (g *GCSGateway) signURL(fullKey string, opts *storage.SignedURLOptions) (string, error) {
 	url, err := g.bucket.SignedURL(fullKey, opts)
 	if err != nil {
 		return "", fmt.Errorf("failed to generate signed URL: %w", err)
@@ -175,6 +296,21 @@ func (g *GCSGateway) SignedURL(ctx context.Context, key string) (string, error)
 	return url, nil
 }
 
+// signedURLExpiry calculates the expiry time for a signed URL created now
+func (g *GCSGateway) signedURLExpiry() time.Time {
+	return time.Now().Add(time.Duration(g.signedURLExpiryMinutes) * time.Minute)
+}
+
+// KeyFromURL returns the key of the object served at a public URL of the bucket
+// The base path is removed, like the keys taken by the other methods
+func (g *GCSGateway) KeyFromURL(url string) string {
+	key := strings.TrimPrefix(url, g.getPublicURL(""))
+	if g.basePath != "" {
+		key = strings.TrimPrefix(key, g.basePath+"/")
+	}
+	return key
+}
+
 // buildFullKey constructs the full object key by prepending the base path
 func (g *GCSGateway) buildFullKey(key string) string {
 	if g.basePath == "" {
@@ -198,14 +334,17 @@ func (g *GCSGateway) Close() error {
 	return nil
 }
 
-// GetObject retrieves an object's content from GCS (helper method, not in port interface)
-// This can be useful for testing or future features
+// GetObject retrieves an object's content from GCS
+// Returns an error wrapping ErrNotFound if the object doesn't exist
 func (g *GCSGateway) GetObject(ctx context.Context, key string) ([]byte, error) {
-	obj := g.bucket.Object(key)
+	// Prepend base path if configured
+	fullKey := g.buildFullKey(key)
+
+	obj := g.bucket.Object(fullKey)
 	reader, err := obj.NewReader(ctx)
 	if err != nil {
-		if err == storage.ErrObjectNotExist {
-			return nil, fmt.Errorf("object not found: %s", key)
+		if errors.Is(err, storage.ErrObjectNotExist) {
+			return nil, fmt.Errorf("%w: object %s", customerrors.ErrNotFound, key)
 		}
 		return nil, fmt.Errorf("failed to create reader: %w", err)
 	}
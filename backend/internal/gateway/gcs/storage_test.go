@@ -0,0 +1,43 @@
+package gcs
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGCSGateway_BuildFullKey(t *testing.T) {
+	g := &GCSGateway{bucketName: "grupy-sanca", basePath: "prod/images"}
+
+	assert.Equal(t, "prod/images/sunset-123.jpg", g.buildFullKey("sunset-123.jpg"))
+	assert.Equal(t, "prod/images/uploads/abc/sunset.png", g.buildFullKey("/uploads/abc/sunset.png"))
+	assert.Equal(t, "prod/images/prod/images/sunset-123.jpg", g.buildFullKey("prod/images/sunset-123.jpg"),
+		"Keys are always under the base path, even when they look like they already are")
+
+	g.basePath = ""
+	assert.Equal(t, "sunset-123.jpg", g.buildFullKey("sunset-123.jpg"))
+}
+
+func TestGCSGateway_KeyFromURL(t *testing.T) {
+	tests := []struct {
+		name     string
+		basePath string
+		key      string
+	}{
+		{name: "base path", basePath: "prod/images", key: "sunset-123.jpg"},
+		{name: "nested key", basePath: "prod/images", key: "galery_events/0b7e/20240501_0.jpg"},
+		{name: "key repeating the base path", basePath: "prod/images", key: "prod/images/sunset-123.jpg"},
+		{name: "no base path", basePath: "", key: "uploads/abc/sunset.png"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			g := &GCSGateway{bucketName: "grupy-sanca", basePath: tt.basePath}
+
+			// Stored URLs resolve to the key they were uploaded with, so they address the same object
+			url := g.getPublicURL(g.buildFullKey(tt.key))
+			assert.Equal(t, tt.key, g.KeyFromURL(url))
+			assert.Equal(t, g.buildFullKey(tt.key), g.buildFullKey(g.KeyFromURL(url)))
+		})
+	}
+}
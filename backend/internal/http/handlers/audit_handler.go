@@ -0,0 +1,72 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+
+	"backend/internal/entities"
+	"backend/internal/http/mapper"
+	"backend/internal/platform/httputil"
+)
+
+// ListAuditRecords handles GET /api/v1/audit?actor=&action=&entity_type=&entity_id=&from=&to=&limit=N&page=N
+// Lists the audit log newest first. from and to (RFC 3339 or YYYY-MM-DD) bound when the mutations happened,
+// meta.has_more tells whether the next page holds more records
+func (h *BaseHandler) ListAuditRecords(w http.ResponseWriter, r *http.Request) {
+	query, err := parseAuditQuery(r.URL.Query())
+	if err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	page, err := h.server.ListAuditRecords(r.Context(), query)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.AuditPageToResponse(page)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
+// parseAuditQuery reads the query parameters of GET /api/v1/audit
+// Missing limit and page are left to the service defaults, malformed ones are rejected
+func parseAuditQuery(values url.Values) (entities.AuditQuery, error) {
+	query := entities.AuditQuery{
+		Actor:      values.Get("actor"),
+		Action:     entities.AuditAction(values.Get("action")),
+		EntityType: entities.AuditEntityType(values.Get("entity_type")),
+		EntityID:   values.Get("entity_id"),
+	}
+
+	var err error
+	if query.Limit, err = parsePositiveInt(values.Get("limit")); err != nil {
+		return entities.AuditQuery{}, fmt.Errorf("invalid limit: %w", err)
+	}
+	if query.Page, err = parsePositiveInt(values.Get("page")); err != nil {
+		return entities.AuditQuery{}, fmt.Errorf("invalid page: %w", err)
+	}
+
+	if query.From, err = parseEventDate(values.Get("from"), false); err != nil {
+		return entities.AuditQuery{}, fmt.Errorf("invalid from: %w", err)
+	}
+	if query.To, err = parseEventDate(values.Get("to"), true); err != nil {
+		return entities.AuditQuery{}, fmt.Errorf("invalid to: %w", err)
+	}
+
+	return query, nil
+}
+
+// parsePositiveInt parses an optional positive integer query parameter, absent means 0
+func parsePositiveInt(value string) (int, error) {
+	if value == "" {
+		return 0, nil
+	}
+	n, err := strconv.Atoi(value)
+	if err != nil || n < 1 {
+		return 0, fmt.Errorf("%q is not a positive integer", value)
+	}
+	return n, nil
+}
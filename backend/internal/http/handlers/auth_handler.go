@@ -3,6 +3,7 @@ package handlers
 import (
 	"net/http"
 
+	"backend/internal/platform/auth"
 	"backend/internal/platform/httputil"
 )
 
@@ -13,10 +14,14 @@ func (h *BaseHandler) Authorized(w http.ResponseWriter, r *http.Request) {
 	// If we reach this handler, the authentication middleware has already
 	// verified that the request is authorized (otherwise it would have
 	// returned 401 Unauthorized)
+	principal, _ := auth.PrincipalFromContext(r.Context())
 	response := map[string]interface{}{
 		"authorized": true,
 		"message":    "Request is authorized",
 		"status":     "success",
+		"uid":        principal.UID,
+		"email":      principal.Email,
+		"role":       principal.Role,
 	}
 
 	httputil.JSON(w, response, http.StatusOK)
@@ -1,40 +1,202 @@
 package handlers
 
 import (
+	"fmt"
 	"net/http"
+	"net/url"
 	"strconv"
+	"time"
 
+	"backend/internal/entities"
 	"backend/internal/http/mapper"
 	"backend/internal/platform/httputil"
 )
 
-// GetEvents handles GET /api/v1/events?limit=N&orderBy=starts-at&desc=true
+// calendarContentType is the media type of iCalendar responses
+const calendarContentType = "text/calendar; charset=utf-8"
+
+// GetEvents handles GET /api/v1/events?limit=N&page=N&orderBy=starts-at&desc=true
 // Follows the same logic as the Grupy API query and filter field names: starts-at, ends-at, name, created-at, etc.
+// Filters: from and to (RFC 3339 or YYYY-MM-DD) bound the start date, upcoming=true or past=true,
+// q searches the name and state matches the event state. meta.count is the number of matching events
+// Events of the partner sources are merged in, meta.failed_sources lists the sources that couldn't be read
 func (h *BaseHandler) GetEvents(w http.ResponseWriter, r *http.Request) {
-	// Parse query parameters
-	query := r.URL.Query()
+	query, err := parseEventQuery(r.URL.Query())
+	if err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	// Call service
+	page, err := h.server.GetEvents(r.Context(), query)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
 
-	limit := 10
-	if limitStr := query.Get("limit"); limitStr != "" {
+	response := mapper.EventListToResponse(page)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
+// parseEventQuery reads the query parameters of GET /api/v1/events
+// A limit that isn't a number falls back to the default, other malformed parameters are rejected
+func parseEventQuery(values url.Values) (entities.EventQuery, error) {
+	query := entities.EventQuery{
+		Limit:   10,
+		OrderBy: values.Get("orderBy"), // Pass through, default handled at client level
+		Desc:    values.Get("desc") == "true",
+		Search:  values.Get("q"),
+		State:   values.Get("state"),
+	}
+
+	if limitStr := values.Get("limit"); limitStr != "" {
 		if parsed, err := strconv.Atoi(limitStr); err == nil {
-			limit = parsed
+			query.Limit = parsed
 		}
 	}
 
-	orderBy := query.Get("orderBy") // Pass through, default handled at client level
+	if pageStr := values.Get("page"); pageStr != "" {
+		page, err := strconv.Atoi(pageStr)
+		if err != nil || page < 1 {
+			return entities.EventQuery{}, fmt.Errorf("page must be a positive integer")
+		}
+		query.Page = page
+	}
 
-	desc := false
-	if descStr := query.Get("desc"); descStr == "true" {
-		desc = true
+	var err error
+	if query.From, err = parseEventDate(values.Get("from"), false); err != nil {
+		return entities.EventQuery{}, fmt.Errorf("invalid from: %w", err)
+	}
+	if query.To, err = parseEventDate(values.Get("to"), true); err != nil {
+		return entities.EventQuery{}, fmt.Errorf("invalid to: %w", err)
 	}
 
-	// Call service
-	events, err := h.server.GetEvents(r.Context(), limit, orderBy, desc)
+	if query.Upcoming, err = parseOptionalBool(values.Get("upcoming")); err != nil {
+		return entities.EventQuery{}, fmt.Errorf("invalid upcoming: %w", err)
+	}
+	if query.Past, err = parseOptionalBool(values.Get("past")); err != nil {
+		return entities.EventQuery{}, fmt.Errorf("invalid past: %w", err)
+	}
+
+	return query, nil
+}
+
+// parseEventDate parses an RFC 3339 timestamp or a YYYY-MM-DD date
+// A date used as an upper bound covers the whole day, so the next midnight is returned
+func parseEventDate(value string, endOfDay bool) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+
+	day, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not an RFC 3339 timestamp or a YYYY-MM-DD date", value)
+	}
+	if endOfDay {
+		day = day.AddDate(0, 0, 1)
+	}
+	return day, nil
+}
+
+// parseOptionalBool parses a boolean query parameter, absent means false
+func parseOptionalBool(value string) (bool, error) {
+	if value == "" {
+		return false, nil
+	}
+	return strconv.ParseBool(value)
+}
+
+// GetEventByID handles GET /api/v1/events/{id}
+// Returns a Grupy event with its tracks
+func (h *BaseHandler) GetEventByID(w http.ResponseWriter, r *http.Request) {
+	id := extractPathParam(r, "id")
+
+	event, err := h.server.GetEventByID(r.Context(), id)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.EventToResponse(event)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
+// GetEventSessions handles GET /api/v1/events/{id}/sessions
+// Sessions are in schedule order, unscheduled ones last
+func (h *BaseHandler) GetEventSessions(w http.ResponseWriter, r *http.Request) {
+	id := extractPathParam(r, "id")
+
+	sessions, err := h.server.GetEventSessions(r.Context(), id)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.SessionsToResponse(sessions)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
+// GetEventSpeakers handles GET /api/v1/events/{id}/speakers
+func (h *BaseHandler) GetEventSpeakers(w http.ResponseWriter, r *http.Request) {
+	id := extractPathParam(r, "id")
+
+	speakers, err := h.server.GetEventSpeakers(r.Context(), id)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.SpeakersToResponse(speakers)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
+// GetEventsCalendar handles GET /api/v1/events.ics
+// Serves the most recent events as an iCalendar feed calendar clients can subscribe to
+func (h *BaseHandler) GetEventsCalendar(w http.ResponseWriter, r *http.Request) {
+	calendar, err := h.server.GetEventsCalendar(r.Context())
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", calendarContentType)
+	w.Header().Set("Content-Disposition", `inline; filename="grupysanca.ics"`)
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(calendar)
+}
+
+// GetEventCalendar handles GET /api/v1/events/{id}/calendar.ics
+// Downloads a single event as an iCalendar file
+func (h *BaseHandler) GetEventCalendar(w http.ResponseWriter, r *http.Request) {
+	id := extractPathParam(r, "id")
+
+	calendar, err := h.server.GetEventCalendar(r.Context(), id)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", calendarContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", "event-"+id+".ics"))
+	w.WriteHeader(http.StatusOK)
+	_, _ = w.Write(calendar)
+}
+
+// GetEventGalery handles GET /api/v1/events/{id}/galery
+// Returns the galery event holding the photos of a Grupy event
+func (h *BaseHandler) GetEventGalery(w http.ResponseWriter, r *http.Request) {
+	id := extractPathParam(r, "id")
+
+	event, err := h.server.GetGaleryEventByGrupyEventID(r.Context(), id)
 	if err != nil {
 		httputil.ErrorFromDomain(w, err)
 		return
 	}
 
-	response := mapper.EventsToResponse(events)
+	response := mapper.GaleryEventToResponse(event)
 	httputil.JSON(w, response, http.StatusOK)
 }
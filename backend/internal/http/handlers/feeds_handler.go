@@ -0,0 +1,43 @@
+package handlers
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"path"
+	"strings"
+
+	"backend/internal/entities"
+	"backend/internal/platform/httputil"
+)
+
+// feedContentTypes maps feed formats to their media type
+var feedContentTypes = map[entities.FeedFormat]string{
+	entities.FeedAtom: "application/atom+xml; charset=utf-8",
+	entities.FeedRSS:  "application/rss+xml; charset=utf-8",
+}
+
+// GetFeed handles GET /api/v1/feeds/{name}, where name is the feed and its format, e.g. timeline.atom or galery.rss
+// Feeds carry an ETag and a Last-Modified date, If-None-Match and If-Modified-Since are answered with 304
+func (h *BaseHandler) GetFeed(w http.ResponseWriter, r *http.Request) {
+	name := extractPathParam(r, "name")
+
+	kind, format := strings.TrimSuffix(name, path.Ext(name)), strings.TrimPrefix(path.Ext(name), ".")
+	contentType, ok := feedContentTypes[entities.FeedFormat(format)]
+	if !ok {
+		httputil.Error(w, fmt.Errorf("feed %q not found, use the .atom or .rss extension", name), http.StatusNotFound)
+		return
+	}
+
+	feed, err := h.server.GetFeed(r.Context(), entities.FeedKind(kind), entities.FeedFormat(format))
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("ETag", feed.ETag)
+
+	// ServeContent evaluates the conditional headers against the ETag and the modification time
+	http.ServeContent(w, r, name, feed.ModTime, bytes.NewReader(feed.Content))
+}
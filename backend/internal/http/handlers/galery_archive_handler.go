@@ -0,0 +1,95 @@
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"backend/internal/platform/httputil"
+)
+
+// errRangeDone stops the archive stream once the requested range has been written
+var errRangeDone = errors.New("range written")
+
+// GetGaleryEventArchive handles GET /api/v1/galery_events/{id}/archive
+// Streams a ZIP with the original images and a manifest, supporting Range requests to resume downloads
+func (h *BaseHandler) GetGaleryEventArchive(w http.ResponseWriter, r *http.Request) {
+	id := extractPathParam(r, "id")
+
+	archive, err := h.server.GetGaleryEventArchive(r.Context(), id)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	w.Header().Set("Accept-Ranges", "bytes")
+	w.Header().Set("ETag", archive.ETag)
+	w.Header().Set("Last-Modified", archive.ModTime.Format(http.TimeFormat))
+
+	byteRange, partial, err := httputil.ParseRange(r.Header.Get("Range"), archive.Size)
+	if ifRange := r.Header.Get("If-Range"); ifRange != "" && ifRange != archive.ETag {
+		// The archive changed since the first part was downloaded, start over
+		partial, err = false, nil
+	}
+	if err != nil {
+		w.Header().Set("Content-Range", fmt.Sprintf("bytes */%d", archive.Size))
+		httputil.Error(w, err, http.StatusRequestedRangeNotSatisfiable)
+		return
+	}
+
+	status := http.StatusOK
+	if partial {
+		status = http.StatusPartialContent
+		w.Header().Set("Content-Range", byteRange.ContentRange(archive.Size))
+	} else {
+		byteRange = httputil.ByteRange{Start: 0, End: archive.Size - 1}
+	}
+
+	w.Header().Set("Content-Type", "application/zip")
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", archive.Name))
+	w.Header().Set("Content-Length", strconv.FormatInt(byteRange.Length(), 10))
+
+	// Large archives take longer than the server write timeout
+	_ = http.NewResponseController(w).SetWriteDeadline(time.Time{})
+	w.WriteHeader(status)
+
+	// Photos ending before the range aren't downloaded again
+	window := &rangeWriter{w: w, skip: byteRange.Start, remaining: byteRange.Length()}
+	if err := h.server.WriteArchive(r.Context(), archive, window, byteRange.Start); err != nil && !errors.Is(err, errRangeDone) {
+		// Headers are gone, the short body tells the client the download failed
+		return
+	}
+}
+
+// rangeWriter forwards only the bytes of a range to w, failing with errRangeDone once it is complete
+type rangeWriter struct {
+	w         io.Writer
+	skip      int64
+	remaining int64
+}
+
+func (rw *rangeWriter) Write(p []byte) (int, error) {
+	n := len(p)
+
+	if rw.skip > 0 {
+		skipped := min(rw.skip, int64(len(p)))
+		rw.skip -= skipped
+		p = p[skipped:]
+	}
+
+	if len(p) > 0 && rw.remaining > 0 {
+		chunk := p[:min(rw.remaining, int64(len(p)))]
+		if _, err := rw.w.Write(chunk); err != nil {
+			return 0, err
+		}
+		rw.remaining -= int64(len(chunk))
+	}
+
+	if rw.remaining == 0 {
+		return n, errRangeDone
+	}
+	return n, nil
+}
@@ -5,11 +5,13 @@ import (
 	"fmt"
 	"net/http"
 
+	"backend/internal/entities"
 	"backend/internal/http/mapper"
 	"backend/internal/platform/httputil"
 )
 
-// CreateGaleryEvent handles POST /api/v1/galery_events
+// CreateGaleryEvent handles POST /api/v1/galery_events?async=true
+// With async=true the images are uploaded by a background job and 202 is returned with the job
 func (h *BaseHandler) CreateGaleryEvent(w http.ResponseWriter, r *http.Request) {
 	var req mapper.CreateGaleryEventRequest
 	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
@@ -35,13 +37,29 @@ func (h *BaseHandler) CreateGaleryEvent(w http.ResponseWriter, r *http.Request)
 		return
 	}
 
+	if r.URL.Query().Get("async") == "true" {
+		job, err := h.server.CreateGaleryEventJob(
+			r.Context(),
+			mapper.ToGaleryEventEntity(req),
+			req.ImagesBase64,
+			entities.DuplicatePolicy(req.OnDuplicate),
+		)
+		if err != nil {
+			httputil.ErrorFromDomain(w, err)
+			return
+		}
+
+		w.Header().Set("Location", "/api/v1/jobs/"+job.ID)
+		httputil.JSON(w, mapper.JobToResponse(job), http.StatusAccepted)
+		return
+	}
+
 	// Create galery event (uploads images and saves to DB)
 	created, err := h.server.CreateGaleryEvent(
 		r.Context(),
-		req.Name,
-		req.Location,
-		req.Date,
+		mapper.ToGaleryEventEntity(req),
 		req.ImagesBase64,
+		entities.DuplicatePolicy(req.OnDuplicate),
 	)
 	if err != nil {
 		httputil.ErrorFromDomain(w, err)
@@ -113,3 +131,96 @@ func (h *BaseHandler) DeleteGaleryEvent(w http.ResponseWriter, r *http.Request)
 
 	w.WriteHeader(http.StatusNoContent)
 }
+
+// AddGaleryEventImage handles POST /api/v1/galery_events/{id}/images
+// Uploads an image and appends it to the galery event
+func (h *BaseHandler) AddGaleryEventImage(w http.ResponseWriter, r *http.Request) {
+	id := extractPathParam(r, "id")
+
+	var req mapper.AddGaleryEventImageRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if req.Data == "" {
+		httputil.Error(w, fmt.Errorf("data is required"), http.StatusBadRequest)
+		return
+	}
+
+	item, meta, data, err := mapper.ToGaleryEventImageEntity(req)
+	if err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.server.AddGaleryEventImage(r.Context(), id, item, meta, data, entities.DuplicatePolicy(req.OnDuplicate))
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.GaleryEventToResponse(updated)
+	httputil.JSON(w, response, http.StatusCreated)
+}
+
+// RemoveGaleryEventImage handles DELETE /api/v1/galery_events/{id}/images/{imageId}?delete=true
+// Detaches the image from the galery event, deleting it as well when asked to
+func (h *BaseHandler) RemoveGaleryEventImage(w http.ResponseWriter, r *http.Request) {
+	id := extractPathParam(r, "id")
+	imageID := extractPathParam(r, "imageId")
+
+	deleteImage := false
+	if deleteStr := r.URL.Query().Get("delete"); deleteStr == "true" {
+		deleteImage = true
+	}
+
+	updated, err := h.server.RemoveGaleryEventImage(r.Context(), id, imageID, deleteImage)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.GaleryEventToResponse(updated)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
+// ReorderGaleryEventImages handles PUT /api/v1/galery_events/{id}/images/order
+func (h *BaseHandler) ReorderGaleryEventImages(w http.ResponseWriter, r *http.Request) {
+	id := extractPathParam(r, "id")
+
+	var req mapper.ReorderGaleryEventImagesRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.server.ReorderGaleryEventImages(r.Context(), id, req.ImageIDs)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.GaleryEventToResponse(updated)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
+// SetGaleryEventVisibility handles PUT /api/v1/galery_events/{id}/visibility
+func (h *BaseHandler) SetGaleryEventVisibility(w http.ResponseWriter, r *http.Request) {
+	id := extractPathParam(r, "id")
+
+	var req mapper.SetGaleryEventVisibilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.server.SetGaleryEventVisibility(r.Context(), id, req.Private)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.GaleryEventToResponse(updated)
+	httputil.JSON(w, response, http.StatusOK)
+}
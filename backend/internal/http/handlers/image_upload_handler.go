@@ -0,0 +1,65 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"backend/internal/entities"
+	"backend/internal/http/mapper"
+	"backend/internal/platform/httputil"
+)
+
+// CreateImageUpload handles POST /api/v1/images/uploads
+// Returns a short-lived signed URL the client uses to PUT the image straight into the bucket
+func (h *BaseHandler) CreateImageUpload(w http.ResponseWriter, r *http.Request) {
+	var req mapper.CreateImageUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if req.ContentType == "" {
+		httputil.Error(w, fmt.Errorf("content_type is required"), http.StatusBadRequest)
+		return
+	}
+
+	upload, err := h.server.CreateImageUpload(r.Context(), req.Slug, req.ContentType, req.Private)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.ImageUploadToResponse(upload)
+	httputil.JSON(w, response, http.StatusCreated)
+}
+
+// FinalizeImageUpload handles POST /api/v1/images/uploads/finalize
+// Validates the uploaded object and creates the image metadata
+func (h *BaseHandler) FinalizeImageUpload(w http.ResponseWriter, r *http.Request) {
+	var req mapper.FinalizeImageUploadRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	if req.Key == "" {
+		httputil.Error(w, fmt.Errorf("key is required"), http.StatusBadRequest)
+		return
+	}
+
+	meta, err := mapper.ToFinalizedImageEntity(req)
+	if err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	created, err := h.server.FinalizeImageUpload(r.Context(), req.Key, meta, entities.DuplicatePolicy(req.OnDuplicate))
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.ImageToResponse(created)
+	httputil.JSON(w, response, http.StatusCreated)
+}
@@ -4,6 +4,7 @@ import (
 	"encoding/json"
 	"net/http"
 
+	"backend/internal/entities"
 	"backend/internal/http/mapper"
 	"backend/internal/platform/httputil"
 )
@@ -36,9 +37,16 @@ func (h *BaseHandler) GetImagesBySlug(w http.ResponseWriter, r *http.Request) {
 	httputil.JSON(w, response, http.StatusOK)
 }
 
-// ListImages handles GET /api/v1/images
+// ListImages handles GET /api/v1/images?tag=name
+// With a tag only the images carrying it are listed
 func (h *BaseHandler) ListImages(w http.ResponseWriter, r *http.Request) {
-	images, err := h.server.ListAllImages(r.Context())
+	var images []entities.Image
+	var err error
+	if tag := r.URL.Query().Get("tag"); tag != "" {
+		images, err = h.server.ListImagesByTag(r.Context(), tag)
+	} else {
+		images, err = h.server.ListAllImages(r.Context())
+	}
 	if err != nil {
 		httputil.ErrorFromDomain(w, err)
 		return
@@ -48,6 +56,19 @@ func (h *BaseHandler) ListImages(w http.ResponseWriter, r *http.Request) {
 	httputil.JSON(w, response, http.StatusOK)
 }
 
+// ListDuplicateImages handles GET /api/v1/images/duplicates
+// Reports clusters of images that share the same content hash
+func (h *BaseHandler) ListDuplicateImages(w http.ResponseWriter, r *http.Request) {
+	clusters, err := h.server.ListDuplicateImages(r.Context())
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.DuplicateClustersToResponse(clusters)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
 // CreateImage handles POST /api/v1/images
 func (h *BaseHandler) CreateImage(w http.ResponseWriter, r *http.Request) {
 	var req mapper.CreateImageRequest
@@ -62,7 +83,7 @@ func (h *BaseHandler) CreateImage(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	created, err := h.server.UploadImage(r.Context(), meta, data)
+	created, err := h.server.UploadImage(r.Context(), meta, data, entities.DuplicatePolicy(req.OnDuplicate))
 	if err != nil {
 		httputil.ErrorFromDomain(w, err)
 		return
@@ -98,6 +119,26 @@ func (h *BaseHandler) UpdateImage(w http.ResponseWriter, r *http.Request) {
 	httputil.JSON(w, response, http.StatusOK)
 }
 
+// SetImageVisibility handles PUT /api/v1/images/{id}/visibility
+func (h *BaseHandler) SetImageVisibility(w http.ResponseWriter, r *http.Request) {
+	id := extractPathParam(r, "id")
+
+	var req mapper.SetImageVisibilityRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.server.SetImageVisibility(r.Context(), id, req.Private)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.ImageToResponse(updated)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
 // DeleteImage handles DELETE /api/v1/images/{id}
 func (h *BaseHandler) DeleteImage(w http.ResponseWriter, r *http.Request) {
 	id := extractPathParam(r, "id")
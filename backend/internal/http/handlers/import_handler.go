@@ -0,0 +1,59 @@
+package handlers
+
+import (
+	"fmt"
+	"net/http"
+
+	"backend/internal/entities"
+	"backend/internal/http/mapper"
+	"backend/internal/platform/httputil"
+	"backend/internal/platform/importer"
+)
+
+// maxImportSize bounds the body of an import request
+const maxImportSize = 5 << 20
+
+// Import handles POST /api/v1/import?kind=timeline|texts&format=csv|yaml|markdown&dry_run=true
+// The body is the import file, its format is read from the Content-Type when format is left out
+// Invalid files are answered with 422 and the report listing the errors by line, nothing is written then
+func (h *BaseHandler) Import(w http.ResponseWriter, r *http.Request) {
+	values := r.URL.Query()
+	kind := entities.ImportKind(values.Get("kind"))
+
+	format, err := parseImportFormat(values.Get("format"), r.Header.Get("Content-Type"))
+	if err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	dryRun, err := parseOptionalBool(values.Get("dry_run"))
+	if err != nil {
+		httputil.Error(w, fmt.Errorf("invalid dry_run: %w", err), http.StatusBadRequest)
+		return
+	}
+
+	body := http.MaxBytesReader(w, r.Body, maxImportSize)
+	report, err := h.server.Import(r.Context(), kind, format, body, dryRun)
+	if len(report.Errors) > 0 {
+		httputil.JSON(w, mapper.ImportReportToResponse(report), http.StatusUnprocessableEntity)
+		return
+	}
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.ImportReportToResponse(report)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
+// parseImportFormat reads the format parameter, falling back to the media type of the body
+func parseImportFormat(format, contentType string) (importer.Format, error) {
+	if format != "" {
+		return importer.ParseFormat(format)
+	}
+	if contentType == "" {
+		return "", fmt.Errorf("format is required when the body has no Content-Type")
+	}
+	return importer.FormatFromContentType(contentType)
+}
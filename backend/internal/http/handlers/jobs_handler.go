@@ -0,0 +1,23 @@
+package handlers
+
+import (
+	"net/http"
+
+	"backend/internal/http/mapper"
+	"backend/internal/platform/httputil"
+)
+
+// GetJob handles GET /api/v1/jobs/{id}
+// Reports the state and progress of a background job
+func (h *BaseHandler) GetJob(w http.ResponseWriter, r *http.Request) {
+	id := extractPathParam(r, "id")
+
+	job, err := h.server.GetJob(r.Context(), id)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.JobToResponse(job)
+	httputil.JSON(w, response, http.StatusOK)
+}
@@ -0,0 +1,21 @@
+package handlers
+
+import (
+	"net/http"
+
+	"backend/internal/http/mapper"
+	"backend/internal/platform/httputil"
+)
+
+// ListDeadLetters handles GET /api/v1/notifications/dead_letters
+// Lists the event notifications the webhooks didn't accept, newest first
+func (h *BaseHandler) ListDeadLetters(w http.ResponseWriter, r *http.Request) {
+	letters, err := h.server.ListDeadLetters(r.Context())
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.DeadLettersToResponse(letters)
+	httputil.JSON(w, response, http.StatusOK)
+}
@@ -0,0 +1,73 @@
+package handlers
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+
+	"backend/internal/http/mapper"
+	"backend/internal/platform/httputil"
+)
+
+// maxBulkTagImageIDs bounds the images tagged or untagged by one request
+const maxBulkTagImageIDs = 500
+
+// ListTags handles GET /api/v1/tags
+// Lists the tags in use along with the number of images carrying them
+func (h *BaseHandler) ListTags(w http.ResponseWriter, r *http.Request) {
+	tags, err := h.server.ListTags(r.Context())
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.TagCountsToResponse(tags)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
+// TagImages handles POST /api/v1/images/tags
+// Adds tags to several images at once
+func (h *BaseHandler) TagImages(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeBulkTagRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.server.TagImages(r.Context(), req.ImageIDs, req.Tags); err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	httputil.NoContent(w)
+}
+
+// UntagImages handles POST /api/v1/images/tags/remove
+// Removes tags from several images at once
+func (h *BaseHandler) UntagImages(w http.ResponseWriter, r *http.Request) {
+	req, ok := decodeBulkTagRequest(w, r)
+	if !ok {
+		return
+	}
+
+	if err := h.server.UntagImages(r.Context(), req.ImageIDs, req.Tags); err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	httputil.NoContent(w)
+}
+
+// decodeBulkTagRequest reads a bulk tag request, answering 400 when it is unreadable or lists too many images
+func decodeBulkTagRequest(w http.ResponseWriter, r *http.Request) (mapper.BulkTagRequest, bool) {
+	var req mapper.BulkTagRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return req, false
+	}
+
+	if len(req.ImageIDs) > maxBulkTagImageIDs {
+		httputil.Error(w, fmt.Errorf("at most %d image ids are accepted per request", maxBulkTagImageIDs), http.StatusBadRequest)
+		return req, false
+	}
+	return req, true
+}
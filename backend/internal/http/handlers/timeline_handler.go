@@ -2,15 +2,27 @@ package handlers
 
 import (
 	"encoding/json"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strings"
 
+	"backend/internal/entities"
 	"backend/internal/http/mapper"
 	"backend/internal/platform/httputil"
 )
 
-// ListTimelineEntries handles GET /api/v1/timelineentries
+// ListTimelineEntries handles GET /api/v1/timelineentries?from=2020-01-01&to=2024-12-31&category=meetup
+// Filters: from and to (RFC 3339 or YYYY-MM-DD) bound the entry date, category matches the entry category
+// expand=images fills the images of every entry
 func (h *BaseHandler) ListTimelineEntries(w http.ResponseWriter, r *http.Request) {
-	entries, err := h.server.ListTimelineEntries(r.Context())
+	query, err := parseTimelineQuery(r.URL.Query())
+	if err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	entries, err := h.server.ListTimelineEntries(r.Context(), query)
 	if err != nil {
 		httputil.ErrorFromDomain(w, err)
 		return
@@ -20,11 +32,75 @@ func (h *BaseHandler) ListTimelineEntries(w http.ResponseWriter, r *http.Request
 	httputil.JSON(w, response, http.StatusOK)
 }
 
-// GetTimelineEntryByID handles GET /api/v1/timelineentries/{id}
+// GroupTimelineEntries handles GET /api/v1/timelineentries/grouped?by=year|decade
+// Accepts the filters of ListTimelineEntries, by defaults to year
+func (h *BaseHandler) GroupTimelineEntries(w http.ResponseWriter, r *http.Request) {
+	query, err := parseTimelineQuery(r.URL.Query())
+	if err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	by := entities.TimelineGrouping(r.URL.Query().Get("by"))
+	if by == "" {
+		by = entities.TimelineByYear
+	}
+
+	groups, err := h.server.GroupTimelineEntries(r.Context(), query, by)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.TimelineGroupsToResponse(string(by), groups)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
+// parseTimelineExpand reads the comma separated expand parameter, images is the only expansion
+func parseTimelineExpand(values url.Values) (bool, error) {
+	expandImages := false
+	for _, field := range strings.Split(values.Get("expand"), ",") {
+		switch strings.TrimSpace(field) {
+		case "":
+		case "images":
+			expandImages = true
+		default:
+			return false, fmt.Errorf("invalid expand: timeline entries can only expand images, not %q", field)
+		}
+	}
+	return expandImages, nil
+}
+
+// parseTimelineQuery reads the filters of the timeline listings
+func parseTimelineQuery(values url.Values) (entities.TimelineQuery, error) {
+	query := entities.TimelineQuery{
+		Category: entities.TimelineCategory(values.Get("category")),
+	}
+
+	var err error
+	if query.ExpandImages, err = parseTimelineExpand(values); err != nil {
+		return entities.TimelineQuery{}, err
+	}
+	if query.From, err = parseEventDate(values.Get("from"), false); err != nil {
+		return entities.TimelineQuery{}, fmt.Errorf("invalid from: %w", err)
+	}
+	if query.To, err = parseEventDate(values.Get("to"), true); err != nil {
+		return entities.TimelineQuery{}, fmt.Errorf("invalid to: %w", err)
+	}
+	return query, nil
+}
+
+// GetTimelineEntryByID handles GET /api/v1/timelineentries/{id}?expand=images
 func (h *BaseHandler) GetTimelineEntryByID(w http.ResponseWriter, r *http.Request) {
 	id := extractPathParam(r, "id")
 
-	entry, err := h.server.GetTimelineEntryByID(r.Context(), id)
+	expandImages, err := parseTimelineExpand(r.URL.Query())
+	if err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	entry, err := h.server.GetTimelineEntryByID(r.Context(), id, expandImages)
 	if err != nil {
 		httputil.ErrorFromDomain(w, err)
 		return
@@ -84,6 +160,27 @@ func (h *BaseHandler) UpdateTimelineEntry(w http.ResponseWriter, r *http.Request
 	httputil.JSON(w, response, http.StatusOK)
 }
 
+// SetTimelineEntryDraft handles PUT /api/v1/timelineentries/{id}/draft
+// Publishes a draft entry, e.g. one generated from a past event, or turns an entry back into a draft
+func (h *BaseHandler) SetTimelineEntryDraft(w http.ResponseWriter, r *http.Request) {
+	id := extractPathParam(r, "id")
+
+	var req mapper.SetTimelineEntryDraftRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	updated, err := h.server.SetTimelineEntryDraft(r.Context(), id, req.Draft)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.TimelineEntryToResponse(updated)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
 // DeleteTimelineEntry handles DELETE /api/v1/timelineentries/{id}
 func (h *BaseHandler) DeleteTimelineEntry(w http.ResponseWriter, r *http.Request) {
 	id := extractPathParam(r, "id")
@@ -0,0 +1,45 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"backend/internal/http/mapper"
+	"backend/internal/platform/httputil"
+)
+
+// GetUser handles GET /api/v1/users/{uid}
+// Returns the account of a user and its role
+func (h *BaseHandler) GetUser(w http.ResponseWriter, r *http.Request) {
+	uid := extractPathParam(r, "uid")
+
+	user, err := h.server.GetUser(r.Context(), uid)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.UserToResponse(user)
+	httputil.JSON(w, response, http.StatusOK)
+}
+
+// SetUserRole handles PUT /api/v1/users/{uid}/role
+// The role applies once the user refreshes their token
+func (h *BaseHandler) SetUserRole(w http.ResponseWriter, r *http.Request) {
+	uid := extractPathParam(r, "uid")
+
+	var req mapper.SetUserRoleRequest
+	if err := json.NewDecoder(r.Body).Decode(&req); err != nil {
+		httputil.Error(w, err, http.StatusBadRequest)
+		return
+	}
+
+	user, err := h.server.SetUserRole(r.Context(), uid, req.Role)
+	if err != nil {
+		httputil.ErrorFromDomain(w, err)
+		return
+	}
+
+	response := mapper.UserToResponse(user)
+	httputil.JSON(w, response, http.StatusOK)
+}
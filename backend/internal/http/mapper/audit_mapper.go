@@ -0,0 +1,72 @@
+package mapper
+
+import (
+	"time"
+
+	"backend/internal/entities"
+)
+
+// Audit DTOs
+
+// AuditPageResponse represents a page of the audit log, newest first
+type AuditPageResponse struct {
+	Data []AuditRecordResponse `json:"data"`
+	Meta AuditPageMeta         `json:"meta"`
+}
+
+// AuditPageMeta describes a page of the audit log
+type AuditPageMeta struct {
+	Page    int  `json:"page"`
+	Limit   int  `json:"limit"`
+	HasMore bool `json:"has_more"` // Whether the next page holds more records
+}
+
+// AuditRecordResponse represents a content mutation recorded by the audit log
+type AuditRecordResponse struct {
+	ID         string                         `json:"id"`
+	Actor      string                         `json:"actor,omitempty"`
+	Action     string                         `json:"action"`
+	EntityType string                         `json:"entity_type"`
+	EntityID   string                         `json:"entity_id"`
+	Changes    map[string]AuditChangeResponse `json:"changes"` // Keyed by the stored field name
+	RequestID  string                         `json:"request_id,omitempty"`
+	OccurredAt time.Time                      `json:"occurred_at"`
+}
+
+// AuditChangeResponse represents the value of a field before and after a mutation
+type AuditChangeResponse struct {
+	Before any `json:"before"`
+	After  any `json:"after"`
+}
+
+// AuditRecordToResponse converts entity to response DTO
+func AuditRecordToResponse(record entities.AuditRecord) AuditRecordResponse {
+	changes := make(map[string]AuditChangeResponse, len(record.Changes))
+	for name, change := range record.Changes {
+		changes[name] = AuditChangeResponse{Before: change.Before, After: change.After}
+	}
+
+	return AuditRecordResponse{
+		ID:         record.ID,
+		Actor:      record.Actor,
+		Action:     string(record.Action),
+		EntityType: string(record.EntityType),
+		EntityID:   record.EntityID,
+		Changes:    changes,
+		RequestID:  record.RequestID,
+		OccurredAt: record.OccurredAt,
+	}
+}
+
+// AuditPageToResponse converts a page of the audit log to its response DTO
+func AuditPageToResponse(page entities.AuditPage) AuditPageResponse {
+	records := make([]AuditRecordResponse, len(page.Records))
+	for i, record := range page.Records {
+		records[i] = AuditRecordToResponse(record)
+	}
+
+	return AuditPageResponse{
+		Data: records,
+		Meta: AuditPageMeta{Page: page.Page, Limit: page.Limit, HasMore: page.HasMore},
+	}
+}
@@ -10,6 +10,7 @@ import (
 
 type EventResponse struct {
 	ID                string    `json:"id"`
+	Source            string    `json:"source"`
 	Identifier        string    `json:"identifier"`
 	Name              string    `json:"name"`
 	Description       string    `json:"description,omitempty"`
@@ -26,6 +27,78 @@ type EventResponse struct {
 	State             string    `json:"state,omitempty"`
 	CreatedAt         time.Time `json:"created_at,omitempty"`
 	Link              string    `json:"link,omitempty"`
+
+	Tracks []TrackResponse `json:"tracks,omitempty"` // Only sent by the single event endpoint
+}
+
+// EventListResponse represents a page of events
+type EventListResponse struct {
+	Data []EventResponse `json:"data"`
+	Meta EventListMeta   `json:"meta"`
+}
+
+// EventListMeta describes the events matching a query
+type EventListMeta struct {
+	Count         int      `json:"count"`                    // Number of matching events over all pages
+	FailedSources []string `json:"failed_sources,omitempty"` // Event sources left out of the list
+}
+
+// TrackResponse represents a track of an event
+type TrackResponse struct {
+	ID          string `json:"id"`
+	Name        string `json:"name"`
+	Description string `json:"description,omitempty"`
+	Color       string `json:"color,omitempty"`
+}
+
+// MicrolocationResponse represents the room of a session
+type MicrolocationResponse struct {
+	ID    string `json:"id"`
+	Name  string `json:"name"`
+	Floor int    `json:"floor"`
+}
+
+// SessionResponse represents a session of an event
+type SessionResponse struct {
+	ID            string                   `json:"id"`
+	Title         string                   `json:"title"`
+	Subtitle      string                   `json:"subtitle,omitempty"`
+	ShortAbstract string                   `json:"short_abstract,omitempty"`
+	LongAbstract  string                   `json:"long_abstract,omitempty"`
+	StartsAt      time.Time                `json:"starts_at,omitzero"` // Absent while the session is not scheduled
+	EndsAt        time.Time                `json:"ends_at,omitzero"`
+	Language      string                   `json:"language,omitempty"`
+	Level         string                   `json:"level,omitempty"`
+	State         string                   `json:"state,omitempty"`
+	SlidesURL     string                   `json:"slides_url,omitempty"`
+	VideoURL      string                   `json:"video_url,omitempty"`
+	Track         *TrackResponse           `json:"track,omitempty"`
+	Microlocation *MicrolocationResponse   `json:"microlocation,omitempty"`
+	Speakers      []SessionSpeakerResponse `json:"speakers"`
+}
+
+// SessionSpeakerResponse represents a speaker as listed in a session
+type SessionSpeakerResponse struct {
+	ID       string `json:"id"`
+	Name     string `json:"name"`
+	PhotoURL string `json:"photo_url,omitempty"`
+}
+
+// SpeakerResponse represents a speaker of an event
+type SpeakerResponse struct {
+	ID             string   `json:"id"`
+	Name           string   `json:"name"`
+	PhotoURL       string   `json:"photo_url,omitempty"`
+	ShortBiography string   `json:"short_biography,omitempty"`
+	LongBiography  string   `json:"long_biography,omitempty"`
+	Organisation   string   `json:"organisation,omitempty"`
+	Position       string   `json:"position,omitempty"`
+	Country        string   `json:"country,omitempty"`
+	Website        string   `json:"website,omitempty"`
+	Twitter        string   `json:"twitter,omitempty"`
+	GitHub         string   `json:"github,omitempty"`
+	LinkedIn       string   `json:"linkedin,omitempty"`
+	SessionIDs     []string `json:"session_ids"`
 }
 
 // Mapping functions
@@ -33,6 +106,7 @@ type EventResponse struct {
 func EventToResponse(event entities.Event) EventResponse {
 	return EventResponse{
 		ID:                event.ID,
+		Source:            event.Source,
 		Identifier:        event.Identifier,
 		Name:              event.Name,
 		Description:       event.Description,
@@ -49,6 +123,7 @@ func EventToResponse(event entities.Event) EventResponse {
 		State:             event.State,
 		CreatedAt:         event.CreatedAt,
 		Link:              event.Link,
+		Tracks:            TracksToResponse(event.Tracks),
 	}
 }
 
@@ -59,3 +134,111 @@ func EventsToResponse(events []entities.Event) []EventResponse {
 	}
 	return result
 }
+
+// EventListToResponse converts a page of events to a response DTO
+func EventListToResponse(page entities.EventPage) EventListResponse {
+	return EventListResponse{
+		Data: EventsToResponse(page.Events),
+		Meta: EventListMeta{Count: page.Count, FailedSources: page.FailedSources},
+	}
+}
+
+// TracksToResponse converts tracks to response DTOs, nil stays nil
+func TracksToResponse(tracks []entities.Track) []TrackResponse {
+	if tracks == nil {
+		return nil
+	}
+
+	result := make([]TrackResponse, len(tracks))
+	for i, track := range tracks {
+		result[i] = TrackToResponse(track)
+	}
+	return result
+}
+
+func TrackToResponse(track entities.Track) TrackResponse {
+	return TrackResponse{
+		ID:          track.ID,
+		Name:        track.Name,
+		Description: track.Description,
+		Color:       track.Color,
+	}
+}
+
+func SessionToResponse(session entities.Session) SessionResponse {
+	resp := SessionResponse{
+		ID:            session.ID,
+		Title:         session.Title,
+		Subtitle:      session.Subtitle,
+		ShortAbstract: session.ShortAbstract,
+		LongAbstract:  session.LongAbstract,
+		StartsAt:      session.StartsAt,
+		EndsAt:        session.EndsAt,
+		Language:      session.Language,
+		Level:         session.Level,
+		State:         session.State,
+		SlidesURL:     session.SlidesURL,
+		VideoURL:      session.VideoURL,
+		Speakers:      make([]SessionSpeakerResponse, len(session.Speakers)),
+	}
+
+	if session.Track != nil {
+		track := TrackToResponse(*session.Track)
+		resp.Track = &track
+	}
+	if session.Microlocation != nil {
+		resp.Microlocation = &MicrolocationResponse{
+			ID:    session.Microlocation.ID,
+			Name:  session.Microlocation.Name,
+			Floor: session.Microlocation.Floor,
+		}
+	}
+	for i, speaker := range session.Speakers {
+		resp.Speakers[i] = SessionSpeakerResponse{
+			ID:       speaker.ID,
+			Name:     speaker.Name,
+			PhotoURL: speaker.PhotoURL,
+		}
+	}
+
+	return resp
+}
+
+func SessionsToResponse(sessions []entities.Session) []SessionResponse {
+	result := make([]SessionResponse, len(sessions))
+	for i, session := range sessions {
+		result[i] = SessionToResponse(session)
+	}
+	return result
+}
+
+func SpeakerToResponse(speaker entities.Speaker) SpeakerResponse {
+	sessionIDs := speaker.SessionIDs
+	if sessionIDs == nil {
+		sessionIDs = []string{}
+	}
+
+	return SpeakerResponse{
+		ID:             speaker.ID,
+		Name:           speaker.Name,
+		PhotoURL:       speaker.PhotoURL,
+		ShortBiography: speaker.ShortBiography,
+		LongBiography:  speaker.LongBiography,
+		Organisation:   speaker.Organisation,
+		Position:       speaker.Position,
+		Country:        speaker.Country,
+		Website:        speaker.Website,
+		Twitter:        speaker.Twitter,
+		GitHub:         speaker.GitHub,
+		LinkedIn:       speaker.LinkedIn,
+		SessionIDs:     sessionIDs,
+	}
+}
+
+func SpeakersToResponse(speakers []entities.Speaker) []SpeakerResponse {
+	result := make([]SpeakerResponse, len(speakers))
+	for i, speaker := range speakers {
+		result[i] = SpeakerToResponse(speaker)
+	}
+	return result
+}
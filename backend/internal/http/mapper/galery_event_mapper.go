@@ -1,6 +1,8 @@
 package mapper
 
 import (
+	"encoding/base64"
+	"fmt"
 	"time"
 
 	"backend/internal/entities"
@@ -14,6 +16,9 @@ type CreateGaleryEventRequest struct {
 	Location     string    `json:"location" binding:"required"`
 	Date         time.Time `json:"date" binding:"required"`
 	ImagesBase64 []string  `json:"images_base64" binding:"required,min=1"`
+	Private      bool      `json:"private,omitempty"`
+	OnDuplicate  string    `json:"on_duplicate,omitempty"` // "allow" (default), "reuse" or "reject"
+	GrupyEventID string    `json:"grupy_event_id,omitempty"`
 }
 
 // GaleryEventResponse represents a galery event response
@@ -24,41 +29,158 @@ type GaleryEventResponse struct {
 	Date      time.Time `json:"date"`
 	ImageURLs []string  `json:"image_urls,omitzero"`
 	ImageIDs  []string  `json:"image_ids,omitzero"`
+	Private   bool      `json:"private"`
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
+
+	CreatedBy     string `json:"created_by,omitempty"`
+	LastUpdatedBy string `json:"last_updated_by,omitempty"`
+
+	Items         []GaleryItemResponse `json:"items"`
+	CoverImageID  string               `json:"cover_image_id,omitempty"`
+	CoverImageURL string               `json:"cover_image_url,omitempty"`
+
+	GrupyEventID string                    `json:"grupy_event_id,omitempty"`
+	GrupyEvent   *GaleryGrupyEventResponse `json:"grupy_event,omitempty"` // Absent when the events API couldn't be reached
+}
+
+// GaleryGrupyEventResponse represents the Grupy event a galery event is linked to
+type GaleryGrupyEventResponse struct {
+	ID          string    `json:"id"`
+	Name        string    `json:"name"`
+	Description string    `json:"description,omitempty"`
+	StartsAt    time.Time `json:"starts_at"`
+	Link        string    `json:"link"`
+	LogoURL     string    `json:"logo_url,omitempty"`
+}
+
+// GaleryItemResponse represents a photo of a galery event
+type GaleryItemResponse struct {
+	ImageID       string `json:"image_id"`
+	ImageURL      string `json:"image_url"`
+	Caption       string `json:"caption,omitempty"`
+	Credit        string `json:"credit,omitempty"`
+	AltText       string `json:"alt_text,omitempty"`
+	BlurHash      string `json:"blur_hash,omitempty"`
+	LQIP          string `json:"lqip,omitempty"`
+	DominantColor string `json:"dominant_color,omitempty"`
+}
+
+// GaleryItemRequest represents a photo of a galery event, its URL is taken from the image
+type GaleryItemRequest struct {
+	ImageID string `json:"image_id"`
+	Caption string `json:"caption,omitempty"`
+	Credit  string `json:"credit,omitempty"`
+	AltText string `json:"alt_text,omitempty"`
 }
 
 // ModifyGaleryEventRequest represents the request to create a galery event
+// Items replace image_urls and image_ids, which are still accepted from older clients when items is absent:
+// the photos they keep retain their caption, credit and alt text. Without either the photos are left unchanged
+// The cover is kept when cover_image_id is left out and the photo is still in the event
 type ModifyGaleryEventRequest struct {
-	ID        string    `json:"id" binding:"required"`
-	Name      string    `json:"name" binding:"required"`
-	Location  string    `json:"location" binding:"required"`
-	Date      time.Time `json:"date" binding:"required"`
-	ImageURLs []string  `json:"image_urls" binding:"required"`
-	ImageIDs  []string  `json:"image_ids"  binding:"required"`
+	ID           string              `json:"id" binding:"required"`
+	Name         string              `json:"name" binding:"required"`
+	Location     string              `json:"location" binding:"required"`
+	Date         time.Time           `json:"date" binding:"required"`
+	Items        []GaleryItemRequest `json:"items,omitempty"`
+	CoverImageID string              `json:"cover_image_id,omitempty"`
+	GrupyEventID *string             `json:"grupy_event_id,omitempty"` // Kept when left out, "" unlinks
+	ImageURLs    []string            `json:"image_urls,omitempty"`
+	ImageIDs     []string            `json:"image_ids,omitempty"`
+}
+
+// AddGaleryEventImageRequest represents the request to upload an image into a galery event
+// Metadata left empty is derived from the galery event
+type AddGaleryEventImageRequest struct {
+	Data        string `json:"data"` // Base64 encoded image
+	Name        string `json:"name,omitempty"`
+	Text        string `json:"text,omitempty"`
+	Caption     string `json:"caption,omitempty"`
+	Credit      string `json:"credit,omitempty"`
+	AltText     string `json:"alt_text,omitempty"`
+	OnDuplicate string `json:"on_duplicate,omitempty"` // "allow" (default), "reuse" or "reject"
+}
+
+// ReorderGaleryEventImagesRequest represents the new order of the images of a galery event
+type ReorderGaleryEventImagesRequest struct {
+	ImageIDs []string `json:"image_ids"`
+}
+
+// SetGaleryEventVisibilityRequest represents the visibility of a galery event and its images
+type SetGaleryEventVisibilityRequest struct {
+	Private bool `json:"private"`
 }
 
 // Mapping functions
 
+// ToGaleryEventEntity converts a create request to a GaleryEvent entity (images are uploaded separately)
+func ToGaleryEventEntity(req CreateGaleryEventRequest) entities.GaleryEvent {
+	return entities.GaleryEvent{
+		Name:         req.Name,
+		Location:     req.Location,
+		Date:         req.Date,
+		Private:      req.Private,
+		GrupyEventID: req.GrupyEventID,
+	}
+}
+
 // GaleryEventToResponse converts a GaleryEvent entity to a response DTO
+// Private events carrying signed URLs are served through them instead of the stored image URLs
 func GaleryEventToResponse(event entities.GaleryEvent) GaleryEventResponse {
 	resp := GaleryEventResponse{
 		ID:        event.ID,
 		Name:      event.Name,
 		Location:  event.Location,
 		Date:      event.Date,
-		ImageURLs: []string(event.ImageURLs),
-		ImageIDs:  []string(event.ImageIDs),
+		ImageURLs: make([]string, len(event.Items)),
+		ImageIDs:  make([]string, len(event.Items)),
+		Private:   event.Private,
 		CreatedAt: event.CreatedAt,
 		UpdatedAt: event.UpdatedAt,
+
+		CreatedBy:     event.CreatedBy,
+		LastUpdatedBy: event.LastUpdatedBy,
+
+		Items: make([]GaleryItemResponse, len(event.Items)),
+
+		GrupyEventID: event.GrupyEventID,
+	}
+
+	if event.GrupyEvent != nil {
+		resp.GrupyEvent = &GaleryGrupyEventResponse{
+			ID:          event.GrupyEvent.ID,
+			Name:        event.GrupyEvent.Name,
+			Description: event.GrupyEvent.Description,
+			StartsAt:    event.GrupyEvent.StartsAt,
+			Link:        event.GrupyEvent.Link,
+			LogoURL:     event.GrupyEvent.LogoURL,
+		}
 	}
 
-	if resp.ImageIDs == nil {
-		resp.ImageIDs = make([]string, 0)
+	for i, item := range event.Items {
+		imageURL := item.ImageURL
+		if i < len(event.SignedImageURLs) {
+			imageURL = event.SignedImageURLs[i]
+		}
+
+		resp.ImageURLs[i] = imageURL
+		resp.ImageIDs[i] = item.ImageID
+		resp.Items[i] = GaleryItemResponse{
+			ImageID:       item.ImageID,
+			ImageURL:      imageURL,
+			Caption:       item.Caption,
+			Credit:        item.Credit,
+			AltText:       item.AltText,
+			BlurHash:      item.Placeholder.BlurHash,
+			LQIP:          item.Placeholder.LQIP,
+			DominantColor: item.Placeholder.DominantColor,
+		}
 	}
 
-	if resp.ImageURLs == nil {
-		resp.ImageURLs = make([]string, 0)
+	if cover := event.Cover(); cover >= 0 {
+		resp.CoverImageID = resp.Items[cover].ImageID
+		resp.CoverImageURL = resp.Items[cover].ImageURL
 	}
 
 	return resp
@@ -73,13 +195,56 @@ func GaleryEventsToResponse(events []entities.GaleryEvent) []GaleryEventResponse
 	return result
 }
 
+// ToGaleryEventImageEntity converts an add image request to a galery item, Image metadata and decoded bytes
+func ToGaleryEventImageEntity(req AddGaleryEventImageRequest) (entities.GaleryItem, entities.Image, []byte, error) {
+	data, err := base64.StdEncoding.DecodeString(req.Data)
+	if err != nil {
+		return entities.GaleryItem{}, entities.Image{}, nil, fmt.Errorf("invalid base64 data: %w", err)
+	}
+
+	item := entities.GaleryItem{
+		Caption: req.Caption,
+		Credit:  req.Credit,
+		AltText: req.AltText,
+	}
+
+	return item, entities.Image{
+		Name: req.Name,
+		Text: req.Text,
+	}, data, nil
+}
+
+// ModifyGaleryRequestToEntity converts a modify request to a GaleryEvent entity
+// Items are left nil when absent, the image IDs and URLs of older clients are then passed on to be merged with the stored items
 func ModifyGaleryRequestToEntity(req ModifyGaleryEventRequest) entities.GaleryEvent {
-	return entities.GaleryEvent{
-		ID:        req.ID,
-		Name:      req.Name,
-		Location:  req.Location,
-		Date:      req.Date,
-		ImageURLs: req.ImageURLs,
-		ImageIDs:  req.ImageIDs,
+	event := entities.GaleryEvent{
+		ID:           req.ID,
+		Name:         req.Name,
+		Location:     req.Location,
+		Date:         req.Date,
+		CoverImageID: req.CoverImageID,
+	}
+
+	if req.GrupyEventID != nil {
+		event.GrupyEventID = *req.GrupyEventID
+		event.LinksGrupyEvent = true
+	}
+
+	if req.Items == nil {
+		// Older clients send the parallel arrays instead of items
+		event.ImageIDs = req.ImageIDs
+		event.ImageURLs = req.ImageURLs
+		return event
+	}
+
+	event.Items = make([]entities.GaleryItem, 0, len(req.Items))
+	for _, item := range req.Items {
+		event.Items = append(event.Items, entities.GaleryItem{
+			ImageID: item.ImageID,
+			Caption: item.Caption,
+			Credit:  item.Credit,
+			AltText: item.AltText,
+		})
 	}
+	return event
 }
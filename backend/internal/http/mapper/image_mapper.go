@@ -11,21 +11,42 @@ import (
 // Image DTOs
 
 type CreateImageRequest struct {
-	Slug     string `json:"slug,omitempty"`
-	Name     string `json:"name"`
-	Text     string `json:"text,omitempty"`
-	Date     string `json:"date,omitempty"` // ISO format
-	Location string `json:"location,omitempty"`
-	Data     string `json:"data"` // base64 encoded
+	Slug     string   `json:"slug,omitempty"`
+	Name     string   `json:"name"`
+	Text     string   `json:"text,omitempty"`
+	Date     string   `json:"date,omitempty"` // ISO format
+	Location string   `json:"location,omitempty"`
+	Private  bool     `json:"private,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+	Data     string   `json:"data"` // base64 encoded
+	// OnDuplicate is "allow" (default), "reuse" or "reject"
+	OnDuplicate string `json:"on_duplicate,omitempty"`
 }
 
 type UpdateImageRequest struct {
-	Slug     string `json:"slug,omitempty"`
-	Name     string `json:"name,omitempty"`
-	Text     string `json:"text,omitempty"`
-	Date     string `json:"date,omitempty"` // ISO format
-	Location string `json:"location,omitempty"`
-	Data     string `json:"data,omitempty"` // base64 encoded (optional)
+	Slug     string   `json:"slug,omitempty"`
+	Name     string   `json:"name,omitempty"`
+	Text     string   `json:"text,omitempty"`
+	Date     string   `json:"date,omitempty"` // ISO format
+	Location string   `json:"location,omitempty"`
+	Tags     []string `json:"tags,omitempty"` // Replaces the tags when present, [] removes them all
+	Data     string   `json:"data,omitempty"` // base64 encoded (optional)
+}
+
+// BulkTagRequest represents the request to tag or untag several images at once
+type BulkTagRequest struct {
+	ImageIDs []string `json:"image_ids"`
+	Tags     []string `json:"tags"`
+}
+
+// TagCountResponse represents a tag and the number of images carrying it
+type TagCountResponse struct {
+	Tag   string `json:"tag"`
+	Count int    `json:"count"`
+}
+
+type SetImageVisibilityRequest struct {
+	Private bool `json:"private"`
 }
 
 type ImageResponse struct {
@@ -36,11 +57,24 @@ type ImageResponse struct {
 	Text          string    `json:"text,omitempty"`
 	Date          time.Time `json:"date,omitempty"`
 	Location      string    `json:"location,omitempty"`
+	Private       bool      `json:"private"`
+	SHA256        string    `json:"sha256,omitempty"`
+	BlurHash      string    `json:"blur_hash,omitempty"`
+	LQIP          string    `json:"lqip,omitempty"`
+	DominantColor string    `json:"dominant_color,omitempty"`
+	Tags          []string  `json:"tags,omitempty"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
+	CreatedBy     string    `json:"created_by,omitempty"`
 	LastUpdatedBy string    `json:"last_updated_by,omitempty"`
 }
 
+type DuplicateClusterResponse struct {
+	SHA256 string          `json:"sha256"`
+	Count  int             `json:"count"`
+	Images []ImageResponse `json:"images"`
+}
+
 // Mapping functions
 
 func ToImageEntity(req CreateImageRequest) (entities.Image, []byte, error) {
@@ -66,6 +100,8 @@ func ToImageEntity(req CreateImageRequest) (entities.Image, []byte, error) {
 		Text:     req.Text,
 		Date:     date,
 		Location: req.Location,
+		Private:  req.Private,
+		Tags:     req.Tags,
 	}
 
 	return img, data, nil
@@ -98,22 +134,37 @@ func ToImageUpdateEntity(req UpdateImageRequest) (entities.Image, []byte, error)
 		Text:     req.Text,
 		Date:     date,
 		Location: req.Location,
+		Tags:     req.Tags,
 	}
 
 	return img, data, nil
 }
 
+// ImageToResponse converts an Image entity to a response DTO
+// Private images carrying a signed URL are served through it instead of the stored object URL
 func ImageToResponse(img entities.Image) ImageResponse {
+	objectURL := img.ObjectURL
+	if img.SignedURL != "" {
+		objectURL = img.SignedURL
+	}
+
 	return ImageResponse{
 		ID:            img.ID,
 		Slug:          img.Slug,
-		ObjectURL:     img.ObjectURL,
+		ObjectURL:     objectURL,
 		Name:          img.Name,
 		Text:          img.Text,
 		Date:          img.Date,
 		Location:      img.Location,
+		Private:       img.Private,
+		SHA256:        img.SHA256,
+		BlurHash:      img.BlurHash,
+		LQIP:          img.LQIP,
+		DominantColor: img.DominantColor,
+		Tags:          img.Tags,
 		CreatedAt:     img.CreatedAt,
 		UpdatedAt:     img.UpdatedAt,
+		CreatedBy:     img.CreatedBy,
 		LastUpdatedBy: img.LastUpdatedBy,
 	}
 }
@@ -125,3 +176,23 @@ func ImagesToResponse(images []entities.Image) []ImageResponse {
 	}
 	return result
 }
+
+func DuplicateClustersToResponse(clusters []entities.DuplicateCluster) []DuplicateClusterResponse {
+	result := make([]DuplicateClusterResponse, len(clusters))
+	for i, cluster := range clusters {
+		result[i] = DuplicateClusterResponse{
+			SHA256: cluster.SHA256,
+			Count:  len(cluster.Images),
+			Images: ImagesToResponse(cluster.Images),
+		}
+	}
+	return result
+}
+
+func TagCountsToResponse(tags []entities.TagCount) []TagCountResponse {
+	result := make([]TagCountResponse, len(tags))
+	for i, tag := range tags {
+		result[i] = TagCountResponse{Tag: tag.Tag, Count: tag.Count}
+	}
+	return result
+}
@@ -0,0 +1,72 @@
+package mapper
+
+import (
+	"fmt"
+	"time"
+
+	"backend/internal/entities"
+)
+
+// Image upload DTOs
+
+type CreateImageUploadRequest struct {
+	Slug        string `json:"slug,omitempty"`
+	ContentType string `json:"content_type"`
+	Private     bool   `json:"private,omitempty"`
+}
+
+type ImageUploadResponse struct {
+	Key       string            `json:"key"`
+	UploadURL string            `json:"upload_url"`
+	Method    string            `json:"method"`
+	Headers   map[string]string `json:"headers,omitempty"` // Must be sent along with the upload request
+	ExpiresAt time.Time         `json:"expires_at"`
+}
+
+type FinalizeImageUploadRequest struct {
+	Key      string   `json:"key"`
+	Slug     string   `json:"slug,omitempty"`
+	Name     string   `json:"name"`
+	Text     string   `json:"text,omitempty"`
+	Date     string   `json:"date,omitempty"` // ISO format
+	Location string   `json:"location,omitempty"`
+	Private  bool     `json:"private,omitempty"`
+	Tags     []string `json:"tags,omitempty"`
+
+	// OnDuplicate is "allow" (default), "reuse" or "reject"
+	OnDuplicate string `json:"on_duplicate,omitempty"`
+}
+
+// Mapping functions
+
+func ImageUploadToResponse(upload entities.SignedUpload) ImageUploadResponse {
+	return ImageUploadResponse{
+		Key:       upload.Key,
+		UploadURL: upload.URL,
+		Method:    upload.Method,
+		Headers:   upload.Headers,
+		ExpiresAt: upload.ExpiresAt,
+	}
+}
+
+func ToFinalizedImageEntity(req FinalizeImageUploadRequest) (entities.Image, error) {
+	// Parse date if provided
+	var date time.Time
+	if req.Date != "" {
+		parsedDate, err := time.Parse("2006-01-02", req.Date)
+		if err != nil {
+			return entities.Image{}, fmt.Errorf("invalid date format: %w", err)
+		}
+		date = parsedDate
+	}
+
+	return entities.Image{
+		Slug:     req.Slug,
+		Name:     req.Name,
+		Text:     req.Text,
+		Date:     date,
+		Location: req.Location,
+		Private:  req.Private,
+		Tags:     req.Tags,
+	}, nil
+}
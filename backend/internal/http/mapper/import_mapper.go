@@ -0,0 +1,43 @@
+package mapper
+
+import "backend/internal/entities"
+
+// Import DTOs
+
+// ImportReportResponse summarizes a bulk import
+type ImportReportResponse struct {
+	Kind    string                `json:"kind"`
+	DryRun  bool                  `json:"dry_run"`
+	Records int                   `json:"records"`
+	Created int                   `json:"created"`
+	Updated int                   `json:"updated"`
+	Errors  []ImportErrorResponse `json:"errors"`
+}
+
+// ImportErrorResponse represents a problem found in a record of an import file
+type ImportErrorResponse struct {
+	Line    int    `json:"line"`
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+// ImportReportToResponse converts entity to response DTO
+func ImportReportToResponse(report entities.ImportReport) ImportReportResponse {
+	errors := make([]ImportErrorResponse, len(report.Errors))
+	for i, err := range report.Errors {
+		errors[i] = ImportErrorResponse{
+			Line:    err.Line,
+			Field:   err.Field,
+			Message: err.Message,
+		}
+	}
+
+	return ImportReportResponse{
+		Kind:    string(report.Kind),
+		DryRun:  report.DryRun,
+		Records: report.Records,
+		Created: report.Created,
+		Updated: report.Updated,
+		Errors:  errors,
+	}
+}
@@ -0,0 +1,50 @@
+package mapper
+
+import (
+	"time"
+
+	"backend/internal/entities"
+)
+
+// Job DTOs
+
+// JobResponse represents a background job response
+type JobResponse struct {
+	ID         string            `json:"id"`
+	Type       string            `json:"type"`
+	State      string            `json:"state"`
+	Progress   JobProgress       `json:"progress"`
+	Error      string            `json:"error,omitempty"`
+	Result     map[string]string `json:"result,omitempty"` // e.g. {"galery_event_id": "..."}
+	CreatedBy  string            `json:"created_by,omitempty"`
+	CreatedAt  time.Time         `json:"created_at"`
+	UpdatedAt  time.Time         `json:"updated_at"`
+	StartedAt  time.Time         `json:"started_at,omitzero"`
+	FinishedAt time.Time         `json:"finished_at,omitzero"`
+}
+
+// JobProgress represents how many steps of a job are done
+type JobProgress struct {
+	Done  int `json:"done"`
+	Total int `json:"total"`
+}
+
+// JobToResponse converts entity to response DTO
+func JobToResponse(job entities.Job) JobResponse {
+	return JobResponse{
+		ID:    job.ID,
+		Type:  string(job.Type),
+		State: string(job.State),
+		Progress: JobProgress{
+			Done:  job.Progress.Done,
+			Total: job.Progress.Total,
+		},
+		Error:      job.Error,
+		Result:     job.Result,
+		CreatedBy:  job.CreatedBy,
+		CreatedAt:  job.CreatedAt,
+		UpdatedAt:  job.UpdatedAt,
+		StartedAt:  job.StartedAt,
+		FinishedAt: job.FinishedAt,
+	}
+}
@@ -0,0 +1,46 @@
+package mapper
+
+import (
+	"time"
+
+	"backend/internal/entities"
+)
+
+// Notification DTOs
+
+// DeadLetterResponse represents a notification a webhook didn't accept
+type DeadLetterResponse struct {
+	ID             string    `json:"id"`
+	Webhook        string    `json:"webhook"`
+	NotificationID string    `json:"notification_id"`
+	Kind           string    `json:"kind"`
+	EventID        string    `json:"event_id"`
+	EventName      string    `json:"event_name"`
+	Attempts       int       `json:"attempts"`
+	Error          string    `json:"error"`
+	CreatedAt      time.Time `json:"created_at"`
+}
+
+// DeadLetterToResponse converts entity to response DTO
+func DeadLetterToResponse(letter entities.DeadLetter) DeadLetterResponse {
+	return DeadLetterResponse{
+		ID:             letter.ID,
+		Webhook:        letter.Webhook,
+		NotificationID: letter.NotificationID,
+		Kind:           string(letter.Kind),
+		EventID:        letter.EventID,
+		EventName:      letter.EventName,
+		Attempts:       letter.Attempts,
+		Error:          letter.Error,
+		CreatedAt:      letter.CreatedAt,
+	}
+}
+
+// DeadLettersToResponse converts a list of entities to response DTOs
+func DeadLettersToResponse(letters []entities.DeadLetter) []DeadLetterResponse {
+	responses := make([]DeadLetterResponse, len(letters))
+	for i, letter := range letters {
+		responses[i] = DeadLetterToResponse(letter)
+	}
+	return responses
+}
@@ -29,6 +29,7 @@ type TextResponse struct {
 	PageSlug      string    `json:"page_slug,omitempty"`
 	CreatedAt     time.Time `json:"created_at"`
 	UpdatedAt     time.Time `json:"updated_at"`
+	CreatedBy     string    `json:"created_by,omitempty"`
 	LastUpdatedBy string    `json:"last_updated_by,omitempty"`
 }
 
@@ -60,6 +61,7 @@ func TextToResponse(text entities.Text) TextResponse {
 		PageSlug:      text.PageSlug,
 		CreatedAt:     text.CreatedAt,
 		UpdatedAt:     text.UpdatedAt,
+		CreatedBy:     text.CreatedBy,
 		LastUpdatedBy: text.LastUpdatedBy,
 	}
 }
@@ -10,28 +10,54 @@ import (
 // TimelineEntry DTOs
 
 type CreateTimelineEntryRequest struct {
-	Name     string `json:"name"`
-	Text     string `json:"text"`
-	Location string `json:"location,omitempty"`
-	Date     string `json:"date"` // ISO format
+	Name       string         `json:"name"`
+	Text       string         `json:"text"`
+	Location   string         `json:"location,omitempty"`
+	Date       string         `json:"date"`                 // ISO format
+	Category   string         `json:"category,omitempty"`   // meetup, conference, milestone or award
+	Importance int            `json:"importance,omitempty"` // 1 (low) to 3 (high), defaults to 2
+	ImageIDs   []string       `json:"image_ids,omitempty"`  // IDs of existing images, in display order
+	Links      []TimelineLink `json:"links,omitempty"`
 }
 
 type UpdateTimelineEntryRequest struct {
-	Name     string `json:"name,omitempty"`
-	Text     string `json:"text,omitempty"`
-	Location string `json:"location,omitempty"`
-	Date     string `json:"date,omitempty"` // ISO format
+	Name       string         `json:"name,omitempty"`
+	Text       string         `json:"text,omitempty"`
+	Location   string         `json:"location,omitempty"`
+	Date       string         `json:"date,omitempty"` // ISO format
+	Category   string         `json:"category,omitempty"`
+	Importance int            `json:"importance,omitempty"`
+	ImageIDs   []string       `json:"image_ids,omitempty"` // Replaces the images when present, [] removes them all
+	Links      []TimelineLink `json:"links,omitempty"`     // Replaces the links when present, [] removes them all
+}
+
+// TimelineLink represents an external page about a timeline entry
+type TimelineLink struct {
+	Title string `json:"title"`
+	URL   string `json:"url"`
+}
+
+type SetTimelineEntryDraftRequest struct {
+	Draft bool `json:"draft"`
 }
 
 type TimelineEntryResponse struct {
-	ID            string    `json:"id"`
-	Name          string    `json:"name"`
-	Text          string    `json:"text"`
-	Location      string    `json:"location,omitempty"`
-	Date          time.Time `json:"date"`
-	CreatedAt     time.Time `json:"created_at"`
-	UpdatedAt     time.Time `json:"updated_at"`
-	LastUpdatedBy string    `json:"last_updated_by,omitempty"`
+	ID            string          `json:"id"`
+	Name          string          `json:"name"`
+	Text          string          `json:"text"`
+	Location      string          `json:"location,omitempty"`
+	Date          time.Time       `json:"date"`
+	Category      string          `json:"category,omitempty"`
+	Importance    int             `json:"importance"`
+	ImageIDs      []string        `json:"image_ids,omitempty"`
+	Links         []TimelineLink  `json:"links,omitempty"`
+	Images        []ImageResponse `json:"images,omitzero"` // Only with ?expand=images, [] when none can be shown
+	Draft         bool            `json:"draft"`
+	Source        string          `json:"source,omitempty"` // e.g. "grupy:123" for entries generated from events
+	CreatedAt     time.Time       `json:"created_at"`
+	UpdatedAt     time.Time       `json:"updated_at"`
+	CreatedBy     string          `json:"created_by,omitempty"`
+	LastUpdatedBy string          `json:"last_updated_by,omitempty"`
 }
 
 // Mapping functions
@@ -44,10 +70,14 @@ func ToTimelineEntryEntity(req CreateTimelineEntryRequest) (entities.TimelineEnt
 	}
 
 	return entities.TimelineEntry{
-		Name:     req.Name,
-		Text:     req.Text,
-		Location: req.Location,
-		Date:     date,
+		Name:       req.Name,
+		Text:       req.Text,
+		Location:   req.Location,
+		Date:       date,
+		Category:   entities.TimelineCategory(req.Category),
+		Importance: req.Importance,
+		ImageIDs:   req.ImageIDs,
+		Links:      toTimelineLinks(req.Links),
 	}, nil
 }
 
@@ -62,10 +92,14 @@ func ToTimelineEntryUpdateEntity(req UpdateTimelineEntryRequest) (entities.Timel
 	}
 
 	return entities.TimelineEntry{
-		Name:     req.Name,
-		Text:     req.Text,
-		Location: req.Location,
-		Date:     date,
+		Name:       req.Name,
+		Text:       req.Text,
+		Location:   req.Location,
+		Date:       date,
+		Category:   entities.TimelineCategory(req.Category),
+		Importance: req.Importance,
+		ImageIDs:   req.ImageIDs,
+		Links:      toTimelineLinks(req.Links),
 	}, nil
 }
 
@@ -76,12 +110,51 @@ func TimelineEntryToResponse(entry entities.TimelineEntry) TimelineEntryResponse
 		Text:          entry.Text,
 		Location:      entry.Location,
 		Date:          entry.Date,
+		Category:      string(entry.Category),
+		Importance:    entry.Importance,
+		ImageIDs:      entry.ImageIDs,
+		Links:         timelineLinksToResponse(entry.Links),
+		Images:        timelineImagesToResponse(entry.Images),
+		Draft:         entry.Draft,
+		Source:        entry.Source,
 		CreatedAt:     entry.CreatedAt,
 		UpdatedAt:     entry.UpdatedAt,
+		CreatedBy:     entry.CreatedBy,
 		LastUpdatedBy: entry.LastUpdatedBy,
 	}
 }
 
+// toTimelineLinks converts link DTOs to entities, nil stays nil so updates can leave the links alone
+func toTimelineLinks(links []TimelineLink) []entities.TimelineLink {
+	if links == nil {
+		return nil
+	}
+	result := make([]entities.TimelineLink, len(links))
+	for i, link := range links {
+		result[i] = entities.TimelineLink{Title: link.Title, URL: link.URL}
+	}
+	return result
+}
+
+func timelineLinksToResponse(links []entities.TimelineLink) []TimelineLink {
+	if len(links) == 0 {
+		return nil
+	}
+	result := make([]TimelineLink, len(links))
+	for i, link := range links {
+		result[i] = TimelineLink{Title: link.Title, URL: link.URL}
+	}
+	return result
+}
+
+// timelineImagesToResponse converts the expanded images, entries that weren't expanded have none
+func timelineImagesToResponse(images []entities.Image) []ImageResponse {
+	if images == nil {
+		return nil
+	}
+	return ImagesToResponse(images)
+}
+
 func TimelineEntriesToResponse(entries []entities.TimelineEntry) []TimelineEntryResponse {
 	result := make([]TimelineEntryResponse, len(entries))
 	for i, entry := range entries {
@@ -89,3 +162,34 @@ func TimelineEntriesToResponse(entries []entities.TimelineEntry) []TimelineEntry
 	}
 	return result
 }
+
+// TimelineGroupsResponse represents the timeline entries grouped by year or decade
+type TimelineGroupsResponse struct {
+	By     string                  `json:"by"`
+	Count  int                     `json:"count"` // Entries in every group
+	Groups []TimelineGroupResponse `json:"groups"`
+}
+
+type TimelineGroupResponse struct {
+	Key     string                  `json:"key"` // e.g. "2024" or "2020s"
+	Start   time.Time               `json:"start"`
+	Count   int                     `json:"count"`
+	Entries []TimelineEntryResponse `json:"entries"`
+}
+
+func TimelineGroupsToResponse(by string, groups []entities.TimelineGroup) TimelineGroupsResponse {
+	response := TimelineGroupsResponse{
+		By:     by,
+		Groups: make([]TimelineGroupResponse, len(groups)),
+	}
+	for i, group := range groups {
+		response.Groups[i] = TimelineGroupResponse{
+			Key:     group.Key,
+			Start:   group.Start,
+			Count:   group.Count,
+			Entries: TimelineEntriesToResponse(group.Entries),
+		}
+		response.Count += group.Count
+	}
+	return response
+}
@@ -0,0 +1,33 @@
+package mapper
+
+import "backend/internal/entities"
+
+// User DTOs
+
+// SetUserRoleRequest represents the request to assign a role to a user
+type SetUserRoleRequest struct {
+	Role string `json:"role"`
+}
+
+// UserResponse represents an account and its role
+type UserResponse struct {
+	UID         string `json:"uid"`
+	Email       string `json:"email,omitempty"`
+	DisplayName string `json:"display_name,omitempty"`
+	Role        string `json:"role"`
+}
+
+// UserToResponse converts entity to response DTO, accounts without a role are viewers
+func UserToResponse(user entities.User) UserResponse {
+	role := user.Role
+	if role == "" {
+		role = "viewer"
+	}
+
+	return UserResponse{
+		UID:         user.UID,
+		Email:       user.Email,
+		DisplayName: user.DisplayName,
+		Role:        role,
+	}
+}
@@ -2,6 +2,7 @@ package http
 
 import (
 	"context"
+	"fmt"
 	"log"
 	"net/http"
 
@@ -16,6 +17,57 @@ type RouterOptions struct {
 	Logger     *log.Logger
 }
 
+// routePermissions is the permission table of the protected routes, the role of the caller must grant it
+var routePermissions = map[string]auth.Permission{
+	// Texts
+	"POST /api/v1/texts":        auth.PermissionEditTexts,
+	"PUT /api/v1/texts/{id}":    auth.PermissionEditTexts,
+	"DELETE /api/v1/texts/{id}": auth.PermissionEditTexts,
+
+	// Images and tags
+	"GET /api/v1/images/duplicates":        auth.PermissionUploadGalery,
+	"POST /api/v1/images":                  auth.PermissionUploadGalery,
+	"PUT /api/v1/images/{id}":              auth.PermissionUploadGalery,
+	"DELETE /api/v1/images/{id}":           auth.PermissionDeleteGalery,
+	"PUT /api/v1/images/{id}/visibility":   auth.PermissionUploadGalery,
+	"POST /api/v1/images/tags":             auth.PermissionUploadGalery,
+	"POST /api/v1/images/tags/remove":      auth.PermissionUploadGalery,
+	"POST /api/v1/images/uploads":          auth.PermissionUploadGalery,
+	"POST /api/v1/images/uploads/finalize": auth.PermissionUploadGalery,
+
+	// Timeline
+	"POST /api/v1/timelineentries":           auth.PermissionEditTimeline,
+	"PUT /api/v1/timelineentries/{id}":       auth.PermissionEditTimeline,
+	"DELETE /api/v1/timelineentries/{id}":    auth.PermissionEditTimeline,
+	"PUT /api/v1/timelineentries/{id}/draft": auth.PermissionEditTimeline,
+
+	// Galery events and their background jobs
+	"POST /api/v1/galery_events":                         auth.PermissionUploadGalery,
+	"PUT /api/v1/galery_events":                          auth.PermissionUploadGalery,
+	"DELETE /api/v1/galery_events/{id}":                  auth.PermissionDeleteGalery,
+	"POST /api/v1/galery_events/{id}/images":             auth.PermissionUploadGalery,
+	"DELETE /api/v1/galery_events/{id}/images/{imageId}": auth.PermissionDeleteGalery,
+	"PUT /api/v1/galery_events/{id}/images/order":        auth.PermissionUploadGalery,
+	"PUT /api/v1/galery_events/{id}/visibility":          auth.PermissionUploadGalery,
+	"GET /api/v1/jobs/{id}":                              auth.PermissionUploadGalery,
+
+	// Administration
+	"GET /api/v1/notifications/dead_letters": auth.PermissionReadNotifications,
+	"POST /api/v1/import":                    auth.PermissionImport,
+	"GET /api/v1/users/{uid}":                auth.PermissionManageUsers,
+	"PUT /api/v1/users/{uid}/role":           auth.PermissionManageUsers,
+	"GET /api/v1/audit":                      auth.PermissionReadAudit,
+}
+
+// handleProtected registers a route requiring authentication and the permission routePermissions gives it
+func handleProtected(mux *http.ServeMux, pattern string, handler func(w http.ResponseWriter, r *http.Request), opts RouterOptions) {
+	permission, ok := routePermissions[pattern]
+	if !ok {
+		panic(fmt.Sprintf("route %s is missing from the permission table", pattern))
+	}
+	mux.HandleFunc(pattern, middleware.NewAuthMiddlewareFunc(handler, permission, opts.AuthConfig, opts.Logger))
+}
+
 // NewRouter creates and configures the HTTP router
 func NewRouter(ctx context.Context, srv server.Server, opts RouterOptions) http.Handler {
 	mux := http.NewServeMux()
@@ -27,6 +79,12 @@ func NewRouter(ctx context.Context, srv server.Server, opts RouterOptions) http.
 	eventsHandler := handlers.NewBaseHandler(srv)
 	galeryEventHandler := handlers.NewBaseHandler(srv)
 	authHandler := handlers.NewBaseHandler(srv)
+	jobsHandler := handlers.NewBaseHandler(srv)
+	feedsHandler := handlers.NewBaseHandler(srv)
+	notificationsHandler := handlers.NewBaseHandler(srv)
+	importHandler := handlers.NewBaseHandler(srv)
+	usersHandler := handlers.NewBaseHandler(srv)
+	auditHandler := handlers.NewBaseHandler(srv)
 
 	// Register routes using Go 1.22+ pattern matching
 
@@ -37,59 +95,100 @@ func NewRouter(ctx context.Context, srv server.Server, opts RouterOptions) http.
 	mux.HandleFunc("GET /api/v1/texts/page/{pageId}", textsHandler.GetTextsByPageID)
 	mux.HandleFunc("GET /api/v1/texts/page/slug/{pageSlug}", textsHandler.GetTextsByPageSlug)
 
-	// Add auth middleware to non-get functions
-	mux.HandleFunc("POST /api/v1/texts",
-		middleware.NewAuthMiddlewareFunc(textsHandler.CreateText, opts.AuthConfig, opts.Logger),
-	)
-	mux.HandleFunc("PUT /api/v1/texts/{id}",
-		middleware.NewAuthMiddlewareFunc(textsHandler.UpdateText, opts.AuthConfig, opts.Logger),
-	)
-	mux.HandleFunc("DELETE /api/v1/texts/{id}",
-		middleware.NewAuthMiddlewareFunc(textsHandler.DeleteText, opts.AuthConfig, opts.Logger),
-	)
+	// Writes require the permission of their route
+	handleProtected(mux, "POST /api/v1/texts", textsHandler.CreateText, opts)
+	handleProtected(mux, "PUT /api/v1/texts/{id}", textsHandler.UpdateText, opts)
+	handleProtected(mux, "DELETE /api/v1/texts/{id}", textsHandler.DeleteText, opts)
 
-	// Images routes
-	mux.HandleFunc("GET /api/v1/images", imagesHandler.ListImages)
-	mux.HandleFunc("GET /api/v1/images/{id}", imagesHandler.GetImageByID)
-	mux.HandleFunc("GET /api/v1/images/slug/{slug}", imagesHandler.GetImagesBySlug)
-	mux.HandleFunc("POST /api/v1/images",
-		middleware.NewAuthMiddlewareFunc(imagesHandler.CreateImage, opts.AuthConfig, opts.Logger),
+	// Images routes (reads identify the caller so private images can be served through signed URLs)
+	mux.HandleFunc("GET /api/v1/images",
+		middleware.NewIdentifyMiddlewareFunc(imagesHandler.ListImages, opts.AuthConfig, opts.Logger),
+	)
+	mux.HandleFunc("GET /api/v1/images/{id}",
+		middleware.NewIdentifyMiddlewareFunc(imagesHandler.GetImageByID, opts.AuthConfig, opts.Logger),
 	)
-	mux.HandleFunc("PUT /api/v1/images/{id}",
-		middleware.NewAuthMiddlewareFunc(imagesHandler.UpdateImage, opts.AuthConfig, opts.Logger),
+	handleProtected(mux, "GET /api/v1/images/duplicates", imagesHandler.ListDuplicateImages, opts)
+	mux.HandleFunc("GET /api/v1/images/slug/{slug}",
+		middleware.NewIdentifyMiddlewareFunc(imagesHandler.GetImagesBySlug, opts.AuthConfig, opts.Logger),
 	)
-	mux.HandleFunc("DELETE /api/v1/images/{id}",
-		middleware.NewAuthMiddlewareFunc(imagesHandler.DeleteImage, opts.AuthConfig, opts.Logger),
+	handleProtected(mux, "POST /api/v1/images", imagesHandler.CreateImage, opts)
+	handleProtected(mux, "PUT /api/v1/images/{id}", imagesHandler.UpdateImage, opts)
+	handleProtected(mux, "DELETE /api/v1/images/{id}", imagesHandler.DeleteImage, opts)
+	handleProtected(mux, "PUT /api/v1/images/{id}/visibility", imagesHandler.SetImageVisibility, opts)
+
+	// Image tags
+	mux.HandleFunc("GET /api/v1/tags",
+		middleware.NewIdentifyMiddlewareFunc(imagesHandler.ListTags, opts.AuthConfig, opts.Logger),
 	)
+	handleProtected(mux, "POST /api/v1/images/tags", imagesHandler.TagImages, opts)
+	handleProtected(mux, "POST /api/v1/images/tags/remove", imagesHandler.UntagImages, opts)
 
-	// Timeline routes
-	mux.HandleFunc("GET /api/v1/timelineentries", timelineHandler.ListTimelineEntries)
-	mux.HandleFunc("GET /api/v1/timelineentries/{id}", timelineHandler.GetTimelineEntryByID)
-	mux.HandleFunc("POST /api/v1/timelineentries",
-		middleware.NewAuthMiddlewareFunc(timelineHandler.CreateTimelineEntry, opts.AuthConfig, opts.Logger),
+	// Direct-to-bucket image uploads
+	handleProtected(mux, "POST /api/v1/images/uploads", imagesHandler.CreateImageUpload, opts)
+	handleProtected(mux, "POST /api/v1/images/uploads/finalize", imagesHandler.FinalizeImageUpload, opts)
+
+	// Timeline routes (reads identify the caller so drafts are only listed to roles that can edit the timeline)
+	mux.HandleFunc("GET /api/v1/timelineentries",
+		middleware.NewIdentifyMiddlewareFunc(timelineHandler.ListTimelineEntries, opts.AuthConfig, opts.Logger),
 	)
-	mux.HandleFunc("PUT /api/v1/timelineentries/{id}",
-		middleware.NewAuthMiddlewareFunc(timelineHandler.UpdateTimelineEntry, opts.AuthConfig, opts.Logger),
+	mux.HandleFunc("GET /api/v1/timelineentries/grouped",
+		middleware.NewIdentifyMiddlewareFunc(timelineHandler.GroupTimelineEntries, opts.AuthConfig, opts.Logger),
 	)
-	mux.HandleFunc("DELETE /api/v1/timelineentries/{id}",
-		middleware.NewAuthMiddlewareFunc(timelineHandler.DeleteTimelineEntry, opts.AuthConfig, opts.Logger),
+	mux.HandleFunc("GET /api/v1/timelineentries/{id}",
+		middleware.NewIdentifyMiddlewareFunc(timelineHandler.GetTimelineEntryByID, opts.AuthConfig, opts.Logger),
 	)
+	handleProtected(mux, "POST /api/v1/timelineentries", timelineHandler.CreateTimelineEntry, opts)
+	handleProtected(mux, "PUT /api/v1/timelineentries/{id}", timelineHandler.UpdateTimelineEntry, opts)
+	handleProtected(mux, "DELETE /api/v1/timelineentries/{id}", timelineHandler.DeleteTimelineEntry, opts)
+	handleProtected(mux, "PUT /api/v1/timelineentries/{id}/draft", timelineHandler.SetTimelineEntryDraft, opts)
 
 	// Events routes
 	mux.HandleFunc("GET /api/v1/events", eventsHandler.GetEvents)
+	mux.HandleFunc("GET /api/v1/events.ics", eventsHandler.GetEventsCalendar)
+	mux.HandleFunc("GET /api/v1/events/{id}", eventsHandler.GetEventByID)
+	mux.HandleFunc("GET /api/v1/events/{id}/calendar.ics", eventsHandler.GetEventCalendar)
+	mux.HandleFunc("GET /api/v1/events/{id}/sessions", eventsHandler.GetEventSessions)
+	mux.HandleFunc("GET /api/v1/events/{id}/speakers", eventsHandler.GetEventSpeakers)
+	mux.HandleFunc("GET /api/v1/events/{id}/galery",
+		middleware.NewIdentifyMiddlewareFunc(eventsHandler.GetEventGalery, opts.AuthConfig, opts.Logger),
+	)
 
-	// GaleryEvent routes
-	mux.HandleFunc("GET /api/v1/galery_events", galeryEventHandler.ListGaleryEvents)
-	mux.HandleFunc("GET /api/v1/galery_events/{id}", galeryEventHandler.GetGaleryEventByID)
-	mux.HandleFunc("POST /api/v1/galery_events",
-		middleware.NewAuthMiddlewareFunc(galeryEventHandler.CreateGaleryEvent, opts.AuthConfig, opts.Logger),
+	// Feed routes, public content only
+	mux.HandleFunc("GET /api/v1/feeds/{name}", feedsHandler.GetFeed)
+
+	// GaleryEvent routes (reads identify the caller so private events can be served through signed URLs)
+	mux.HandleFunc("GET /api/v1/galery_events",
+		middleware.NewIdentifyMiddlewareFunc(galeryEventHandler.ListGaleryEvents, opts.AuthConfig, opts.Logger),
 	)
-	mux.HandleFunc("PUT /api/v1/galery_events",
-		middleware.NewAuthMiddlewareFunc(galeryEventHandler.ModifyGaleryEvent, opts.AuthConfig, opts.Logger),
+	mux.HandleFunc("GET /api/v1/galery_events/{id}",
+		middleware.NewIdentifyMiddlewareFunc(galeryEventHandler.GetGaleryEventByID, opts.AuthConfig, opts.Logger),
 	)
-	mux.HandleFunc("DELETE /api/v1/galery_events/{id}",
-		middleware.NewAuthMiddlewareFunc(galeryEventHandler.DeleteGaleryEvent, opts.AuthConfig, opts.Logger),
+	mux.HandleFunc("GET /api/v1/galery_events/{id}/archive",
+		middleware.NewIdentifyMiddlewareFunc(galeryEventHandler.GetGaleryEventArchive, opts.AuthConfig, opts.Logger),
 	)
+	handleProtected(mux, "POST /api/v1/galery_events", galeryEventHandler.CreateGaleryEvent, opts)
+	handleProtected(mux, "PUT /api/v1/galery_events", galeryEventHandler.ModifyGaleryEvent, opts)
+	handleProtected(mux, "DELETE /api/v1/galery_events/{id}", galeryEventHandler.DeleteGaleryEvent, opts)
+	handleProtected(mux, "POST /api/v1/galery_events/{id}/images", galeryEventHandler.AddGaleryEventImage, opts)
+	handleProtected(mux, "DELETE /api/v1/galery_events/{id}/images/{imageId}", galeryEventHandler.RemoveGaleryEventImage, opts)
+	handleProtected(mux, "PUT /api/v1/galery_events/{id}/images/order", galeryEventHandler.ReorderGaleryEventImages, opts)
+	handleProtected(mux, "PUT /api/v1/galery_events/{id}/visibility", galeryEventHandler.SetGaleryEventVisibility, opts)
+
+	// Background job routes
+	handleProtected(mux, "GET /api/v1/jobs/{id}", jobsHandler.GetJob, opts)
+
+	// Event notification routes
+	handleProtected(mux, "GET /api/v1/notifications/dead_letters", notificationsHandler.ListDeadLetters, opts)
+
+	// Bulk import routes
+	handleProtected(mux, "POST /api/v1/import", importHandler.Import, opts)
+
+	// User role routes
+	handleProtected(mux, "GET /api/v1/users/{uid}", usersHandler.GetUser, opts)
+	handleProtected(mux, "PUT /api/v1/users/{uid}/role", usersHandler.SetUserRole, opts)
+
+	// Audit log routes
+	handleProtected(mux, "GET /api/v1/audit", auditHandler.ListAuditRecords, opts)
 
 	// Authorization check endpoint (always requires authentication)
 	mux.HandleFunc("GET /authorized",
@@ -0,0 +1,41 @@
+package http
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"backend/internal/platform/auth"
+)
+
+func TestRouter_AdminRoutesRequireTokenWhenOptional(t *testing.T) {
+	router := NewRouter(context.Background(), nil, RouterOptions{
+		AuthConfig: auth.AuthConfig{Verifier: auth.NewFakeVerifier(), Level: auth.AuthOptional},
+		Logger:     log.New(io.Discard, "", 0),
+	})
+
+	serve := func(method, path, idToken string) int {
+		r := httptest.NewRequest(method, path, strings.NewReader(`{"role":"admin"}`))
+		if idToken != "" {
+			r.Header.Set("Authorization", "Bearer "+idToken)
+		}
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, r)
+		return w.Code
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodPut, "/api/v1/users/k3C9dX2vQbT7/role", ""), "Anonymous callers can't grant roles")
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodPut, "/api/v1/users/k3C9dX2vQbT7/role", "forged"))
+	assert.Equal(t, http.StatusForbidden, serve(http.MethodPut, "/api/v1/users/k3C9dX2vQbT7/role", auth.FakeToken("k3C9dX2vQbT7", auth.RoleEditor)))
+
+	for _, path := range []string{"/api/v1/audit", "/api/v1/notifications/dead_letters", "/api/v1/users/k3C9dX2vQbT7"} {
+		assert.Equal(t, http.StatusUnauthorized, serve(http.MethodGet, path, ""), path)
+	}
+	assert.Equal(t, http.StatusUnauthorized, serve(http.MethodPost, "/api/v1/import?kind=texts&format=csv", ""))
+}
@@ -0,0 +1,108 @@
+// Package audit computes the changes of the content mutations recorded by the audit log
+//
+// Changes are keyed by the Firestore name of the fields, so they read like the stored documents.
+// Fields tagged firestore:"-" are never stored and never compared.
+package audit
+
+import (
+	"reflect"
+	"slices"
+	"strings"
+	"time"
+)
+
+// Change is the value of a field before and after a mutation
+// Before is nil for created values and After is nil for removed ones
+type Change struct {
+	Before any
+	After  any
+}
+
+// Diff returns the fields of two structs of the same type whose values differ
+// before or after may be nil, for creations and deletions: every non-empty field of the other is a change.
+// Fields named in ignore are skipped, e.g. timestamps that change on every write
+func Diff(before, after any, ignore ...string) map[string]Change {
+	b, a := structValue(before), structValue(after)
+	if !b.IsValid() && !a.IsValid() {
+		return nil
+	}
+
+	var t reflect.Type
+	switch {
+	case !a.IsValid():
+		t = b.Type()
+	case !b.IsValid() || b.Type() == a.Type():
+		t = a.Type()
+	default:
+		return nil
+	}
+
+	changes := make(map[string]Change)
+	for i := range t.NumField() {
+		field := t.Field(i)
+		name, stored := fieldName(field)
+		if !stored || slices.Contains(ignore, name) {
+			continue
+		}
+
+		var change Change
+		if b.IsValid() && !isEmpty(b.Field(i)) {
+			change.Before = b.Field(i).Interface()
+		}
+		if a.IsValid() && !isEmpty(a.Field(i)) {
+			change.After = a.Field(i).Interface()
+		}
+		if !equal(change.Before, change.After) {
+			changes[name] = change
+		}
+	}
+	return changes
+}
+
+// structValue dereferences v, the zero Value when it is nil or not a struct
+func structValue(v any) reflect.Value {
+	value := reflect.ValueOf(v)
+	for value.Kind() == reflect.Pointer {
+		if value.IsNil() {
+			return reflect.Value{}
+		}
+		value = value.Elem()
+	}
+	if value.Kind() != reflect.Struct {
+		return reflect.Value{}
+	}
+	return value
+}
+
+// fieldName returns the Firestore name of a field, stored is false for unexported fields and fields tagged "-"
+func fieldName(field reflect.StructField) (name string, stored bool) {
+	if !field.IsExported() {
+		return "", false
+	}
+	name, _, _ = strings.Cut(field.Tag.Get("firestore"), ",")
+	if name == "-" {
+		return "", false
+	}
+	if name == "" {
+		name = field.Name
+	}
+	return name, true
+}
+
+// isEmpty reports whether a field holds its zero value, empty slices and maps included
+func isEmpty(v reflect.Value) bool {
+	switch v.Kind() {
+	case reflect.Slice, reflect.Map:
+		return v.Len() == 0
+	}
+	return v.IsZero()
+}
+
+// equal compares two field values, times are equal when they are the same instant
+func equal(before, after any) bool {
+	if bt, ok := before.(time.Time); ok {
+		at, ok := after.(time.Time)
+		return ok && bt.Equal(at)
+	}
+	return reflect.DeepEqual(before, after)
+}
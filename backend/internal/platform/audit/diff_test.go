@@ -0,0 +1,54 @@
+package audit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+type document struct {
+	ID        string    `firestore:"-"`
+	Name      string    `firestore:"name"`
+	Tags      []string  `firestore:"tags,omitempty"`
+	Private   bool      `firestore:"private"`
+	Untagged  int       // Stored under its Go name
+	UpdatedAt time.Time `firestore:"updatedAt"`
+}
+
+func TestDiff_Update(t *testing.T) {
+	at := time.Date(2025, 3, 14, 19, 0, 0, 0, time.UTC)
+	before := document{ID: "a", Name: "Grupy", Tags: []string{"meetup"}, UpdatedAt: at}
+	after := document{ID: "b", Name: "Grupy Sanca", Tags: []string{}, Private: true, UpdatedAt: at.Add(time.Hour)}
+
+	changes := Diff(before, &after, "updatedAt")
+	assert.Equal(t, map[string]Change{
+		"name":    {Before: "Grupy", After: "Grupy Sanca"},
+		"tags":    {Before: []string{"meetup"}},
+		"private": {After: true},
+	}, changes, "IDs and ignored fields are left out")
+}
+
+func TestDiff_CreateAndDelete(t *testing.T) {
+	doc := document{ID: "a", Name: "Grupy", Untagged: 2}
+
+	assert.Equal(t, map[string]Change{
+		"name":     {After: "Grupy"},
+		"Untagged": {After: 2},
+	}, Diff(nil, doc))
+
+	assert.Equal(t, map[string]Change{
+		"name":     {Before: "Grupy"},
+		"Untagged": {Before: 2},
+	}, Diff(&doc, nil))
+}
+
+func TestDiff_Unchanged(t *testing.T) {
+	at := time.Date(2025, 3, 14, 19, 0, 0, 0, time.UTC)
+	before := document{Name: "Grupy", UpdatedAt: at}
+	after := document{Name: "Grupy", Tags: []string{}, UpdatedAt: at.In(time.FixedZone("BRT", -3*60*60))}
+
+	assert.Empty(t, Diff(before, after), "Nil and empty slices and the same instant in another zone are equal")
+	assert.Nil(t, Diff(nil, nil))
+	assert.Nil(t, Diff(before, struct{ Name string }{}), "Values of different types can't be compared")
+}
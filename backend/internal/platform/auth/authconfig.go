@@ -1,10 +1,6 @@
 package auth
 
-import (
-	"strings"
-
-	"firebase.google.com/go/v4/auth"
-)
+import "strings"
 
 type AuthLevel int
 
@@ -40,6 +36,6 @@ func AuthLevelFromString(value string) AuthLevel {
 
 // AuthConfig determines the configuration used for endpoint authorization in the server
 type AuthConfig struct {
-	Client *auth.Client
-	Level  AuthLevel
+	Verifier TokenVerifier // Nil disables authentication, every request is trusted
+	Level    AuthLevel
 }
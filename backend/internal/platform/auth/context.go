@@ -0,0 +1,47 @@
+package auth
+
+import "context"
+
+type contextKey string
+
+const principalKey contextKey = "principal"
+
+// Principal is the verified caller of a request
+type Principal struct {
+	UID   string // Firebase uid, empty when authentication is disabled
+	Email string
+	Role  Role
+}
+
+// ContextWithPrincipal marks the context as belonging to a request with a verified token, made by p
+func ContextWithPrincipal(ctx context.Context, p Principal) context.Context {
+	return context.WithValue(ctx, principalKey, p)
+}
+
+// PrincipalFromContext returns the verified caller of a request, ok is false for anonymous callers
+func PrincipalFromContext(ctx context.Context) (p Principal, ok bool) {
+	p, ok = ctx.Value(principalKey).(Principal)
+	return p, ok
+}
+
+// IsAuthenticated reports whether the context belongs to a request with a verified token
+func IsAuthenticated(ctx context.Context) bool {
+	_, ok := PrincipalFromContext(ctx)
+	return ok
+}
+
+// CanFromContext reports whether the caller of a request has permission, anonymous callers have none
+func CanFromContext(ctx context.Context, permission Permission) bool {
+	p, ok := PrincipalFromContext(ctx)
+	return ok && p.Role.Can(permission)
+}
+
+// ActorFromContext identifies the caller in the CreatedBy and LastUpdatedBy fields: its email, or its uid
+// when the account has no email. Empty for anonymous callers and while authentication is disabled
+func ActorFromContext(ctx context.Context) string {
+	p, _ := PrincipalFromContext(ctx)
+	if p.Email != "" {
+		return p.Email
+	}
+	return p.UID
+}
@@ -0,0 +1,39 @@
+package auth
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPrincipalFromContext(t *testing.T) {
+	ctx := context.Background()
+	assert.False(t, IsAuthenticated(ctx))
+	assert.Empty(t, ActorFromContext(ctx), "Anonymous callers have no actor")
+
+	ctx = ContextWithPrincipal(ctx, Principal{UID: "k3C9dX2vQbT7", Email: "editor@example.com", Role: RoleEditor})
+	p, ok := PrincipalFromContext(ctx)
+	assert.True(t, ok)
+	assert.Equal(t, RoleEditor, p.Role)
+	assert.True(t, IsAuthenticated(ctx))
+	assert.Equal(t, "editor@example.com", ActorFromContext(ctx))
+}
+
+func TestActorFromContext_WithoutEmail(t *testing.T) {
+	ctx := ContextWithPrincipal(context.Background(), Principal{UID: "k3C9dX2vQbT7", Role: RoleViewer})
+	assert.Equal(t, "k3C9dX2vQbT7", ActorFromContext(ctx))
+
+	ctx = ContextWithPrincipal(context.Background(), Principal{Role: RoleAdmin})
+	assert.Empty(t, ActorFromContext(ctx), "Requests served while authentication is disabled have no actor")
+}
+
+func TestCanFromContext(t *testing.T) {
+	assert.False(t, CanFromContext(context.Background(), PermissionEditTimeline), "Anonymous callers have no permission")
+
+	ctx := ContextWithPrincipal(context.Background(), Principal{UID: "k3C9dX2vQbT7", Role: RoleViewer})
+	assert.False(t, CanFromContext(ctx, PermissionEditTimeline))
+
+	ctx = ContextWithPrincipal(context.Background(), Principal{UID: "k3C9dX2vQbT7", Role: RoleEditor})
+	assert.True(t, CanFromContext(ctx, PermissionEditTimeline))
+}
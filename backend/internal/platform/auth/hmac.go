@@ -0,0 +1,63 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// minHMACSecretLength is the size of a HS256 key, shorter secrets are easy to brute force
+const minHMACSecretLength = 32
+
+// hmacVerifier verifies JWTs signed with a shared secret, so local development runs without a Firebase project
+type hmacVerifier struct {
+	secret []byte
+	issuer string
+}
+
+// NewHMACVerifier creates a verifier of the JWTs signed with secret, e.g. by SignHMACToken
+// The iss claim must be issuer unless it is empty
+func NewHMACVerifier(secret []byte, issuer string) (TokenVerifier, error) {
+	if len(secret) < minHMACSecretLength {
+		return nil, fmt.Errorf("hmac secret must be at least %d bytes", minHMACSecretLength)
+	}
+	return &hmacVerifier{secret: secret, issuer: issuer}, nil
+}
+
+func (v *hmacVerifier) VerifyIDToken(ctx context.Context, idToken string) (*Token, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, func(*jwt.Token) (any, error) {
+		return v.secret, nil
+	}, jwt.WithValidMethods([]string{"HS256", "HS384", "HS512"}))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return tokenFromClaims(claims, v.issuer, "")
+}
+
+// SignHMACToken signs a HS256 token of principal accepted by NewHMACVerifier for ttl
+func SignHMACToken(secret []byte, issuer string, principal Principal, ttl time.Duration) (string, error) {
+	if principal.UID == "" {
+		return "", errors.New("principal must have a uid")
+	}
+
+	now := time.Now()
+	claims := jwt.MapClaims{
+		"sub": principal.UID,
+		"iat": now.Unix(),
+		"exp": now.Add(ttl).Unix(),
+	}
+	if issuer != "" {
+		claims["iss"] = issuer
+	}
+	if principal.Email != "" {
+		claims["email"] = principal.Email
+	}
+	if principal.Role != "" {
+		claims[RoleClaim] = string(principal.Role)
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+
+	"github.com/MicahParks/keyfunc"
+	"github.com/golang-jwt/jwt/v4"
+)
+
+const (
+	// oidcRequestTimeout bounds the discovery and the downloads of the signing keys
+	oidcRequestTimeout = 10 * time.Second
+
+	// oidcRefreshInterval is the time between two downloads of the signing keys, providers rotate them slowly
+	oidcRefreshInterval = time.Hour
+
+	// oidcRefreshRateLimit is the minimum time between two downloads caused by tokens signed with an unknown key
+	oidcRefreshRateLimit = 5 * time.Minute
+)
+
+// oidcVerifier verifies the ID tokens of an OpenID Connect provider with the keys it publishes as a JWKS
+type oidcVerifier struct {
+	jwks     *keyfunc.JWKS
+	issuer   string
+	audience string
+}
+
+// NewOIDCVerifier creates a verifier of the ID tokens issued by issuer for audience, usually the client id of the site
+// The signing keys are read from jwksURL, or from the jwks_uri of the issuer's discovery document when it is empty.
+// They are refreshed in the background until ctx is done
+func NewOIDCVerifier(ctx context.Context, issuer, audience, jwksURL string) (TokenVerifier, error) {
+	if issuer == "" || audience == "" {
+		return nil, errors.New("oidc verifier needs an issuer and an audience")
+	}
+
+	client := &http.Client{Timeout: oidcRequestTimeout}
+	if jwksURL == "" {
+		discovered, err := discoverJWKSURL(ctx, client, issuer)
+		if err != nil {
+			return nil, err
+		}
+		jwksURL = discovered
+	}
+
+	jwks, err := keyfunc.Get(jwksURL, keyfunc.Options{
+		Client:            client,
+		Ctx:               ctx,
+		RefreshInterval:   oidcRefreshInterval,
+		RefreshRateLimit:  oidcRefreshRateLimit,
+		RefreshTimeout:    oidcRequestTimeout,
+		RefreshUnknownKID: true,
+		RefreshErrorHandler: func(err error) {
+			log.Printf("oidc: failed to refresh the signing keys of %s: %v", issuer, err)
+		},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the signing keys of %s: %w", issuer, err)
+	}
+	return &oidcVerifier{jwks: jwks, issuer: issuer, audience: audience}, nil
+}
+
+func (v *oidcVerifier) VerifyIDToken(ctx context.Context, idToken string) (*Token, error) {
+	claims := jwt.MapClaims{}
+	_, err := jwt.ParseWithClaims(idToken, claims, v.jwks.Keyfunc,
+		jwt.WithValidMethods([]string{"RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "PS256", "PS384", "PS512"}))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", ErrInvalidToken, err)
+	}
+	return tokenFromClaims(claims, v.issuer, v.audience)
+}
+
+// discoverJWKSURL reads the address of the signing keys from the OpenID Connect discovery document of issuer
+func discoverJWKSURL(ctx context.Context, client *http.Client, issuer string) (string, error) {
+	url := strings.TrimSuffix(issuer, "/") + "/.well-known/openid-configuration"
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, url, nil)
+	if err != nil {
+		return "", fmt.Errorf("failed to create discovery request: %w", err)
+	}
+
+	resp, err := client.Do(req)
+	if err != nil {
+		return "", fmt.Errorf("failed to get discovery document of %s: %w", issuer, err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("discovery document of %s returned status %d", issuer, resp.StatusCode)
+	}
+
+	var document struct {
+		JWKSURI string `json:"jwks_uri"`
+	}
+	if err := json.NewDecoder(resp.Body).Decode(&document); err != nil {
+		return "", fmt.Errorf("failed to decode discovery document of %s: %w", issuer, err)
+	}
+	if document.JWKSURI == "" {
+		return "", fmt.Errorf("discovery document of %s has no jwks_uri", issuer)
+	}
+	return document.JWKSURI, nil
+}
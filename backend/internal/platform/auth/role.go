@@ -0,0 +1,82 @@
+package auth
+
+import (
+	"fmt"
+	"slices"
+)
+
+// RoleClaim is the custom claim of the Firebase ID tokens holding the role of the user
+const RoleClaim = "role"
+
+// Role is what a user does for the community, it grants the permissions of the editing routes
+type Role string
+
+const (
+	RoleAdmin        Role = "admin"        // Everything, including the roles of the other users
+	RoleEditor       Role = "editor"       // Texts, timeline and galery
+	RolePhotographer Role = "photographer" // Uploads images and galery events, can't delete them
+	RoleViewer       Role = "viewer"       // Reads private content only, the role of users without claim
+)
+
+// Permission is an action an editing route requires
+type Permission string
+
+const (
+	PermissionEditTexts         Permission = "texts:edit"
+	PermissionEditTimeline      Permission = "timeline:edit"
+	PermissionUploadGalery      Permission = "galery:upload" // Images, tags, galery events and their jobs
+	PermissionDeleteGalery      Permission = "galery:delete"
+	PermissionImport            Permission = "import"
+	PermissionReadNotifications Permission = "notifications:read"
+	PermissionManageUsers       Permission = "users:manage"
+	PermissionReadAudit         Permission = "audit:read"
+)
+
+// rolePermissions is the permission table of the roles, admins are granted everything
+var rolePermissions = map[Role][]Permission{
+	RoleEditor: {
+		PermissionEditTexts,
+		PermissionEditTimeline,
+		PermissionUploadGalery,
+		PermissionDeleteGalery,
+	},
+	RolePhotographer: {
+		PermissionUploadGalery,
+	},
+}
+
+// ParseRole reads a role name
+func ParseRole(name string) (Role, error) {
+	role := Role(name)
+	if role != RoleAdmin && role != RoleEditor && role != RolePhotographer && role != RoleViewer {
+		return "", fmt.Errorf("unknown role %q, expected admin, editor, photographer or viewer", name)
+	}
+	return role, nil
+}
+
+// RoleFromClaims reads the role of the custom claims of a token
+// Users without a known role are viewers
+func RoleFromClaims(claims map[string]any) Role {
+	name, _ := claims[RoleClaim].(string)
+	role, err := ParseRole(name)
+	if err != nil {
+		return RoleViewer
+	}
+	return role
+}
+
+// Can reports whether the role grants permission
+func (r Role) Can(permission Permission) bool {
+	return r == RoleAdmin || slices.Contains(rolePermissions[r], permission)
+}
+
+// AdminOnly reports whether only admins are granted permission
+// Such permissions are never served to anonymous callers, whatever the level of authentication
+func (p Permission) AdminOnly() bool {
+	for _, permissions := range rolePermissions {
+		if slices.Contains(permissions, p) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,51 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRoleFromClaims(t *testing.T) {
+	assert.Equal(t, RoleEditor, RoleFromClaims(map[string]any{"role": "editor"}))
+	assert.Equal(t, RoleViewer, RoleFromClaims(map[string]any{}), "Users without claim are viewers")
+	assert.Equal(t, RoleViewer, RoleFromClaims(map[string]any{"role": "owner"}), "Unknown roles are viewers")
+	assert.Equal(t, RoleViewer, RoleFromClaims(map[string]any{"role": 1}))
+}
+
+func TestRoleCan(t *testing.T) {
+	for _, tc := range []struct {
+		role       Role
+		permission Permission
+		can        bool
+	}{
+		{RoleAdmin, PermissionManageUsers, true},
+		{RoleEditor, PermissionEditTexts, true},
+		{RoleEditor, PermissionManageUsers, false},
+		{RoleEditor, PermissionReadAudit, false},
+		{RolePhotographer, PermissionUploadGalery, true},
+		{RolePhotographer, PermissionDeleteGalery, false},
+		{RolePhotographer, PermissionEditTexts, false},
+		{RoleViewer, PermissionUploadGalery, false},
+	} {
+		assert.Equal(t, tc.can, tc.role.Can(tc.permission), "%s %s", tc.role, tc.permission)
+	}
+}
+
+func TestPermissionAdminOnly(t *testing.T) {
+	assert.True(t, PermissionManageUsers.AdminOnly())
+	assert.True(t, PermissionReadAudit.AdminOnly())
+	assert.True(t, PermissionImport.AdminOnly())
+	assert.True(t, PermissionReadNotifications.AdminOnly())
+	assert.False(t, PermissionEditTexts.AdminOnly())
+	assert.False(t, PermissionUploadGalery.AdminOnly())
+}
+
+func TestParseRole(t *testing.T) {
+	role, err := ParseRole("photographer")
+	assert.NoError(t, err)
+	assert.Equal(t, RolePhotographer, role)
+
+	_, err = ParseRole("Admin")
+	assert.Error(t, err)
+}
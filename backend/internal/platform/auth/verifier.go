@@ -0,0 +1,46 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+)
+
+// ErrInvalidToken is returned by the verifiers for tokens that are malformed, expired or not signed by the expected issuer
+var ErrInvalidToken = errors.New("invalid token")
+
+// Token is a verified ID token
+type Token struct {
+	UID    string         // Subject of the token, the Firebase uid for Firebase tokens
+	Claims map[string]any // Every claim of the token, the role is read from RoleClaim
+}
+
+// TokenVerifier verifies the ID tokens sent as Bearer tokens in the Authorization header
+// Implementations: Firebase (clients.NewFirebaseTokenVerifier), any OIDC provider publishing its keys as a JWKS,
+// tokens signed with a shared HMAC secret for local development and deterministic fake tokens for tests
+type TokenVerifier interface {
+	VerifyIDToken(ctx context.Context, idToken string) (*Token, error)
+}
+
+// tokenFromClaims checks the claims of a JWT whose signature was verified, issuer and audience are skipped when empty
+// The token must expire and name its subject
+func tokenFromClaims(claims jwt.MapClaims, issuer, audience string) (*Token, error) {
+	if !claims.VerifyExpiresAt(time.Now().Unix(), true) {
+		return nil, fmt.Errorf("%w: missing or past exp claim", ErrInvalidToken)
+	}
+	if issuer != "" && !claims.VerifyIssuer(issuer, true) {
+		return nil, fmt.Errorf("%w: unexpected issuer %v", ErrInvalidToken, claims["iss"])
+	}
+	if audience != "" && !claims.VerifyAudience(audience, true) {
+		return nil, fmt.Errorf("%w: unexpected audience %v", ErrInvalidToken, claims["aud"])
+	}
+
+	uid, _ := claims["sub"].(string)
+	if uid == "" {
+		return nil, fmt.Errorf("%w: missing sub claim", ErrInvalidToken)
+	}
+	return &Token{UID: uid, Claims: claims}, nil
+}
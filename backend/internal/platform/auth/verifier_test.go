@@ -0,0 +1,164 @@
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/base64"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/golang-jwt/jwt/v4"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const testSecret = "0123456789abcdef0123456789abcdef"
+
+func TestFakeVerifier(t *testing.T) {
+	verifier := NewFakeVerifier()
+
+	token, err := verifier.VerifyIDToken(context.Background(), FakeToken("k3C9dX2vQbT7", RoleEditor))
+	require.NoError(t, err)
+	assert.Equal(t, "k3C9dX2vQbT7", token.UID)
+	assert.Equal(t, RoleEditor, RoleFromClaims(token.Claims))
+
+	token, err = verifier.VerifyIDToken(context.Background(), FakeToken("k3C9dX2vQbT7", ""))
+	require.NoError(t, err)
+	assert.Equal(t, RoleViewer, RoleFromClaims(token.Claims), "Users without a role are viewers")
+
+	for _, idToken := range []string{"", "k3C9dX2vQbT7.editor", "fake.", "fake..admin"} {
+		_, err := verifier.VerifyIDToken(context.Background(), idToken)
+		assert.ErrorIs(t, err, ErrInvalidToken, idToken)
+	}
+}
+
+func TestHMACVerifier(t *testing.T) {
+	verifier, err := NewHMACVerifier([]byte(testSecret), "grupy-dev")
+	require.NoError(t, err)
+
+	idToken, err := SignHMACToken([]byte(testSecret), "grupy-dev", Principal{UID: "k3C9dX2vQbT7", Email: "editor@example.com", Role: RoleEditor}, time.Hour)
+	require.NoError(t, err)
+
+	token, err := verifier.VerifyIDToken(context.Background(), idToken)
+	require.NoError(t, err)
+	assert.Equal(t, "k3C9dX2vQbT7", token.UID)
+	assert.Equal(t, "editor@example.com", token.Claims["email"])
+	assert.Equal(t, RoleEditor, RoleFromClaims(token.Claims))
+}
+
+func TestHMACVerifier_Invalid(t *testing.T) {
+	_, err := NewHMACVerifier([]byte("short"), "")
+	assert.Error(t, err, "Short secrets are refused")
+
+	verifier, err := NewHMACVerifier([]byte(testSecret), "grupy-dev")
+	require.NoError(t, err)
+	principal := Principal{UID: "k3C9dX2vQbT7", Role: RoleAdmin}
+
+	otherSecret, _ := SignHMACToken([]byte("fedcba9876543210fedcba9876543210"), "grupy-dev", principal, time.Hour)
+	expired, _ := SignHMACToken([]byte(testSecret), "grupy-dev", principal, -time.Minute)
+	otherIssuer, _ := SignHMACToken([]byte(testSecret), "someone-else", principal, time.Hour)
+	noExpiry, _ := jwt.NewWithClaims(jwt.SigningMethodHS256, jwt.MapClaims{"sub": "k3C9dX2vQbT7", "iss": "grupy-dev"}).SignedString([]byte(testSecret))
+	unsigned, _ := jwt.NewWithClaims(jwt.SigningMethodNone, jwt.MapClaims{"sub": "k3C9dX2vQbT7"}).SignedString(jwt.UnsafeAllowNoneSignatureType)
+
+	for name, idToken := range map[string]string{
+		"other secret": otherSecret,
+		"expired":      expired,
+		"other issuer": otherIssuer,
+		"no expiry":    noExpiry,
+		"unsigned":     unsigned,
+		"malformed":    "not-a-jwt",
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := verifier.VerifyIDToken(context.Background(), idToken)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+// newOIDCProvider serves the discovery document and the JWKS of an RSA key, identified as kid
+func newOIDCProvider(t *testing.T, key *rsa.PrivateKey, kid string) *httptest.Server {
+	mux := http.NewServeMux()
+	provider := httptest.NewServer(mux)
+	t.Cleanup(provider.Close)
+
+	mux.HandleFunc("GET /.well-known/openid-configuration", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]string{"issuer": provider.URL, "jwks_uri": provider.URL + "/keys"})
+	})
+	mux.HandleFunc("GET /keys", func(w http.ResponseWriter, r *http.Request) {
+		json.NewEncoder(w).Encode(map[string]any{"keys": []map[string]string{{
+			"kty": "RSA",
+			"kid": kid,
+			"alg": "RS256",
+			"use": "sig",
+			"n":   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+			"e":   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+		}}})
+	})
+	return provider
+}
+
+// signRS256 signs claims with key, identified as kid
+func signRS256(t *testing.T, key *rsa.PrivateKey, kid string, claims jwt.MapClaims) string {
+	token := jwt.NewWithClaims(jwt.SigningMethodRS256, claims)
+	token.Header["kid"] = kid
+	signed, err := token.SignedString(key)
+	require.NoError(t, err)
+	return signed
+}
+
+func TestOIDCVerifier(t *testing.T) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	provider := newOIDCProvider(t, key, "key-1")
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	verifier, err := NewOIDCVerifier(ctx, provider.URL, "grupy-site", "")
+	require.NoError(t, err, "The keys are discovered from the issuer")
+
+	exp := time.Now().Add(time.Hour).Unix()
+	idToken := signRS256(t, key, "key-1", jwt.MapClaims{
+		"iss": provider.URL, "aud": "grupy-site", "sub": "k3C9dX2vQbT7", "exp": exp, RoleClaim: "photographer",
+	})
+	token, err := verifier.VerifyIDToken(ctx, idToken)
+	require.NoError(t, err)
+	assert.Equal(t, "k3C9dX2vQbT7", token.UID)
+	assert.Equal(t, RolePhotographer, RoleFromClaims(token.Claims))
+
+	otherKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+	for name, idToken := range map[string]string{
+		"other audience": signRS256(t, key, "key-1", jwt.MapClaims{"iss": provider.URL, "aud": "other-site", "sub": "k3C9dX2vQbT7", "exp": exp}),
+		"other issuer":   signRS256(t, key, "key-1", jwt.MapClaims{"iss": "https://example.com", "aud": "grupy-site", "sub": "k3C9dX2vQbT7", "exp": exp}),
+		"other key":      signRS256(t, otherKey, "key-1", jwt.MapClaims{"iss": provider.URL, "aud": "grupy-site", "sub": "k3C9dX2vQbT7", "exp": exp}),
+		"no subject":     signRS256(t, key, "key-1", jwt.MapClaims{"iss": provider.URL, "aud": "grupy-site", "exp": exp}),
+		"hmac":           mustSignHMAC(t, jwt.MapClaims{"iss": provider.URL, "aud": "grupy-site", "sub": "k3C9dX2vQbT7", "exp": exp}),
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := verifier.VerifyIDToken(ctx, idToken)
+			assert.ErrorIs(t, err, ErrInvalidToken)
+		})
+	}
+}
+
+func TestNewOIDCVerifier_Invalid(t *testing.T) {
+	_, err := NewOIDCVerifier(context.Background(), "", "grupy-site", "")
+	assert.Error(t, err, "The issuer is required")
+
+	provider := httptest.NewServer(http.NotFoundHandler())
+	defer provider.Close()
+	_, err = NewOIDCVerifier(context.Background(), provider.URL, "grupy-site", "")
+	assert.Error(t, err, "Issuers without a discovery document need a jwks_url")
+}
+
+// mustSignHMAC signs claims with the test secret, the algorithm confusion an OIDC verifier must refuse
+func mustSignHMAC(t *testing.T, claims jwt.MapClaims) string {
+	signed, err := jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString([]byte(testSecret))
+	require.NoError(t, err)
+	return signed
+}
@@ -0,0 +1,222 @@
+package feed
+
+import (
+	"encoding/xml"
+	"fmt"
+	"io"
+	"strconv"
+	"time"
+)
+
+const (
+	_atomNamespace = "http://www.w3.org/2005/Atom"
+	_rssVersion    = "2.0"
+)
+
+// Feed is a syndication feed, encoded as Atom 1.0 or RSS 2.0
+type Feed struct {
+	ID          string // Permanent IRI, e.g. "tag:grupysanca.com.br,2025:feeds/timeline"
+	Title       string
+	Description string
+	Link        string // Page of the site showing the content of the feed
+	Author      string
+	Updated     time.Time
+	Items       []Item
+}
+
+// Item is an entry of a feed
+type Item struct {
+	ID        string // Permanent IRI, stable across updates of the item
+	Title     string
+	Link      string
+	Summary   string // Plain text
+	Published time.Time
+	Updated   time.Time
+	Enclosure *Enclosure
+}
+
+// Enclosure is a media file attached to an item, e.g. the cover image of an album
+type Enclosure struct {
+	URL    string
+	Type   string // Media type, e.g. "image/jpeg"
+	Length int64  // Size in bytes, 0 when unknown
+}
+
+// Atom 1.0 document (RFC 4287)
+type atomFeed struct {
+	XMLName  xml.Name    `xml:"feed"`
+	Xmlns    string      `xml:"xmlns,attr"`
+	ID       string      `xml:"id"`
+	Title    string      `xml:"title"`
+	Subtitle string      `xml:"subtitle,omitempty"`
+	Updated  string      `xml:"updated"`
+	Links    []atomLink  `xml:"link"`
+	Author   *atomAuthor `xml:"author,omitempty"`
+	Entries  []atomEntry `xml:"entry"`
+}
+
+type atomLink struct {
+	Rel    string `xml:"rel,attr,omitempty"`
+	Href   string `xml:"href,attr"`
+	Type   string `xml:"type,attr,omitempty"`
+	Length string `xml:"length,attr,omitempty"`
+}
+
+type atomAuthor struct {
+	Name string `xml:"name"`
+}
+
+type atomEntry struct {
+	ID        string     `xml:"id"`
+	Title     string     `xml:"title"`
+	Updated   string     `xml:"updated"`
+	Published string     `xml:"published,omitempty"`
+	Links     []atomLink `xml:"link"`
+	Summary   string     `xml:"summary,omitempty"`
+}
+
+// RSS 2.0 document
+type rssDocument struct {
+	XMLName xml.Name   `xml:"rss"`
+	Version string     `xml:"version,attr"`
+	Channel rssChannel `xml:"channel"`
+}
+
+type rssChannel struct {
+	Title         string    `xml:"title"`
+	Link          string    `xml:"link"`
+	Description   string    `xml:"description"`
+	LastBuildDate string    `xml:"lastBuildDate,omitempty"`
+	Items         []rssItem `xml:"item"`
+}
+
+type rssItem struct {
+	Title       string        `xml:"title"`
+	Link        string        `xml:"link,omitempty"`
+	Description string        `xml:"description,omitempty"`
+	GUID        rssGUID       `xml:"guid"`
+	PubDate     string        `xml:"pubDate,omitempty"`
+	Enclosure   *rssEnclosure `xml:"enclosure,omitempty"`
+}
+
+type rssGUID struct {
+	Value       string `xml:",chardata"`
+	IsPermaLink bool   `xml:"isPermaLink,attr"`
+}
+
+type rssEnclosure struct {
+	URL    string `xml:"url,attr"`
+	Type   string `xml:"type,attr"`
+	Length int64  `xml:"length,attr"`
+}
+
+// EncodeAtom writes f as an Atom 1.0 document
+// Items without an update time are stamped with their publication time
+func EncodeAtom(w io.Writer, f Feed) error {
+	doc := atomFeed{
+		Xmlns:    _atomNamespace,
+		ID:       f.ID,
+		Title:    f.Title,
+		Subtitle: f.Description,
+		Updated:  formatAtomTime(f.Updated),
+		Entries:  make([]atomEntry, 0, len(f.Items)),
+	}
+	if f.Link != "" {
+		doc.Links = append(doc.Links, atomLink{Rel: "alternate", Href: f.Link})
+	}
+	if f.Author != "" {
+		doc.Author = &atomAuthor{Name: f.Author}
+	}
+
+	for _, item := range f.Items {
+		updated := item.Updated
+		if updated.IsZero() {
+			updated = item.Published
+		}
+
+		entry := atomEntry{
+			ID:      item.ID,
+			Title:   item.Title,
+			Updated: formatAtomTime(updated),
+			Summary: item.Summary,
+		}
+		if !item.Published.IsZero() {
+			entry.Published = formatAtomTime(item.Published)
+		}
+		if item.Link != "" {
+			entry.Links = append(entry.Links, atomLink{Rel: "alternate", Href: item.Link})
+		}
+		if item.Enclosure != nil {
+			link := atomLink{Rel: "enclosure", Href: item.Enclosure.URL, Type: item.Enclosure.Type}
+			if item.Enclosure.Length > 0 {
+				link.Length = strconv.FormatInt(item.Enclosure.Length, 10)
+			}
+			entry.Links = append(entry.Links, link)
+		}
+		doc.Entries = append(doc.Entries, entry)
+	}
+
+	return encode(w, doc)
+}
+
+// EncodeRSS writes f as an RSS 2.0 document
+// The feed ID isn't part of RSS, item IDs are used as GUIDs that aren't permalinks
+func EncodeRSS(w io.Writer, f Feed) error {
+	doc := rssDocument{
+		Version: _rssVersion,
+		Channel: rssChannel{
+			Title:       f.Title,
+			Link:        f.Link,
+			Description: f.Description,
+			Items:       make([]rssItem, 0, len(f.Items)),
+		},
+	}
+	if !f.Updated.IsZero() {
+		doc.Channel.LastBuildDate = formatRSSTime(f.Updated)
+	}
+
+	for _, item := range f.Items {
+		rss := rssItem{
+			Title:       item.Title,
+			Link:        item.Link,
+			Description: item.Summary,
+			GUID:        rssGUID{Value: item.ID},
+		}
+		if !item.Published.IsZero() {
+			rss.PubDate = formatRSSTime(item.Published)
+		}
+		if item.Enclosure != nil {
+			// RSS requires a length, 0 is the usual value when it is unknown
+			rss.Enclosure = &rssEnclosure{
+				URL:    item.Enclosure.URL,
+				Type:   item.Enclosure.Type,
+				Length: item.Enclosure.Length,
+			}
+		}
+		doc.Channel.Items = append(doc.Channel.Items, rss)
+	}
+
+	return encode(w, doc)
+}
+
+// encode writes an XML document with its declaration
+func encode(w io.Writer, doc any) error {
+	if _, err := io.WriteString(w, xml.Header); err != nil {
+		return err
+	}
+
+	enc := xml.NewEncoder(w)
+	enc.Indent("", "  ")
+	if err := enc.Encode(doc); err != nil {
+		return fmt.Errorf("failed to encode feed: %w", err)
+	}
+	return enc.Close()
+}
+
+func formatAtomTime(t time.Time) string {
+	return t.UTC().Format(time.RFC3339)
+}
+
+func formatRSSTime(t time.Time) string {
+	return t.UTC().Format(time.RFC1123Z)
+}
@@ -0,0 +1,122 @@
+package feed
+
+import (
+	"bytes"
+	"encoding/xml"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func albumFeed() Feed {
+	return Feed{
+		ID:          "tag:grupysanca.com.br,2025:feeds/galery",
+		Title:       "Álbuns",
+		Description: "Fotos & eventos",
+		Link:        "https://site-grupy.vercel.app/galeria",
+		Author:      "Grupy Sanca",
+		Updated:     time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC),
+		Items: []Item{
+			{
+				ID:        "tag:grupysanca.com.br,2025:galery/1",
+				Title:     "Pylestras <3",
+				Link:      "https://site-grupy.vercel.app/galeria#1",
+				Summary:   "São Carlos",
+				Published: time.Date(2025, 3, 1, 7, 0, 0, 0, time.FixedZone("-03", -3*3600)),
+				Updated:   time.Date(2025, 3, 2, 10, 0, 0, 0, time.UTC),
+				Enclosure: &Enclosure{URL: "https://storage.example.com/1.jpg", Type: "image/jpeg", Length: 1234},
+			},
+			{
+				ID:        "tag:grupysanca.com.br,2025:galery/2",
+				Title:     "Sprint",
+				Published: time.Date(2025, 2, 1, 10, 0, 0, 0, time.UTC),
+			},
+		},
+	}
+}
+
+func TestEncodeAtom(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, EncodeAtom(&buf, albumFeed()))
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<feed xmlns="http://www.w3.org/2005/Atom">
+  <id>tag:grupysanca.com.br,2025:feeds/galery</id>
+  <title>Álbuns</title>
+  <subtitle>Fotos &amp; eventos</subtitle>
+  <updated>2025-03-02T10:00:00Z</updated>
+  <link rel="alternate" href="https://site-grupy.vercel.app/galeria"></link>
+  <author>
+    <name>Grupy Sanca</name>
+  </author>
+  <entry>
+    <id>tag:grupysanca.com.br,2025:galery/1</id>
+    <title>Pylestras &lt;3</title>
+    <updated>2025-03-02T10:00:00Z</updated>
+    <published>2025-03-01T10:00:00Z</published>
+    <link rel="alternate" href="https://site-grupy.vercel.app/galeria#1"></link>
+    <link rel="enclosure" href="https://storage.example.com/1.jpg" type="image/jpeg" length="1234"></link>
+    <summary>São Carlos</summary>
+  </entry>
+  <entry>
+    <id>tag:grupysanca.com.br,2025:galery/2</id>
+    <title>Sprint</title>
+    <updated>2025-02-01T10:00:00Z</updated>
+    <published>2025-02-01T10:00:00Z</published>
+  </entry>
+</feed>`
+
+	assert.Equal(t, want, buf.String())
+}
+
+func TestEncodeRSS(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, EncodeRSS(&buf, albumFeed()))
+
+	want := `<?xml version="1.0" encoding="UTF-8"?>
+<rss version="2.0">
+  <channel>
+    <title>Álbuns</title>
+    <link>https://site-grupy.vercel.app/galeria</link>
+    <description>Fotos &amp; eventos</description>
+    <lastBuildDate>Sun, 02 Mar 2025 10:00:00 +0000</lastBuildDate>
+    <item>
+      <title>Pylestras &lt;3</title>
+      <link>https://site-grupy.vercel.app/galeria#1</link>
+      <description>São Carlos</description>
+      <guid isPermaLink="false">tag:grupysanca.com.br,2025:galery/1</guid>
+      <pubDate>Sat, 01 Mar 2025 10:00:00 +0000</pubDate>
+      <enclosure url="https://storage.example.com/1.jpg" type="image/jpeg" length="1234"></enclosure>
+    </item>
+    <item>
+      <title>Sprint</title>
+      <guid isPermaLink="false">tag:grupysanca.com.br,2025:galery/2</guid>
+      <pubDate>Sat, 01 Feb 2025 10:00:00 +0000</pubDate>
+    </item>
+  </channel>
+</rss>`
+
+	assert.Equal(t, want, buf.String())
+}
+
+func TestEncode_EmptyFeed(t *testing.T) {
+	empty := Feed{ID: "tag:grupysanca.com.br,2025:feeds/events", Title: "Eventos", Updated: time.Unix(0, 0)}
+
+	for name, encode := range map[string]func(*bytes.Buffer, Feed) error{
+		"atom": func(b *bytes.Buffer, f Feed) error { return EncodeAtom(b, f) },
+		"rss":  func(b *bytes.Buffer, f Feed) error { return EncodeRSS(b, f) },
+	} {
+		t.Run(name, func(t *testing.T) {
+			var buf bytes.Buffer
+			require.NoError(t, encode(&buf, empty))
+
+			// Still a well-formed document
+			var doc struct{ XMLName xml.Name }
+			require.NoError(t, xml.Unmarshal(buf.Bytes(), &doc))
+			assert.NotContains(t, buf.String(), "<entry>")
+			assert.NotContains(t, buf.String(), "<item>")
+		})
+	}
+}
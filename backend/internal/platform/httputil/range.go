@@ -0,0 +1,71 @@
+package httputil
+
+import (
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ErrUnsatisfiableRange is returned for ranges outside the content
+var ErrUnsatisfiableRange = errors.New("range not satisfiable")
+
+// ByteRange is an inclusive range of bytes of a response body
+type ByteRange struct {
+	Start int64
+	End   int64
+}
+
+// Length returns the number of bytes in the range
+func (r ByteRange) Length() int64 {
+	return r.End - r.Start + 1
+}
+
+// ContentRange formats the range as a Content-Range header value
+func (r ByteRange) ContentRange(size int64) string {
+	return fmt.Sprintf("bytes %d-%d/%d", r.Start, r.End, size)
+}
+
+// ParseRange parses a Range header for a body of the given size
+// Only single ranges are supported ("bytes=0-99", "bytes=100-" and "bytes=-100"), ok is false when the
+// header is absent or asks for several ranges, in which case the full body should be served
+func ParseRange(header string, size int64) (r ByteRange, ok bool, err error) {
+	if header == "" {
+		return ByteRange{}, false, nil
+	}
+
+	spec, found := strings.CutPrefix(header, "bytes=")
+	if !found || strings.Contains(spec, ",") {
+		return ByteRange{}, false, nil
+	}
+
+	startStr, endStr, found := strings.Cut(strings.TrimSpace(spec), "-")
+	if !found {
+		return ByteRange{}, false, ErrUnsatisfiableRange
+	}
+
+	if startStr == "" {
+		// Suffix range: the last N bytes
+		suffix, err := strconv.ParseInt(endStr, 10, 64)
+		if err != nil || suffix <= 0 {
+			return ByteRange{}, false, ErrUnsatisfiableRange
+		}
+		return ByteRange{Start: max(0, size-suffix), End: size - 1}, true, nil
+	}
+
+	start, err := strconv.ParseInt(startStr, 10, 64)
+	if err != nil || start < 0 || start >= size {
+		return ByteRange{}, false, ErrUnsatisfiableRange
+	}
+
+	end := size - 1
+	if endStr != "" {
+		end, err = strconv.ParseInt(endStr, 10, 64)
+		if err != nil || end < start {
+			return ByteRange{}, false, ErrUnsatisfiableRange
+		}
+		end = min(end, size-1)
+	}
+
+	return ByteRange{Start: start, End: end}, true, nil
+}
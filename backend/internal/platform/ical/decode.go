@@ -0,0 +1,238 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+)
+
+const (
+	// _dateFormat parses DATE values, used by all-day events
+	_dateFormat = "20060102"
+
+	// _maxDecodedLine bounds an unfolded content line, larger lines are rejected
+	_maxDecodedLine = 1 << 20
+)
+
+// property is a content line split into its name, parameters and value
+type property struct {
+	name   string
+	params map[string]string
+	value  string
+}
+
+// Decode reads the events of an RFC 5545 iCalendar stream
+// Times with a TZID are read in that zone, floating times and dates in the X-WR-TIMEZONE of the
+// calendar or UTC. Unknown zones fall back to UTC. Components nested in events, e.g. VALARM, are skipped
+func Decode(r io.Reader) (Calendar, error) {
+	var (
+		cal     Calendar
+		event   *Event
+		depth   int  // Components open inside the current event
+		allDay  bool // The current event starts at a DATE, it lasts the whole day
+		seen    bool
+		defLoc  = time.UTC
+		lineNum int
+	)
+
+	lines, err := unfold(r)
+	if err != nil {
+		return Calendar{}, err
+	}
+
+	for _, line := range lines {
+		lineNum++
+		if line == "" {
+			continue
+		}
+
+		prop, err := parseProperty(line)
+		if err != nil {
+			return Calendar{}, fmt.Errorf("content line %d: %w", lineNum, err)
+		}
+
+		switch {
+		case prop.name == "BEGIN" && prop.value == "VCALENDAR":
+			seen = true
+		case prop.name == "BEGIN" && prop.value == "VEVENT" && event == nil:
+			event, allDay = &Event{}, false
+		case prop.name == "BEGIN" && event != nil:
+			depth++
+		case prop.name == "END" && event != nil && depth > 0:
+			depth--
+		case prop.name == "END" && prop.value == "VEVENT" && event != nil:
+			// Without DTEND an all-day event ends the next day, other events end when they start
+			if event.End.IsZero() && allDay {
+				event.End = event.Start.AddDate(0, 0, 1)
+			} else if event.End.IsZero() {
+				event.End = event.Start
+			}
+			cal.Events = append(cal.Events, *event)
+			event = nil
+		case event != nil && depth == 0:
+			if err := event.set(prop, defLoc); err != nil {
+				return Calendar{}, fmt.Errorf("content line %d: %w", lineNum, err)
+			}
+			if prop.name == "DTSTART" {
+				allDay = isDate(prop)
+			}
+		case event == nil:
+			switch prop.name {
+			case "PRODID":
+				cal.ProdID = prop.value
+			case "X-WR-CALNAME":
+				cal.Name = unescapeText(prop.value)
+			case "X-WR-TIMEZONE":
+				defLoc = locationOrUTC(prop.value)
+			}
+		}
+	}
+
+	if !seen {
+		return Calendar{}, fmt.Errorf("not an iCalendar stream, VCALENDAR is missing")
+	}
+	return cal, nil
+}
+
+// set stores an event property, properties the Event doesn't model are ignored
+func (e *Event) set(prop property, defLoc *time.Location) error {
+	var err error
+
+	switch prop.name {
+	case "UID":
+		e.UID = prop.value
+	case "DTSTAMP":
+		e.Stamp, err = parseDateTime(prop, defLoc)
+	case "DTSTART":
+		e.Start, err = parseDateTime(prop, defLoc)
+	case "DTEND":
+		e.End, err = parseDateTime(prop, defLoc)
+	case "SUMMARY":
+		e.Summary = unescapeText(prop.value)
+	case "DESCRIPTION":
+		e.Description = unescapeText(prop.value)
+	case "LOCATION":
+		e.Location = unescapeText(prop.value)
+	case "URL":
+		e.URL = prop.value
+	}
+
+	if err != nil {
+		return fmt.Errorf("invalid %s: %w", prop.name, err)
+	}
+	return nil
+}
+
+// unfold reads the content lines of a stream, joining folded lines
+// Both CRLF and bare LF line endings are accepted
+func unfold(r io.Reader) ([]string, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), _maxDecodedLine)
+
+	var lines []string
+	for scanner.Scan() {
+		line := strings.TrimSuffix(scanner.Text(), "\r")
+		if (strings.HasPrefix(line, " ") || strings.HasPrefix(line, "\t")) && len(lines) > 0 {
+			lines[len(lines)-1] += line[1:]
+			continue
+		}
+		lines = append(lines, line)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read calendar: %w", err)
+	}
+	return lines, nil
+}
+
+// parseProperty splits a content line, e.g. "DTSTART;TZID=America/Sao_Paulo:20250315T190000"
+// Quoted parameter values may contain ":" and ";"
+func parseProperty(line string) (property, error) {
+	prop := property{params: make(map[string]string)}
+
+	i := strings.IndexAny(line, ";:")
+	if i <= 0 {
+		return property{}, fmt.Errorf("malformed content line %q", line)
+	}
+	prop.name = strings.ToUpper(line[:i])
+
+	for line[i] == ';' {
+		rest := line[i+1:]
+		eq := strings.IndexByte(rest, '=')
+		if eq < 0 {
+			return property{}, fmt.Errorf("malformed parameter in %q", line)
+		}
+		key := strings.ToUpper(rest[:eq])
+		rest = rest[eq+1:]
+
+		var value string
+		if strings.HasPrefix(rest, `"`) {
+			end := strings.IndexByte(rest[1:], '"')
+			if end < 0 {
+				return property{}, fmt.Errorf("unterminated quoted parameter in %q", line)
+			}
+			value = rest[1 : end+1]
+			rest = rest[end+2:]
+		} else {
+			end := strings.IndexAny(rest, ";:")
+			if end < 0 {
+				return property{}, fmt.Errorf("missing value in %q", line)
+			}
+			value, rest = rest[:end], rest[end:]
+		}
+		prop.params[key] = value
+
+		i = len(line) - len(rest)
+		if i >= len(line) {
+			return property{}, fmt.Errorf("missing value in %q", line)
+		}
+	}
+
+	prop.value = line[i+1:]
+	return prop, nil
+}
+
+// parseDateTime parses a DATE or DATE-TIME property value
+func parseDateTime(prop property, defLoc *time.Location) (time.Time, error) {
+	loc := defLoc
+	if tzid, ok := prop.params["TZID"]; ok {
+		loc = locationOrUTC(tzid)
+	}
+
+	switch {
+	case isDate(prop):
+		return time.ParseInLocation(_dateFormat, prop.value, loc)
+	case strings.HasSuffix(prop.value, "Z"):
+		return time.Parse(_utcFormat, prop.value)
+	default:
+		return time.ParseInLocation(_localFormat, prop.value, loc)
+	}
+}
+
+// isDate reports whether a property holds a DATE rather than a DATE-TIME
+func isDate(prop property) bool {
+	return prop.params["VALUE"] == "DATE" || len(prop.value) == len(_dateFormat)
+}
+
+// locationOrUTC loads an IANA zone, UTC when the name is unknown (e.g. Windows zone names)
+func locationOrUTC(name string) *time.Location {
+	loc, err := time.LoadLocation(strings.Trim(name, "/"))
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// textUnescaper reverses escapeText
+var textUnescaper = strings.NewReplacer(
+	`\\`, `\`,
+	`\;`, ";",
+	`\,`, ",",
+	`\n`, "\n",
+	`\N`, "\n",
+)
+
+func unescapeText(s string) string {
+	return textUnescaper.Replace(s)
+}
@@ -0,0 +1,124 @@
+package ical
+
+import (
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode(t *testing.T) {
+	saoPaulo := loadLocation(t, "America/Sao_Paulo")
+
+	stream := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Python Brasil//Events//PT",
+		"X-WR-CALNAME:Python Brasil",
+		"X-WR-TIMEZONE:America/Sao_Paulo",
+		"BEGIN:VEVENT",
+		"UID:pybr-2025@python.org.br",
+		"DTSTAMP:20250110T120000Z",
+		"DTSTART;TZID=America/Sao_Paulo:20251021T090000",
+		"DTEND;TZID=\"America/Sao_Paulo\":20251021T180000",
+		`SUMMARY:Python Brasil\; 2025\, dia 1`,
+		"DESCRIPTION:Palestras e tutoriais sobre Python para toda a comunidade bra",
+		" sileira\\nInscrições abertas",
+		"LOCATION:São Paulo",
+		"URL:https://2025.pythonbrasil.org.br",
+		"BEGIN:VALARM",
+		"ACTION:DISPLAY",
+		"DESCRIPTION:Reminder",
+		"END:VALARM",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:sprint@python.org.br",
+		"DTSTART;VALUE=DATE:20251025",
+		"SUMMARY:Sprint",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:floating@python.org.br",
+		"DTSTART:20251101T190000",
+		"DTEND:20251101T220000Z",
+		"SUMMARY:Meetup",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	cal, err := Decode(strings.NewReader(stream))
+	require.NoError(t, err)
+
+	assert.Equal(t, "-//Python Brasil//Events//PT", cal.ProdID)
+	assert.Equal(t, "Python Brasil", cal.Name)
+	require.Len(t, cal.Events, 3)
+
+	conference := cal.Events[0]
+	assert.Equal(t, "pybr-2025@python.org.br", conference.UID)
+	assert.True(t, conference.Stamp.Equal(time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)))
+	assert.True(t, conference.Start.Equal(time.Date(2025, 10, 21, 9, 0, 0, 0, saoPaulo)))
+	assert.Equal(t, "America/Sao_Paulo", conference.Start.Location().String())
+	assert.True(t, conference.End.Equal(time.Date(2025, 10, 21, 18, 0, 0, 0, saoPaulo)))
+	assert.Equal(t, "Python Brasil; 2025, dia 1", conference.Summary)
+	assert.Equal(t, "Palestras e tutoriais sobre Python para toda a comunidade brasileira\nInscrições abertas", conference.Description, "Folded lines are joined and the VALARM description is skipped")
+	assert.Equal(t, "São Paulo", conference.Location)
+	assert.Equal(t, "https://2025.pythonbrasil.org.br", conference.URL)
+
+	// All-day events without an end last the whole day, in the calendar time zone
+	sprint := cal.Events[1]
+	assert.True(t, sprint.Start.Equal(time.Date(2025, 10, 25, 0, 0, 0, 0, saoPaulo)))
+	assert.True(t, sprint.End.Equal(time.Date(2025, 10, 26, 0, 0, 0, 0, saoPaulo)))
+
+	meetup := cal.Events[2]
+	assert.True(t, meetup.Start.Equal(time.Date(2025, 11, 1, 19, 0, 0, 0, saoPaulo)), "Floating times are read in X-WR-TIMEZONE")
+	assert.True(t, meetup.End.Equal(time.Date(2025, 11, 1, 22, 0, 0, 0, time.UTC)))
+}
+
+func TestDecode_RoundTrip(t *testing.T) {
+	saoPaulo := loadLocation(t, "America/Sao_Paulo")
+	want := Calendar{
+		ProdID: "-//Grupy Sanca//Events//PT",
+		Name:   "Grupy Sanca",
+		Events: []Event{{
+			UID:         "b8324ae2@eventos.grupysanca.com.br",
+			Stamp:       time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC),
+			Start:       time.Date(2025, 3, 15, 19, 0, 0, 0, saoPaulo),
+			End:         time.Date(2025, 3, 15, 22, 0, 0, 0, saoPaulo),
+			Summary:     "Pylestras; edição 1, 2025",
+			Description: strings.Repeat("Programação em Python\n", 10),
+			Location:    "ICMC - USP",
+			URL:         "https://eventos.grupysanca.com.br/e/42",
+		}},
+	}
+
+	got, err := Decode(strings.NewReader(encode(t, want)))
+	require.NoError(t, err)
+	require.Len(t, got.Events, 1)
+
+	event := got.Events[0]
+	assert.Equal(t, want.ProdID, got.ProdID)
+	assert.Equal(t, want.Name, got.Name)
+	assert.Equal(t, want.Events[0].UID, event.UID)
+	assert.True(t, want.Events[0].Start.Equal(event.Start))
+	assert.True(t, want.Events[0].End.Equal(event.End))
+	assert.Equal(t, want.Events[0].Summary, event.Summary)
+	assert.Equal(t, want.Events[0].Description, event.Description)
+	assert.Equal(t, want.Events[0].URL, event.URL)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	tests := map[string]string{
+		"not a calendar": "hello\r\n",
+		"malformed date": "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART:2025-03-15\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+		"no value":       "BEGIN:VCALENDAR\r\nBEGIN:VEVENT\r\nDTSTART;TZID=UTC\r\nEND:VEVENT\r\nEND:VCALENDAR\r\n",
+	}
+
+	for name, stream := range tests {
+		t.Run(name, func(t *testing.T) {
+			_, err := Decode(strings.NewReader(stream))
+			assert.Error(t, err)
+		})
+	}
+}
@@ -0,0 +1,259 @@
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	// Embed the time zone database, TZID handling must not depend on the host having one
+	_ "time/tzdata"
+)
+
+const (
+	// _maxLineOctets is the longest content line allowed before folding (RFC 5545 section 3.1)
+	_maxLineOctets = 75
+
+	// _localFormat formats DATE-TIME values in local time, the TZID parameter gives the zone
+	_localFormat = "20060102T150405"
+	// _utcFormat formats DATE-TIME values in UTC
+	_utcFormat = "20060102T150405Z"
+)
+
+// Calendar is an iCalendar object publishing events
+type Calendar struct {
+	ProdID          string        // Identifies the product that created the calendar
+	Name            string        // Display name used by calendar clients
+	RefreshInterval time.Duration // How often subscribers should refresh the feed, zero leaves it to clients
+	Events          []Event
+}
+
+// Event is a VEVENT component
+// Start and End are written in their location, with a VTIMEZONE describing it. UTC times are written as UTC
+type Event struct {
+	UID         string // Globally unique and stable across feeds, e.g. "b8324ae2@eventos.grupysanca.com.br"
+	Stamp       time.Time
+	Start       time.Time
+	End         time.Time
+	Summary     string
+	Description string
+	Location    string
+	URL         string
+}
+
+// Encode writes cal as an RFC 5545 iCalendar stream
+func Encode(w io.Writer, cal Calendar) error {
+	enc := &encoder{w: bufio.NewWriter(w)}
+
+	enc.line("BEGIN:VCALENDAR")
+	enc.line("VERSION:2.0")
+	enc.line("PRODID:" + cal.ProdID)
+	enc.line("CALSCALE:GREGORIAN")
+	enc.line("METHOD:PUBLISH")
+	if cal.Name != "" {
+		enc.line("X-WR-CALNAME:" + escapeText(cal.Name))
+	}
+	if cal.RefreshInterval > 0 {
+		duration := formatDuration(cal.RefreshInterval)
+		enc.line("REFRESH-INTERVAL;VALUE=DURATION:" + duration)
+		enc.line("X-PUBLISHED-TTL:" + duration)
+	}
+
+	for _, tz := range timezones(cal.Events) {
+		enc.timezone(tz)
+	}
+	for _, event := range cal.Events {
+		enc.event(event)
+	}
+
+	enc.line("END:VCALENDAR")
+
+	if enc.err != nil {
+		return enc.err
+	}
+	return enc.w.Flush()
+}
+
+// encoder writes content lines, keeping the first error
+type encoder struct {
+	w   *bufio.Writer
+	err error
+}
+
+// line writes a content line, folding it so no physical line is longer than 75 octets
+// Folds never split a UTF-8 sequence
+func (e *encoder) line(s string) {
+	if e.err != nil {
+		return
+	}
+
+	limit := _maxLineOctets
+	for len(s) > limit {
+		cut := limit
+		for cut > 0 && !utf8.RuneStart(s[cut]) {
+			cut--
+		}
+		if _, e.err = e.w.WriteString(s[:cut] + "\r\n "); e.err != nil {
+			return
+		}
+		s = s[cut:]
+
+		// Continuation lines start with the folding space
+		limit = _maxLineOctets - 1
+	}
+	_, e.err = e.w.WriteString(s + "\r\n")
+}
+
+// event writes a VEVENT component
+func (e *encoder) event(event Event) {
+	e.line("BEGIN:VEVENT")
+	e.line("UID:" + escapeText(event.UID))
+	e.line("DTSTAMP:" + event.Stamp.UTC().Format(_utcFormat))
+	e.line("DTSTART" + formatDateTime(event.Start))
+	if !event.End.IsZero() {
+		e.line("DTEND" + formatDateTime(event.End))
+	}
+	e.line("SUMMARY:" + escapeText(event.Summary))
+	if event.Description != "" {
+		e.line("DESCRIPTION:" + escapeText(event.Description))
+	}
+	if event.Location != "" {
+		e.line("LOCATION:" + escapeText(event.Location))
+	}
+	if event.URL != "" {
+		e.line("URL:" + event.URL)
+	}
+	e.line("END:VEVENT")
+}
+
+// timezoneSpan is a zone referenced by events and the period it must be described for
+type timezoneSpan struct {
+	loc      *time.Location
+	from, to time.Time
+}
+
+// timezones lists the zones referenced by events in order of first use
+func timezones(events []Event) []timezoneSpan {
+	var spans []timezoneSpan
+	index := make(map[string]int)
+
+	for _, event := range events {
+		for _, t := range []time.Time{event.Start, event.End} {
+			if t.IsZero() || t.Location() == time.UTC {
+				continue
+			}
+
+			name := t.Location().String()
+			i, ok := index[name]
+			if !ok {
+				index[name] = len(spans)
+				spans = append(spans, timezoneSpan{loc: t.Location(), from: t, to: t})
+				continue
+			}
+			if t.Before(spans[i].from) {
+				spans[i].from = t
+			}
+			if t.After(spans[i].to) {
+				spans[i].to = t
+			}
+		}
+	}
+
+	return spans
+}
+
+// timezone writes a VTIMEZONE component with an observance for every offset the zone
+// uses between the first and the last time referencing it
+func (e *encoder) timezone(tz timezoneSpan) {
+	e.line("BEGIN:VTIMEZONE")
+	e.line("TZID:" + tz.loc.String())
+
+	t := tz.from.In(tz.loc)
+	for {
+		name, offset := t.Zone()
+		start, end := t.ZoneBounds()
+
+		// Offset in effect before this observance began, the zone start is written in it
+		offsetFrom := offset
+		if start.IsZero() {
+			start = time.Date(1970, 1, 1, 0, 0, 0, 0, time.UTC).Add(-time.Duration(offset) * time.Second)
+		} else {
+			_, offsetFrom = start.Add(-time.Second).Zone()
+		}
+
+		kind := "STANDARD"
+		if t.IsDST() {
+			kind = "DAYLIGHT"
+		}
+
+		e.line("BEGIN:" + kind)
+		e.line("DTSTART:" + start.UTC().Add(time.Duration(offsetFrom)*time.Second).Format(_localFormat))
+		e.line("TZOFFSETFROM:" + formatOffset(offsetFrom))
+		e.line("TZOFFSETTO:" + formatOffset(offset))
+		e.line("TZNAME:" + escapeText(name))
+		e.line("END:" + kind)
+
+		if end.IsZero() || end.After(tz.to) {
+			break
+		}
+		t = end
+	}
+
+	e.line("END:VTIMEZONE")
+}
+
+// formatDateTime formats a DATE-TIME property value with its parameters, starting with ";" or ":"
+func formatDateTime(t time.Time) string {
+	if t.Location() == time.UTC {
+		return ":" + t.Format(_utcFormat)
+	}
+	return ";TZID=" + t.Location().String() + ":" + t.Format(_localFormat)
+}
+
+// formatOffset formats a UTC offset in seconds as a UTC-OFFSET value, e.g. "-0300"
+func formatOffset(seconds int) string {
+	sign := "+"
+	if seconds < 0 {
+		sign = "-"
+		seconds = -seconds
+	}
+
+	s := fmt.Sprintf("%s%02d%02d", sign, seconds/3600, seconds/60%60)
+	if seconds%60 != 0 {
+		s += fmt.Sprintf("%02d", seconds%60)
+	}
+	return s
+}
+
+// formatDuration formats a positive duration as a DURATION value, e.g. "PT12H"
+func formatDuration(d time.Duration) string {
+	d = d.Round(time.Second)
+
+	s := "PT"
+	if h := int(d.Hours()); h > 0 {
+		s += fmt.Sprintf("%dH", h)
+	}
+	if m := int(d.Minutes()) % 60; m > 0 {
+		s += fmt.Sprintf("%dM", m)
+	}
+	if sec := int(d.Seconds()) % 60; sec > 0 || s == "PT" {
+		s += fmt.Sprintf("%dS", sec)
+	}
+	return s
+}
+
+// textEscaper escapes TEXT values (RFC 5545 section 3.3.11)
+var textEscaper = strings.NewReplacer(
+	`\`, `\\`,
+	";", `\;`,
+	",", `\,`,
+	"\r\n", `\n`,
+	"\n", `\n`,
+	"\r", `\n`,
+)
+
+func escapeText(s string) string {
+	return textEscaper.Replace(s)
+}
@@ -0,0 +1,163 @@
+package ical
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+	"unicode/utf8"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func loadLocation(t *testing.T, name string) *time.Location {
+	loc, err := time.LoadLocation(name)
+	require.NoError(t, err)
+	return loc
+}
+
+func encode(t *testing.T, cal Calendar) string {
+	var buf bytes.Buffer
+	require.NoError(t, Encode(&buf, cal))
+	return buf.String()
+}
+
+func TestEncode(t *testing.T) {
+	saoPaulo := loadLocation(t, "America/Sao_Paulo")
+	stamp := time.Date(2025, 1, 10, 12, 0, 0, 0, time.UTC)
+
+	got := encode(t, Calendar{
+		ProdID:          "-//Grupy Sanca//Events//PT",
+		Name:            "Grupy Sanca",
+		RefreshInterval: 12 * time.Hour,
+		Events: []Event{
+			{
+				UID:         "b8324ae2@eventos.grupysanca.com.br",
+				Stamp:       stamp,
+				Start:       time.Date(2025, 3, 15, 19, 0, 0, 0, saoPaulo),
+				End:         time.Date(2025, 3, 15, 22, 0, 0, 0, saoPaulo),
+				Summary:     "Pylestras; edição 1, 2025",
+				Description: "Palestras\nsobre Python",
+				Location:    "ICMC - USP",
+				URL:         "https://eventos.grupysanca.com.br/e/42",
+			},
+			{
+				UID:     "c1@eventos.grupysanca.com.br",
+				Stamp:   stamp,
+				Start:   time.Date(2025, 4, 1, 12, 0, 0, 0, time.UTC),
+				Summary: "Online",
+			},
+		},
+	})
+
+	want := strings.Join([]string{
+		"BEGIN:VCALENDAR",
+		"VERSION:2.0",
+		"PRODID:-//Grupy Sanca//Events//PT",
+		"CALSCALE:GREGORIAN",
+		"METHOD:PUBLISH",
+		"X-WR-CALNAME:Grupy Sanca",
+		"REFRESH-INTERVAL;VALUE=DURATION:PT12H",
+		"X-PUBLISHED-TTL:PT12H",
+		"BEGIN:VTIMEZONE",
+		"TZID:America/Sao_Paulo",
+		"BEGIN:STANDARD",
+		"DTSTART:20190217T000000",
+		"TZOFFSETFROM:-0200",
+		"TZOFFSETTO:-0300",
+		"TZNAME:-03",
+		"END:STANDARD",
+		"END:VTIMEZONE",
+		"BEGIN:VEVENT",
+		"UID:b8324ae2@eventos.grupysanca.com.br",
+		"DTSTAMP:20250110T120000Z",
+		"DTSTART;TZID=America/Sao_Paulo:20250315T190000",
+		"DTEND;TZID=America/Sao_Paulo:20250315T220000",
+		`SUMMARY:Pylestras\; edição 1\, 2025`,
+		`DESCRIPTION:Palestras\nsobre Python`,
+		"LOCATION:ICMC - USP",
+		"URL:https://eventos.grupysanca.com.br/e/42",
+		"END:VEVENT",
+		"BEGIN:VEVENT",
+		"UID:c1@eventos.grupysanca.com.br",
+		"DTSTAMP:20250110T120000Z",
+		"DTSTART:20250401T120000Z",
+		"SUMMARY:Online",
+		"END:VEVENT",
+		"END:VCALENDAR",
+		"",
+	}, "\r\n")
+
+	assert.Equal(t, want, got)
+}
+
+func TestEncode_DaylightSavingTime(t *testing.T) {
+	berlin := loadLocation(t, "Europe/Berlin")
+
+	// A winter and a summer event, the zone switches to and back from summer time in between
+	got := encode(t, Calendar{
+		ProdID: "-//Test//Test//EN",
+		Events: []Event{
+			{UID: "winter", Start: time.Date(2025, 3, 1, 19, 0, 0, 0, berlin), Summary: "Winter"},
+			{UID: "summer", Start: time.Date(2025, 7, 1, 19, 0, 0, 0, berlin), Summary: "Summer"},
+		},
+	})
+
+	assert.Contains(t, got, strings.Join([]string{
+		"BEGIN:VTIMEZONE",
+		"TZID:Europe/Berlin",
+		"BEGIN:STANDARD",
+		"DTSTART:20241027T030000",
+		"TZOFFSETFROM:+0200",
+		"TZOFFSETTO:+0100",
+		"TZNAME:CET",
+		"END:STANDARD",
+		"BEGIN:DAYLIGHT",
+		"DTSTART:20250330T020000",
+		"TZOFFSETFROM:+0100",
+		"TZOFFSETTO:+0200",
+		"TZNAME:CEST",
+		"END:DAYLIGHT",
+		"END:VTIMEZONE",
+	}, "\r\n"))
+	assert.Equal(t, 1, strings.Count(got, "BEGIN:VTIMEZONE"), "one VTIMEZONE per zone")
+	assert.Contains(t, got, "DTSTART;TZID=Europe/Berlin:20250701T190000\r\n")
+}
+
+func TestEncode_FoldsLongLines(t *testing.T) {
+	description := strings.Repeat("Programação em Python ", 20)
+
+	got := encode(t, Calendar{
+		ProdID: "-//Test//Test//EN",
+		Events: []Event{{UID: "long", Summary: "Long", Description: description}},
+	})
+
+	lines := strings.Split(strings.TrimSuffix(got, "\r\n"), "\r\n")
+	var unfolded strings.Builder
+	for _, line := range lines {
+		assert.LessOrEqual(t, len(line), 75, "line %q is longer than 75 octets", line)
+		assert.True(t, utf8.ValidString(line), "line %q splits a UTF-8 sequence", line)
+
+		if strings.HasPrefix(line, " ") {
+			unfolded.WriteString(line[1:])
+		} else {
+			unfolded.WriteString("\n" + line)
+		}
+	}
+
+	assert.Contains(t, unfolded.String(), "\nDESCRIPTION:"+description+"\n")
+}
+
+func TestFormatOffset(t *testing.T) {
+	assert.Equal(t, "-0300", formatOffset(-3*3600))
+	assert.Equal(t, "+0530", formatOffset(5*3600+30*60))
+	assert.Equal(t, "+0000", formatOffset(0))
+	assert.Equal(t, "-001215", formatOffset(-(12*60 + 15)))
+}
+
+func TestFormatDuration(t *testing.T) {
+	assert.Equal(t, "PT12H", formatDuration(12*time.Hour))
+	assert.Equal(t, "PT1H30M", formatDuration(90*time.Minute))
+	assert.Equal(t, "PT45S", formatDuration(45*time.Second))
+}
@@ -0,0 +1,166 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"fmt"
+	"image"
+	"image/color"
+	"image/jpeg"
+
+	// Register the decoders for the formats accepted by the image endpoints
+	_ "image/gif"
+	_ "image/png"
+)
+
+const (
+	// _analysisSize is the longest side of the thumbnail BlurHash and dominant color are computed from
+	_analysisSize = 32
+	// _lqipSize is the longest side of the inlined low quality preview
+	_lqipSize = 16
+	// _lqipQuality is the JPEG quality of the inlined low quality preview
+	_lqipQuality = 50
+	// _maxPixels bounds the images decoded, a small compressed file can declare enormous dimensions
+	_maxPixels = 40_000_000
+)
+
+// Placeholder holds the lightweight previews shown while an image loads
+type Placeholder struct {
+	BlurHash      string // BlurHash string, see https://blurha.sh
+	LQIP          string // Tiny JPEG preview as a base64 data URI
+	DominantColor string // Hex color, e.g. "#a1b2c3"
+}
+
+// ComputePlaceholder decodes an image and computes its BlurHash, LQIP and dominant color
+// JPEG, PNG and GIF are supported, other formats return image.ErrFormat.
+// Images of more than _maxPixels pixels are refused before being decoded
+func ComputePlaceholder(data []byte) (Placeholder, error) {
+	config, _, err := image.DecodeConfig(bytes.NewReader(data))
+	if err != nil {
+		return Placeholder{}, fmt.Errorf("failed to decode image: %w", err)
+	}
+	if pixels := int64(config.Width) * int64(config.Height); pixels > _maxPixels {
+		return Placeholder{}, fmt.Errorf("image has %dx%d pixels, more than %d", config.Width, config.Height, _maxPixels)
+	}
+
+	img, _, err := image.Decode(bytes.NewReader(data))
+	if err != nil {
+		return Placeholder{}, fmt.Errorf("failed to decode image: %w", err)
+	}
+
+	bounds := img.Bounds()
+	if bounds.Empty() {
+		return Placeholder{}, fmt.Errorf("image has no pixels")
+	}
+
+	thumb := downscale(img, _analysisSize)
+
+	xComponents, yComponents := 4, 3
+	if bounds.Dy() > bounds.Dx() {
+		xComponents, yComponents = 3, 4
+	}
+
+	lqip, err := encodeLQIP(downscale(img, _lqipSize))
+	if err != nil {
+		return Placeholder{}, err
+	}
+
+	return Placeholder{
+		BlurHash:      encodeBlurHash(thumb, xComponents, yComponents),
+		LQIP:          lqip,
+		DominantColor: dominantColor(thumb),
+	}, nil
+}
+
+// downscale shrinks img so its longest side is at most maxSide, averaging the covered source pixels
+// Large sources are sampled on a grid instead of visiting every pixel
+func downscale(img image.Image, maxSide int) *image.RGBA {
+	bounds := img.Bounds()
+	srcW, srcH := bounds.Dx(), bounds.Dy()
+
+	dstW, dstH := srcW, srcH
+	if srcW >= srcH && srcW > maxSide {
+		dstW, dstH = maxSide, max(1, srcH*maxSide/srcW)
+	} else if srcH > srcW && srcH > maxSide {
+		dstW, dstH = max(1, srcW*maxSide/srcH), maxSide
+	}
+
+	const maxSamplesPerAxis = 8
+
+	dst := image.NewRGBA(image.Rect(0, 0, dstW, dstH))
+	for y := 0; y < dstH; y++ {
+		y0, y1 := bounds.Min.Y+y*srcH/dstH, bounds.Min.Y+(y+1)*srcH/dstH
+		stepY := max(1, (y1-y0)/maxSamplesPerAxis)
+
+		for x := 0; x < dstW; x++ {
+			x0, x1 := bounds.Min.X+x*srcW/dstW, bounds.Min.X+(x+1)*srcW/dstW
+			stepX := max(1, (x1-x0)/maxSamplesPerAxis)
+
+			var r, g, b, a, n uint64
+			for sy := y0; sy < max(y1, y0+1); sy += stepY {
+				for sx := x0; sx < max(x1, x0+1); sx += stepX {
+					c := color.NRGBAModel.Convert(img.At(sx, sy)).(color.NRGBA)
+					r += uint64(c.R)
+					g += uint64(c.G)
+					b += uint64(c.B)
+					a += uint64(c.A)
+					n++
+				}
+			}
+
+			dst.Set(x, y, color.NRGBA{R: uint8(r / n), G: uint8(g / n), B: uint8(b / n), A: uint8(a / n)})
+		}
+	}
+
+	return dst
+}
+
+// encodeLQIP encodes a thumbnail as a JPEG data URI
+func encodeLQIP(thumb *image.RGBA) (string, error) {
+	var buf bytes.Buffer
+	if err := jpeg.Encode(&buf, thumb, &jpeg.Options{Quality: _lqipQuality}); err != nil {
+		return "", fmt.Errorf("failed to encode preview: %w", err)
+	}
+	return "data:image/jpeg;base64," + base64.StdEncoding.EncodeToString(buf.Bytes()), nil
+}
+
+// dominantColor returns the average color of the most populated bucket of a coarse color histogram
+// Fully transparent pixels are ignored
+func dominantColor(thumb *image.RGBA) string {
+	type bucket struct {
+		r, g, b, n int
+	}
+	buckets := make(map[int]*bucket)
+
+	var best *bucket
+	bounds := thumb.Bounds()
+	for y := bounds.Min.Y; y < bounds.Max.Y; y++ {
+		for x := bounds.Min.X; x < bounds.Max.X; x++ {
+			c := color.NRGBAModel.Convert(thumb.At(x, y)).(color.NRGBA)
+			if c.A == 0 {
+				continue
+			}
+
+			// 4 bits per channel is enough to tell colors apart without splitting gradients
+			key := int(c.R>>4)<<8 | int(c.G>>4)<<4 | int(c.B>>4)
+			bk, ok := buckets[key]
+			if !ok {
+				bk = &bucket{}
+				buckets[key] = bk
+			}
+			bk.r += int(c.R)
+			bk.g += int(c.G)
+			bk.b += int(c.B)
+			bk.n++
+
+			if best == nil || bk.n > best.n {
+				best = bk
+			}
+		}
+	}
+
+	if best == nil {
+		return "#000000"
+	}
+	return fmt.Sprintf("#%02x%02x%02x", best.r/best.n, best.g/best.n, best.b/best.n)
+}
@@ -0,0 +1,109 @@
+package imaging
+
+import (
+	"bytes"
+	"encoding/base64"
+	"image"
+	"image/color"
+	"image/png"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// tinyPNG is a 1x1 red pixel PNG in base64
+const tinyPNG = "iVBORw0KGgoAAAANSUhEUgAAAAEAAAABCAYAAAAfFcSJAAAADUlEQVR42mP8z8DwHwAFBQIAX8jx0gAAAABJRU5ErkJggg=="
+
+func encodePNG(t *testing.T, img image.Image) []byte {
+	var buf bytes.Buffer
+	require.NoError(t, png.Encode(&buf, img))
+	return buf.Bytes()
+}
+
+func TestComputePlaceholder(t *testing.T) {
+	tinyData, err := base64.StdEncoding.DecodeString(tinyPNG)
+	require.NoError(t, err)
+
+	landscape := image.NewNRGBA(image.Rect(0, 0, 200, 100))
+	portrait := image.NewNRGBA(image.Rect(0, 0, 100, 200))
+	for y := 0; y < 200; y++ {
+		for x := 0; x < 200; x++ {
+			// Mostly blue with a white stripe
+			c := color.NRGBA{R: 0x20, G: 0x40, B: 0xc0, A: 0xff}
+			if x < 20 {
+				c = color.NRGBA{R: 0xff, G: 0xff, B: 0xff, A: 0xff}
+			}
+			landscape.Set(x, y, c)
+			portrait.Set(x, y, c)
+		}
+	}
+
+	tests := []struct {
+		name          string
+		data          []byte
+		expectError   bool
+		sizeFlag      string // First BlurHash character, encodes the component count
+		hashLength    int
+		dominantColor string
+	}{
+		{
+			name:          "single red pixel",
+			data:          tinyData,
+			sizeFlag:      "L", // 4x3 components
+			hashLength:    28,
+			dominantColor: "#ff0000",
+		},
+		{
+			name:          "landscape image",
+			data:          encodePNG(t, landscape),
+			sizeFlag:      "L", // 4x3 components
+			hashLength:    28,
+			dominantColor: "#2040c0",
+		},
+		{
+			name:          "portrait image",
+			data:          encodePNG(t, portrait),
+			sizeFlag:      "T", // 3x4 components
+			hashLength:    28,
+			dominantColor: "#2040c0",
+		},
+		{
+			name:        "decompression bomb",
+			data:        []byte("GIF89a\xff\xff\xff\xff\x00\x00\x00"), // Declares 65535x65535 pixels
+			expectError: true,
+		},
+		{
+			name:        "not an image",
+			data:        []byte("definitely not an image"),
+			expectError: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			placeholder, err := ComputePlaceholder(tt.data)
+			if tt.expectError {
+				assert.Error(t, err)
+				return
+			}
+
+			require.NoError(t, err)
+			assert.Len(t, placeholder.BlurHash, tt.hashLength)
+			assert.True(t, strings.HasPrefix(placeholder.BlurHash, tt.sizeFlag), "unexpected size flag in %s", placeholder.BlurHash)
+			assert.True(t, strings.HasPrefix(placeholder.LQIP, "data:image/jpeg;base64,"))
+			assert.Equal(t, tt.dominantColor, placeholder.DominantColor)
+		})
+	}
+}
+
+func TestEncodeBlurHash_SolidColor(t *testing.T) {
+	img := image.NewRGBA(image.Rect(0, 0, 1, 1))
+	img.Set(0, 0, color.RGBA{R: 0xff, A: 0xff})
+
+	// The DC component holds the average color, pure red encodes as "TI:j"
+	hash := encodeBlurHash(img, 4, 3)
+	assert.Len(t, hash, 28)
+	assert.Equal(t, "TI:j", hash[2:6])
+}
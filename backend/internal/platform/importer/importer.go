@@ -0,0 +1,267 @@
+// Package importer reads the records of bulk imports written by hand in spreadsheets and docs
+//
+// Every format yields the same flat records: field names are lowercase and values are strings,
+// each record remembers the line it starts on so validation errors can point at it.
+//   - CSV: the first row names the fields, every other row is a record
+//   - YAML: a list of mappings, lists of scalars are joined with commas
+//   - Markdown: records open with a front matter between --- lines, the text that follows is the
+//     record body. Bodies can't hold --- lines, *** draws horizontal rules instead
+package importer
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strings"
+
+	"gopkg.in/yaml.v3"
+)
+
+// Format is the encoding of an import file
+type Format string
+
+const (
+	CSV      Format = "csv"
+	YAML     Format = "yaml"
+	Markdown Format = "markdown"
+)
+
+// _frontMatterDelimiter opens and closes the front matter of a Markdown record
+const _frontMatterDelimiter = "---"
+
+// Record is a row, a YAML item or a Markdown document of an import file
+type Record struct {
+	Line   int               // Line of the file where the record starts, 1-based
+	Fields map[string]string // Values by lowercase field name
+	Body   string            // Markdown text after the front matter, empty for other formats
+}
+
+// Get returns the trimmed value of a field, empty when missing
+func (r Record) Get(field string) string {
+	return strings.TrimSpace(r.Fields[field])
+}
+
+// ParseFormat reads a format name, "yml" and "md" are accepted as well
+func ParseFormat(name string) (Format, error) {
+	switch strings.ToLower(strings.TrimSpace(name)) {
+	case "csv":
+		return CSV, nil
+	case "yaml", "yml":
+		return YAML, nil
+	case "markdown", "md":
+		return Markdown, nil
+	}
+	return "", fmt.Errorf("unknown import format %q, expected csv, yaml or markdown", name)
+}
+
+// FormatFromFilename detects the format of a file by its extension
+func FormatFromFilename(name string) (Format, error) {
+	return ParseFormat(strings.TrimPrefix(filepath.Ext(name), "."))
+}
+
+// FormatFromContentType detects the format of a request body by its media type
+func FormatFromContentType(contentType string) (Format, error) {
+	mediaType, _, _ := strings.Cut(contentType, ";")
+	switch strings.ToLower(strings.TrimSpace(mediaType)) {
+	case "text/csv":
+		return CSV, nil
+	case "application/yaml", "application/x-yaml", "text/yaml", "text/x-yaml":
+		return YAML, nil
+	case "text/markdown", "text/x-markdown":
+		return Markdown, nil
+	}
+	return "", fmt.Errorf("unknown import content type %q", contentType)
+}
+
+// Decode reads the records of an import file
+// Errors point at the line of the file that couldn't be read
+func Decode(format Format, r io.Reader) ([]Record, error) {
+	switch format {
+	case CSV:
+		return decodeCSV(r)
+	case YAML:
+		return decodeYAML(r)
+	case Markdown:
+		return decodeMarkdown(r)
+	}
+	return nil, fmt.Errorf("unknown import format %q", format)
+}
+
+// decodeCSV reads a header row naming the fields followed by one record per row
+func decodeCSV(r io.Reader) ([]Record, error) {
+	reader := csv.NewReader(r)
+	reader.TrimLeadingSpace = true
+
+	header, err := reader.Read()
+	if errors.Is(err, io.EOF) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to read csv header: %w", err)
+	}
+
+	names := make([]string, len(header))
+	for i, name := range header {
+		names[i] = normalizeField(strings.TrimPrefix(name, "\ufeff")) // Spreadsheets may start with a byte order mark
+	}
+
+	var records []Record
+	for {
+		row, err := reader.Read()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("failed to read csv: %w", err)
+		}
+
+		line, _ := reader.FieldPos(0)
+		record := Record{Line: line, Fields: make(map[string]string, len(row))}
+		for i, value := range row {
+			if names[i] != "" {
+				record.Fields[names[i]] = value
+			}
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// decodeYAML reads a list of mappings, one record per item
+func decodeYAML(r io.Reader) ([]Record, error) {
+	var doc yaml.Node
+	if err := yaml.NewDecoder(r).Decode(&doc); err != nil {
+		if errors.Is(err, io.EOF) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("failed to parse yaml: %w", err)
+	}
+
+	root := &doc
+	if root.Kind == yaml.DocumentNode && len(root.Content) > 0 {
+		root = root.Content[0]
+	}
+	if root.Kind != yaml.SequenceNode {
+		return nil, fmt.Errorf("line %d: yaml import must be a list of items", root.Line)
+	}
+
+	records := make([]Record, 0, len(root.Content))
+	for _, item := range root.Content {
+		record, err := yamlRecord(item)
+		if err != nil {
+			return nil, err
+		}
+		records = append(records, record)
+	}
+	return records, nil
+}
+
+// yamlRecord reads the fields of a YAML mapping
+func yamlRecord(node *yaml.Node) (Record, error) {
+	if node.Kind != yaml.MappingNode {
+		return Record{}, fmt.Errorf("line %d: yaml import items must be mappings", node.Line)
+	}
+
+	record := Record{Line: node.Line, Fields: make(map[string]string, len(node.Content)/2)}
+	for i := 0; i+1 < len(node.Content); i += 2 {
+		key, value := node.Content[i], node.Content[i+1]
+
+		switch value.Kind {
+		case yaml.ScalarNode:
+			record.Fields[normalizeField(key.Value)] = value.Value
+		case yaml.SequenceNode:
+			values := make([]string, 0, len(value.Content))
+			for _, item := range value.Content {
+				if item.Kind != yaml.ScalarNode {
+					return Record{}, fmt.Errorf("line %d: field %s can only list plain values", item.Line, key.Value)
+				}
+				values = append(values, item.Value)
+			}
+			record.Fields[normalizeField(key.Value)] = strings.Join(values, ",")
+		default:
+			return Record{}, fmt.Errorf("line %d: field %s must be a plain value or a list", value.Line, key.Value)
+		}
+	}
+	return record, nil
+}
+
+// decodeMarkdown reads documents made of a front matter followed by their body
+func decodeMarkdown(r io.Reader) ([]Record, error) {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1<<20)
+
+	var (
+		records     []Record
+		current     *Record
+		frontMatter bytes.Buffer
+		body        strings.Builder
+		inFront     bool
+		lineNum     int
+	)
+
+	finish := func() {
+		if current != nil {
+			current.Body = strings.TrimSpace(body.String())
+			records = append(records, *current)
+			current = nil
+		}
+	}
+
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), " \t\r")
+
+		switch {
+		case line == _frontMatterDelimiter && inFront:
+			var fields yaml.Node
+			if err := yaml.Unmarshal(frontMatter.Bytes(), &fields); err != nil {
+				return nil, fmt.Errorf("line %d: failed to parse front matter: %w", current.Line, err)
+			}
+			if len(fields.Content) > 0 {
+				parsed, err := yamlRecord(fields.Content[0])
+				if err != nil {
+					return nil, fmt.Errorf("front matter of line %d: %w", current.Line, err)
+				}
+				current.Fields = parsed.Fields
+			}
+			inFront = false
+
+		case line == _frontMatterDelimiter:
+			finish()
+			current = &Record{Line: lineNum, Fields: map[string]string{}}
+			frontMatter.Reset()
+			body.Reset()
+			inFront = true
+
+		case inFront:
+			frontMatter.WriteString(line)
+			frontMatter.WriteByte('\n')
+
+		case current != nil:
+			body.WriteString(line)
+			body.WriteByte('\n')
+
+		case strings.TrimSpace(line) != "":
+			return nil, fmt.Errorf("line %d: markdown import must start with a front matter", lineNum)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("failed to read markdown: %w", err)
+	}
+	if inFront {
+		return nil, fmt.Errorf("line %d: front matter is never closed", current.Line)
+	}
+
+	finish()
+	return records, nil
+}
+
+// normalizeField lowercases a field name, spaces and dashes become underscores
+func normalizeField(name string) string {
+	name = strings.ToLower(strings.TrimSpace(name))
+	return strings.NewReplacer(" ", "_", "-", "_").Replace(name)
+}
@@ -0,0 +1,116 @@
+package importer
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDecode_CSV(t *testing.T) {
+	file := "\ufeffName,Date,Location,Text\n" +
+		"Primeiro encontro,2015-03-14,ICMC,\"Fundação do grupo,\nno ICMC\"\n" +
+		"PyLadies,2018-03-08,UFSCar,Primeiro PyLadies\n"
+
+	records, err := Decode(CSV, strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, 2, records[0].Line)
+	assert.Equal(t, "Primeiro encontro", records[0].Get("name"))
+	assert.Equal(t, "Fundação do grupo,\nno ICMC", records[0].Get("text"))
+
+	assert.Equal(t, 4, records[1].Line, "Quoted line breaks move the next row down")
+	assert.Equal(t, "UFSCar", records[1].Get("location"))
+	assert.Empty(t, records[1].Body)
+}
+
+func TestDecode_YAML(t *testing.T) {
+	file := strings.Join([]string{
+		"- slug: sobre",
+		"  page-slug: home",
+		"  content: |",
+		"    Somos o grupo de Python de São Carlos",
+		"- slug: contato",
+		"  tags: [a, b]",
+	}, "\n")
+
+	records, err := Decode(YAML, strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, 1, records[0].Line)
+	assert.Equal(t, "home", records[0].Get("page_slug"), "Field names are normalized")
+	assert.Equal(t, "Somos o grupo de Python de São Carlos", records[0].Get("content"))
+
+	assert.Equal(t, 5, records[1].Line)
+	assert.Equal(t, "a,b", records[1].Get("tags"))
+}
+
+func TestDecode_Markdown(t *testing.T) {
+	file := strings.Join([]string{
+		"---",
+		"name: Python Brasil em São Carlos",
+		"date: 2019-10-23",
+		"---",
+		"# Python Brasil",
+		"",
+		"***",
+		"Cinco dias de evento.",
+		"---",
+		"name: Grupy 10 anos",
+		"date: 2025-03-14",
+		"---",
+	}, "\n")
+
+	records, err := Decode(Markdown, strings.NewReader(file))
+	require.NoError(t, err)
+	require.Len(t, records, 2)
+
+	assert.Equal(t, 1, records[0].Line)
+	assert.Equal(t, "Python Brasil em São Carlos", records[0].Get("name"))
+	assert.Equal(t, "# Python Brasil\n\n***\nCinco dias de evento.", records[0].Body)
+
+	assert.Equal(t, 9, records[1].Line)
+	assert.Equal(t, "2025-03-14", records[1].Get("date"))
+	assert.Empty(t, records[1].Body)
+}
+
+func TestDecode_Errors(t *testing.T) {
+	for name, tc := range map[string]struct {
+		format Format
+		file   string
+		line   string
+	}{
+		"csv with a short row":       {CSV, "name,date\nonly-name\n", "line 2"},
+		"yaml mapping":               {YAML, "name: not a list\n", "line 1"},
+		"yaml nested item":           {YAML, "- name: ok\n- links:\n    title: nested\n", "line 3"},
+		"markdown without front":     {Markdown, "just text\n", "line 1"},
+		"markdown open front matter": {Markdown, "text: ok\n---\nname: x\n", "line 1"},
+		"markdown unclosed":          {Markdown, "---\nname: x\n", "line 1"},
+	} {
+		t.Run(name, func(t *testing.T) {
+			_, err := Decode(tc.format, strings.NewReader(tc.file))
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tc.line)
+		})
+	}
+}
+
+func TestFormatDetection(t *testing.T) {
+	format, err := FormatFromFilename("historia/marcos.yml")
+	require.NoError(t, err)
+	assert.Equal(t, YAML, format)
+
+	format, err = FormatFromContentType("text/markdown; charset=utf-8")
+	require.NoError(t, err)
+	assert.Equal(t, Markdown, format)
+
+	format, err = ParseFormat("CSV")
+	require.NoError(t, err)
+	assert.Equal(t, CSV, format)
+
+	_, err = FormatFromFilename("planilha.xlsx")
+	assert.Error(t, err)
+}
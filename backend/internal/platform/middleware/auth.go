@@ -1,72 +1,79 @@
 package middleware
 
 import (
+	"context"
 	"errors"
+	"fmt"
 	"log"
 	"net/http"
 	"strings"
 
 	authcfg "backend/internal/platform/auth"
+	customerrors "backend/internal/platform/errors"
+	"backend/internal/platform/httputil"
 )
 
 // Middleware is a func which takes in an http request and returns it and an error
 type Middleware func(*http.Request) (*http.Request, error)
 
 // NewAuthMiddlewareFunc wraps an HTTP handler and checks if the request to the endpoint is authorized given the current auth config
-func NewAuthMiddlewareFunc(nextHandle func(w http.ResponseWriter, r *http.Request), authCfg authcfg.AuthConfig, logger *log.Logger) func(w http.ResponseWriter, r *http.Request) {
-	if authCfg.Client == nil {
+// A token sent with the request is always verified and the role read from it must grant permission, other callers get 401 or 403
+// Requests without a token are only served under the optional level, and never for the permissions only admins are granted
+func NewAuthMiddlewareFunc(nextHandle func(w http.ResponseWriter, r *http.Request), permission authcfg.Permission, authCfg authcfg.AuthConfig, logger *log.Logger) func(w http.ResponseWriter, r *http.Request) {
+	if authCfg.Verifier == nil {
+		// Authentication is disabled, every request is trusted
 		return func(w http.ResponseWriter, r *http.Request) {
-			nextHandle(w, r)
+			nextHandle(w, r.WithContext(trustedContext(r.Context())))
 		}
 	}
 
-	switch authCfg.Level {
+	tokenRequired := authCfg.Level == authcfg.AuthRequired || permission.AdminOnly()
 
-	case authcfg.AuthRequired:
-		return func(w http.ResponseWriter, r *http.Request) {
-			if r == nil {
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
-				return
-			}
+	return func(w http.ResponseWriter, r *http.Request) {
+		if r == nil {
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
+		}
 
-			// log token extraction and enforce it
-			idToken, err := getIdToken(r)
-			if err != nil {
-				logTokenNotFound(logger, r, err)
+		// log token extraction, anonymous callers are only served when the token is optional
+		idToken, err := getIdToken(r)
+		if err != nil {
+			logTokenNotFound(logger, r, err)
+			if tokenRequired {
 				http.Error(w, "Unauthorized", http.StatusUnauthorized)
 				return
 			}
+			nextHandle(w, r)
+			return
+		}
 
-			logTokenFound(logger, r, idToken)
-
-			// Verify the token
-			_, err = authCfg.Client.VerifyIDToken(r.Context(), idToken)
-			if err != nil {
-				logTokenVerificationFailed(logger, r, idToken, err)
-				http.Error(w, "Unauthorized", http.StatusUnauthorized)
-				return
-			}
+		logTokenFound(logger, r, idToken)
 
-			nextHandle(w, r)
+		// Verify the token
+		token, err := authCfg.Verifier.VerifyIDToken(r.Context(), idToken)
+		if err != nil {
+			logTokenVerificationFailed(logger, r, idToken, err)
+			http.Error(w, "Unauthorized", http.StatusUnauthorized)
+			return
 		}
-	default:
-		return func(w http.ResponseWriter, r *http.Request) {
-			// log token extraction but do not enforce it
-			idToken, err := getIdToken(r)
-			if err != nil {
-				logTokenNotFound(logger, r, err)
-			} else {
-				logTokenFound(logger, r, idToken)
-			}
-			nextHandle(w, r)
+
+		// Check the role of the caller
+		role := authcfg.RoleFromClaims(token.Claims)
+		if !role.Can(permission) {
+			logPermissionDenied(logger, r, token.UID, role, permission)
+			httputil.ErrorFromDomain(w, fmt.Errorf("%w: role %s doesn't grant %s", customerrors.ErrForbidden, role, permission))
+			return
 		}
+
+		nextHandle(w, r.WithContext(authenticatedContext(r.Context(), token)))
 	}
 }
 
 func NewForceAuthMiddlewareFunc(nextHandle func(w http.ResponseWriter, r *http.Request), authCfg authcfg.AuthConfig, logger *log.Logger) func(w http.ResponseWriter, r *http.Request) {
-	if authCfg.Client == nil {
+	if authCfg.Verifier == nil {
+		// Authentication is disabled, every request is trusted
 		return func(w http.ResponseWriter, r *http.Request) {
-			nextHandle(w, r)
+			nextHandle(w, r.WithContext(trustedContext(r.Context())))
 		}
 	}
 
@@ -87,17 +94,61 @@ func NewForceAuthMiddlewareFunc(nextHandle func(w http.ResponseWriter, r *http.R
 		logTokenFound(logger, r, idToken)
 
 		// Verify the token
-		_, err = authCfg.Client.VerifyIDToken(r.Context(), idToken)
+		token, err := authCfg.Verifier.VerifyIDToken(r.Context(), idToken)
 		if err != nil {
 			logTokenVerificationFailed(logger, r, idToken, err)
 			http.Error(w, "Unauthorized", http.StatusUnauthorized)
 			return
 		}
 
-		nextHandle(w, r)
+		nextHandle(w, r.WithContext(authenticatedContext(r.Context(), token)))
+	}
+}
+
+// NewIdentifyMiddlewareFunc wraps a public HTTP handler and marks the request as authenticated if it carries a valid token
+// Requests without a token or with an invalid one are still served, as anonymous readers
+func NewIdentifyMiddlewareFunc(nextHandle func(w http.ResponseWriter, r *http.Request), authCfg authcfg.AuthConfig, logger *log.Logger) func(w http.ResponseWriter, r *http.Request) {
+	if authCfg.Verifier == nil {
+		// Authentication is disabled, every request is trusted
+		return func(w http.ResponseWriter, r *http.Request) {
+			nextHandle(w, r.WithContext(trustedContext(r.Context())))
+		}
+	}
+
+	return func(w http.ResponseWriter, r *http.Request) {
+		idToken, err := getIdToken(r)
+		if err != nil {
+			// Anonymous reader, nothing to log
+			nextHandle(w, r)
+			return
+		}
+
+		token, err := authCfg.Verifier.VerifyIDToken(r.Context(), idToken)
+		if err != nil {
+			logTokenVerificationFailed(logger, r, idToken, err)
+			nextHandle(w, r)
+			return
+		}
+
+		nextHandle(w, r.WithContext(authenticatedContext(r.Context(), token)))
 	}
 }
 
+// authenticatedContext stores the principal of a verified token in the context
+func authenticatedContext(ctx context.Context, token *authcfg.Token) context.Context {
+	email, _ := token.Claims["email"].(string)
+	return authcfg.ContextWithPrincipal(ctx, authcfg.Principal{
+		UID:   token.UID,
+		Email: email,
+		Role:  authcfg.RoleFromClaims(token.Claims),
+	})
+}
+
+// trustedContext marks the context of a request served while authentication is disabled, as an anonymous admin
+func trustedContext(ctx context.Context) context.Context {
+	return authcfg.ContextWithPrincipal(ctx, authcfg.Principal{Role: authcfg.RoleAdmin})
+}
+
 // getRequestOrigin gets the origin from the request (Origin header or RemoteAddr)
 func getRequestOrigin(r *http.Request) string {
 	origin := r.Header.Get("Origin")
@@ -147,6 +198,16 @@ func logTokenVerificationFailed(logger *log.Logger, r *http.Request, idToken str
 	)
 }
 
+// logPermissionDenied logs when the role of a verified caller doesn't grant the permission of a route
+func logPermissionDenied(logger *log.Logger, r *http.Request, uid string, role authcfg.Role, permission authcfg.Permission) {
+	logger.Printf("[AUTH] Permission denied - origin=%s uid=%s role=%s permission=%s",
+		getRequestOrigin(r),
+		uid,
+		role,
+		permission,
+	)
+}
+
 func getIdToken(r *http.Request) (string, error) {
 	authHeader := r.Header.Get("Authorization")
 	if !strings.HasPrefix(authHeader, "Bearer ") {
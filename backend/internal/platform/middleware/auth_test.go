@@ -0,0 +1,147 @@
+package middleware
+
+import (
+	"context"
+	"io"
+	"log"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	authcfg "backend/internal/platform/auth"
+)
+
+// serve calls handler with a request carrying idToken, none when it is empty
+func serve(handler func(w http.ResponseWriter, r *http.Request), idToken string) *httptest.ResponseRecorder {
+	r := httptest.NewRequest(http.MethodPost, "/api/v1/texts", nil)
+	if idToken != "" {
+		r.Header.Set("Authorization", "Bearer "+idToken)
+	}
+	w := httptest.NewRecorder()
+	handler(w, r)
+	return w
+}
+
+// recordPrincipal is a handler saving the principal of the requests it serves
+func recordPrincipal(principal *authcfg.Principal, authenticated *bool) func(w http.ResponseWriter, r *http.Request) {
+	return func(w http.ResponseWriter, r *http.Request) {
+		*principal, *authenticated = authcfg.PrincipalFromContext(r.Context())
+		w.WriteHeader(http.StatusNoContent)
+	}
+}
+
+func TestAuthMiddleware_Required(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	authCfg := authcfg.AuthConfig{Verifier: authcfg.NewFakeVerifier(), Level: authcfg.AuthRequired}
+
+	var principal authcfg.Principal
+	var authenticated bool
+	handler := NewAuthMiddlewareFunc(recordPrincipal(&principal, &authenticated), authcfg.PermissionEditTexts, authCfg, logger)
+
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "").Code, "Requests without a token are refused")
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "forged").Code, "Invalid tokens are refused")
+	assert.Equal(t, http.StatusForbidden, serve(handler, authcfg.FakeToken("k3C9dX2vQbT7", authcfg.RolePhotographer)).Code)
+	assert.False(t, authenticated)
+
+	assert.Equal(t, http.StatusNoContent, serve(handler, authcfg.FakeToken("k3C9dX2vQbT7", authcfg.RoleEditor)).Code)
+	assert.True(t, authenticated)
+	assert.Equal(t, authcfg.Principal{UID: "k3C9dX2vQbT7", Role: authcfg.RoleEditor}, principal)
+}
+
+func TestAuthMiddleware_Optional(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	authCfg := authcfg.AuthConfig{Verifier: authcfg.NewFakeVerifier(), Level: authcfg.AuthOptional}
+
+	var principal authcfg.Principal
+	var authenticated bool
+	handler := NewAuthMiddlewareFunc(recordPrincipal(&principal, &authenticated), authcfg.PermissionEditTexts, authCfg, logger)
+
+	assert.Equal(t, http.StatusNoContent, serve(handler, "").Code, "Anonymous callers are served")
+	assert.False(t, authenticated)
+
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "forged").Code, "Tokens are verified when sent")
+	assert.Equal(t, http.StatusForbidden, serve(handler, authcfg.FakeToken("k3C9dX2vQbT7", authcfg.RoleViewer)).Code, "Roles are enforced when a token is sent")
+
+	assert.Equal(t, http.StatusNoContent, serve(handler, authcfg.FakeToken("k3C9dX2vQbT7", authcfg.RoleEditor)).Code)
+	assert.True(t, authenticated)
+	assert.Equal(t, authcfg.Principal{UID: "k3C9dX2vQbT7", Role: authcfg.RoleEditor}, principal)
+}
+
+func TestAuthMiddleware_OptionalAdminOnly(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	authCfg := authcfg.AuthConfig{Verifier: authcfg.NewFakeVerifier(), Level: authcfg.AuthOptional}
+
+	var principal authcfg.Principal
+	var authenticated bool
+	handler := NewAuthMiddlewareFunc(recordPrincipal(&principal, &authenticated), authcfg.PermissionManageUsers, authCfg, logger)
+
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "").Code, "Admin routes always require a token")
+	assert.Equal(t, http.StatusForbidden, serve(handler, authcfg.FakeToken("k3C9dX2vQbT7", authcfg.RoleEditor)).Code)
+	assert.False(t, authenticated)
+
+	assert.Equal(t, http.StatusNoContent, serve(handler, authcfg.FakeToken("k3C9dX2vQbT7", authcfg.RoleAdmin)).Code)
+	assert.True(t, authenticated)
+}
+
+func TestAuthMiddleware_Disabled(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+
+	var principal authcfg.Principal
+	var authenticated bool
+	handler := NewAuthMiddlewareFunc(recordPrincipal(&principal, &authenticated), authcfg.PermissionManageUsers, authcfg.AuthConfig{Level: authcfg.AuthRequired}, logger)
+
+	assert.Equal(t, http.StatusNoContent, serve(handler, "").Code)
+	assert.True(t, authenticated)
+	assert.Equal(t, authcfg.Principal{Role: authcfg.RoleAdmin}, principal, "Without a verifier every request is trusted")
+}
+
+func TestForceAuthMiddleware(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	authCfg := authcfg.AuthConfig{Verifier: authcfg.NewFakeVerifier(), Level: authcfg.AuthOptional}
+
+	var principal authcfg.Principal
+	var authenticated bool
+	handler := NewForceAuthMiddlewareFunc(recordPrincipal(&principal, &authenticated), authCfg, logger)
+
+	assert.Equal(t, http.StatusUnauthorized, serve(handler, "").Code, "Tokens are required whatever the level")
+	assert.Equal(t, http.StatusNoContent, serve(handler, authcfg.FakeToken("k3C9dX2vQbT7", "")).Code, "Any role is accepted")
+	assert.Equal(t, authcfg.RoleViewer, principal.Role)
+}
+
+func TestIdentifyMiddleware(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	authCfg := authcfg.AuthConfig{Verifier: authcfg.NewFakeVerifier(), Level: authcfg.AuthRequired}
+
+	var principal authcfg.Principal
+	var authenticated bool
+	handler := NewIdentifyMiddlewareFunc(recordPrincipal(&principal, &authenticated), authCfg, logger)
+
+	assert.Equal(t, http.StatusNoContent, serve(handler, "forged").Code, "Invalid tokens are served as anonymous readers")
+	assert.False(t, authenticated)
+
+	assert.Equal(t, http.StatusNoContent, serve(handler, authcfg.FakeToken("k3C9dX2vQbT7", authcfg.RoleViewer)).Code)
+	assert.True(t, authenticated)
+	assert.Equal(t, "k3C9dX2vQbT7", principal.UID)
+}
+
+// emailVerifier accepts any token as the one of a user with an email
+type emailVerifier struct{}
+
+func (emailVerifier) VerifyIDToken(ctx context.Context, idToken string) (*authcfg.Token, error) {
+	return &authcfg.Token{UID: "k3C9dX2vQbT7", Claims: map[string]any{"email": "ana@grupysanca.com.br", authcfg.RoleClaim: "editor"}}, nil
+}
+
+func TestAuthMiddleware_OptionalStoresPrincipal(t *testing.T) {
+	logger := log.New(io.Discard, "", 0)
+	authCfg := authcfg.AuthConfig{Verifier: emailVerifier{}, Level: authcfg.AuthOptional}
+
+	var principal authcfg.Principal
+	var authenticated bool
+	handler := NewAuthMiddlewareFunc(recordPrincipal(&principal, &authenticated), authcfg.PermissionEditTimeline, authCfg, logger)
+
+	assert.Equal(t, http.StatusNoContent, serve(handler, "any").Code)
+	assert.True(t, authenticated, "Mutations are credited to the callers sending a token")
+	assert.Equal(t, authcfg.Principal{UID: "k3C9dX2vQbT7", Email: "ana@grupysanca.com.br", Role: authcfg.RoleEditor}, principal)
+}
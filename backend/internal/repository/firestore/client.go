@@ -11,10 +11,17 @@ import (
 
 // CollectionNames holds the names of Firestore collections
 type CollectionNames struct {
-	Texts           string
-	Images          string
-	TimelineEntries string
-	GaleryEvents    string
+	Texts              string
+	Images             string
+	TimelineEntries    string
+	GaleryEvents       string
+	Jobs               string
+	EventWatch         string
+	DeadLetters        string
+	AuditLog           string
+	Leases             string
+	Uploads            string
+	TimelineTombstones string
 }
 
 // FirestoreConfig holds configuration for Firestore client initialization
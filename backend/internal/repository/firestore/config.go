@@ -44,10 +44,17 @@ func NewFirestoreClientWithProvider(ctx context.Context, provider FirebaseConfig
 		ProjectID:       fbConfig.ProjectID,
 		CredentialsJSON: credentialsJSON,
 		Collections: CollectionNames{
-			Texts:           collections.Texts,
-			Images:          collections.Images,
-			TimelineEntries: collections.Timelines,
-			GaleryEvents:    collections.GaleryEvents,
+			Texts:              collections.Texts,
+			Images:             collections.Images,
+			TimelineEntries:    collections.Timelines,
+			GaleryEvents:       collections.GaleryEvents,
+			Jobs:               collections.Jobs,
+			EventWatch:         collections.EventWatch,
+			DeadLetters:        collections.DeadLetters,
+			AuditLog:           collections.AuditLog,
+			Leases:             collections.Leases,
+			Uploads:            collections.Uploads,
+			TimelineTombstones: collections.TimelineTombstones,
 		},
 	}
 
@@ -71,10 +78,17 @@ func NewDBRepositoryWithProvider(ctx context.Context, provider FirebaseConfigPro
 
 	// Convert Collections to CollectionNames
 	collectionNames := CollectionNames{
-		Texts:           collections.Texts,
-		Images:          collections.Images,
-		TimelineEntries: collections.Timelines,
-		GaleryEvents:    collections.GaleryEvents,
+		Texts:              collections.Texts,
+		Images:             collections.Images,
+		TimelineEntries:    collections.Timelines,
+		GaleryEvents:       collections.GaleryEvents,
+		Jobs:               collections.Jobs,
+		EventWatch:         collections.EventWatch,
+		DeadLetters:        collections.DeadLetters,
+		AuditLog:           collections.AuditLog,
+		Leases:             collections.Leases,
+		Uploads:            collections.Uploads,
+		TimelineTombstones: collections.TimelineTombstones,
 	}
 
 	// Create and return DB repository
@@ -3,6 +3,7 @@ package firestore
 import (
 	"context"
 	"fmt"
+	"slices"
 	"time"
 
 	"cloud.google.com/go/firestore"
@@ -182,6 +183,16 @@ func (r *DBRepository) GetImagesBySlug(ctx context.Context, slug string) ([]enti
 	return r.imagesFromIterator(iter)
 }
 
+func (r *DBRepository) GetImagesBySHA256(ctx context.Context, hash string) ([]entities.Image, error) {
+	iter := r.client.Collection(r.collections.Images).Where("sha256", "==", hash).Documents(ctx)
+	return r.imagesFromIterator(iter)
+}
+
+func (r *DBRepository) GetImagesByTag(ctx context.Context, tag string) ([]entities.Image, error) {
+	iter := r.client.Collection(r.collections.Images).Where("tags", "array-contains", tag).Documents(ctx)
+	return r.imagesFromIterator(iter)
+}
+
 func (r *DBRepository) ListAllImages(ctx context.Context) ([]entities.Image, error) {
 	iter := r.client.Collection(r.collections.Images).OrderBy("createdAt", firestore.Desc).Documents(ctx)
 	return r.imagesFromIterator(iter)
@@ -236,6 +247,22 @@ func (r *DBRepository) UpdateImageMeta(ctx context.Context, id string, patch ent
 	if !patch.Date.IsZero() {
 		updates = append(updates, firestore.Update{Path: "date", Value: patch.Date})
 	}
+	if patch.SHA256 != "" {
+		updates = append(updates, firestore.Update{Path: "sha256", Value: patch.SHA256})
+	}
+	if patch.BlurHash != "" {
+		updates = append(updates, firestore.Update{Path: "blurHash", Value: patch.BlurHash})
+	}
+	if patch.LQIP != "" {
+		updates = append(updates, firestore.Update{Path: "lqip", Value: patch.LQIP})
+	}
+	if patch.DominantColor != "" {
+		updates = append(updates, firestore.Update{Path: "dominantColor", Value: patch.DominantColor})
+	}
+	if patch.Tags != nil {
+		// An empty, non-nil slice removes every tag
+		updates = append(updates, firestore.Update{Path: "tags", Value: patch.Tags})
+	}
 	if patch.LastUpdatedBy != "" {
 		updates = append(updates, firestore.Update{Path: "lastUpdatedBy", Value: patch.LastUpdatedBy})
 	}
@@ -258,6 +285,143 @@ func (r *DBRepository) DeleteImageMeta(ctx context.Context, id string) error {
 	return nil
 }
 
+func (r *DBRepository) SetImagePrivate(ctx context.Context, id string, private bool, by string) (entities.Image, error) {
+	docRef := r.client.Collection(r.collections.Images).Doc(id)
+
+	updates := []firestore.Update{
+		{Path: "updatedAt", Value: time.Now()},
+		{Path: "private", Value: private},
+	}
+	if by != "" {
+		updates = append(updates, firestore.Update{Path: "lastUpdatedBy", Value: by})
+	}
+
+	if _, err := docRef.Update(ctx, updates); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return entities.Image{}, fmt.Errorf("image with id %s not found: %w", id, customerrors.ErrNotFound)
+		}
+		return entities.Image{}, fmt.Errorf("error updating image visibility: %w", err)
+	}
+
+	return r.GetImageByID(ctx, id)
+}
+
+// AddImageTags adds tags to several images in batched writes
+// Either every image of a batch is tagged or none is, e.g. when one of them doesn't exist
+func (r *DBRepository) AddImageTags(ctx context.Context, imageIDs []string, tags []string, by string) error {
+	return r.updateImageTags(ctx, imageIDs, by, func(current []string) []string {
+		return append(slices.Clone(current), tags...)
+	})
+}
+
+// RemoveImageTags removes tags from several images in batched writes
+// Either every image of a batch is untagged or none is, e.g. when one of them doesn't exist
+func (r *DBRepository) RemoveImageTags(ctx context.Context, imageIDs []string, tags []string, by string) error {
+	return r.updateImageTags(ctx, imageIDs, by, func(current []string) []string {
+		return slices.DeleteFunc(slices.Clone(current), func(tag string) bool {
+			return slices.Contains(tags, tag)
+		})
+	})
+}
+
+// tagBatchSize bounds the images tagged together, the Firestore limit per commit
+const tagBatchSize = 500
+
+// updateImageTags rewrites the tags of several images, each batch in its own transaction
+// The tags are read inside the transaction and written back sorted and without repeats, as
+// stored by the other writes. A failed batch leaves the previous ones written
+func (r *DBRepository) updateImageTags(ctx context.Context, imageIDs []string, by string, change func(current []string) []string) error {
+	ids := slices.Clone(imageIDs)
+	slices.Sort(ids)
+	ids = slices.Compact(ids)
+
+	for start := 0; start < len(ids); start += tagBatchSize {
+		batch := ids[start:min(start+tagBatchSize, len(ids))]
+
+		refs := make([]*firestore.DocumentRef, len(batch))
+		for i, id := range batch {
+			refs[i] = r.client.Collection(r.collections.Images).Doc(id)
+		}
+
+		err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+			docs, err := tx.GetAll(refs)
+			if err != nil {
+				return fmt.Errorf("error fetching images: %w", err)
+			}
+
+			now := time.Now()
+			for _, doc := range docs {
+				if !doc.Exists() {
+					return fmt.Errorf("image with id %s not found: %w", doc.Ref.ID, customerrors.ErrNotFound)
+				}
+
+				var image entities.Image
+				if err := doc.DataTo(&image); err != nil {
+					return fmt.Errorf("error parsing image %s: %w", doc.Ref.ID, err)
+				}
+
+				tags := change(image.Tags)
+				slices.Sort(tags)
+				updates := []firestore.Update{
+					{Path: "tags", Value: slices.Compact(tags)},
+					{Path: "updatedAt", Value: now},
+				}
+				if by != "" {
+					updates = append(updates, firestore.Update{Path: "lastUpdatedBy", Value: by})
+				}
+
+				if err := tx.Update(doc.Ref, updates); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			if start == 0 {
+				return fmt.Errorf("error updating image tags, no image was updated: %w", err)
+			}
+			return fmt.Errorf("error updating image tags after %d of %d images were committed: %w", start, len(ids), err)
+		}
+	}
+	return nil
+}
+
+// getAllBatchSize bounds the documents read by a single GetAll call
+const getAllBatchSize = 100
+
+// GetImagesByIDs reads several images with batched lookups, in the order of ids
+// Images that don't exist are left out
+func (r *DBRepository) GetImagesByIDs(ctx context.Context, ids []string) ([]entities.Image, error) {
+	images := make([]entities.Image, 0, len(ids))
+	for start := 0; start < len(ids); start += getAllBatchSize {
+		batch := ids[start:min(start+getAllBatchSize, len(ids))]
+
+		refs := make([]*firestore.DocumentRef, len(batch))
+		for i, id := range batch {
+			refs[i] = r.client.Collection(r.collections.Images).Doc(id)
+		}
+
+		docs, err := r.client.GetAll(ctx, refs)
+		if err != nil {
+			return nil, fmt.Errorf("error fetching images: %w", err)
+		}
+
+		for _, doc := range docs {
+			if !doc.Exists() {
+				continue
+			}
+
+			var image entities.Image
+			if err := doc.DataTo(&image); err != nil {
+				continue // Skip malformed documents
+			}
+			image.ID = doc.Ref.ID
+			images = append(images, image)
+		}
+	}
+	return images, nil
+}
+
 // =======================
 // TIMELINE OPERATIONS
 // =======================
@@ -308,8 +472,10 @@ func (r *DBRepository) CreateTimelineEntry(ctx context.Context, entry entities.T
 func (r *DBRepository) UpdateTimelineEntry(ctx context.Context, id string, patch entities.TimelineEntry) (entities.TimelineEntry, error) {
 	docRef := r.client.Collection(r.collections.TimelineEntries).Doc(id)
 
-	// Update timestamp
-	patch.UpdatedAt = time.Now()
+	// Update timestamp, kept when given so the sync can match it with syncedAt
+	if patch.UpdatedAt.IsZero() {
+		patch.UpdatedAt = time.Now()
+	}
 
 	// Build update map
 	updates := []firestore.Update{
@@ -327,6 +493,21 @@ func (r *DBRepository) UpdateTimelineEntry(ctx context.Context, id string, patch
 	if !patch.Date.IsZero() {
 		updates = append(updates, firestore.Update{Path: "date", Value: patch.Date})
 	}
+	if patch.Category != "" {
+		updates = append(updates, firestore.Update{Path: "category", Value: patch.Category})
+	}
+	if patch.Importance != 0 {
+		updates = append(updates, firestore.Update{Path: "importance", Value: patch.Importance})
+	}
+	if patch.ImageIDs != nil {
+		updates = append(updates, firestore.Update{Path: "imageIds", Value: patch.ImageIDs})
+	}
+	if patch.Links != nil {
+		updates = append(updates, firestore.Update{Path: "links", Value: patch.Links})
+	}
+	if !patch.SyncedAt.IsZero() {
+		updates = append(updates, firestore.Update{Path: "syncedAt", Value: patch.SyncedAt})
+	}
 	if patch.LastUpdatedBy != "" {
 		updates = append(updates, firestore.Update{Path: "lastUpdatedBy", Value: patch.LastUpdatedBy})
 	}
@@ -342,6 +523,59 @@ func (r *DBRepository) UpdateTimelineEntry(ctx context.Context, id string, patch
 	return r.GetTimelineEntryByID(ctx, id)
 }
 
+// SetTimelineEntryDraft hides a timeline entry from anonymous readers or publishes it
+func (r *DBRepository) SetTimelineEntryDraft(ctx context.Context, id string, draft bool, by string) (entities.TimelineEntry, error) {
+	docRef := r.client.Collection(r.collections.TimelineEntries).Doc(id)
+
+	updates := []firestore.Update{
+		{Path: "updatedAt", Value: time.Now()},
+		{Path: "draft", Value: draft},
+	}
+	if by != "" {
+		updates = append(updates, firestore.Update{Path: "lastUpdatedBy", Value: by})
+	}
+
+	if _, err := docRef.Update(ctx, updates); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return entities.TimelineEntry{}, fmt.Errorf("timeline entry with id %s not found: %w", id, customerrors.ErrNotFound)
+		}
+		return entities.TimelineEntry{}, fmt.Errorf("error updating timeline entry draft: %w", err)
+	}
+
+	return r.GetTimelineEntryByID(ctx, id)
+}
+
+// RemoveTimelineImage drops an image from every timeline entry referencing it, in a single transaction
+// ListTimelineEntriesByImageID lists the timeline entries showing an image
+func (r *DBRepository) ListTimelineEntriesByImageID(ctx context.Context, imageID string) ([]entities.TimelineEntry, error) {
+	iter := r.client.Collection(r.collections.TimelineEntries).Where("imageIds", "array-contains", imageID).Documents(ctx)
+	return r.timelineEntriesFromIterator(iter)
+}
+
+func (r *DBRepository) RemoveTimelineImage(ctx context.Context, imageID string) error {
+	query := r.client.Collection(r.collections.TimelineEntries).Where("imageIds", "array-contains", imageID)
+
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		docs, err := tx.Documents(query).GetAll()
+		if err != nil {
+			return err
+		}
+
+		for _, doc := range docs {
+			if err := tx.Update(doc.Ref, []firestore.Update{
+				{Path: "imageIds", Value: firestore.ArrayRemove(imageID)},
+			}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		return fmt.Errorf("error removing image from timeline entries: %w", err)
+	}
+	return nil
+}
+
 func (r *DBRepository) DeleteTimelineEntry(ctx context.Context, id string) error {
 	if _, err := r.client.Collection(r.collections.TimelineEntries).Doc(id).Delete(ctx); err != nil {
 		return fmt.Errorf("error deleting timeline entry: %w", err)
@@ -349,6 +583,49 @@ func (r *DBRepository) DeleteTimelineEntry(ctx context.Context, id string) error
 	return nil
 }
 
+// DismissTimelineEntry deletes a generated timeline entry and records a tombstone for its source in one transaction
+func (r *DBRepository) DismissTimelineEntry(ctx context.Context, id string, tombstone entities.TimelineTombstone) error {
+	entryRef := r.client.Collection(r.collections.TimelineEntries).Doc(id)
+	tombstoneRef := r.client.Collection(r.collections.TimelineTombstones).Doc(tombstone.Source)
+
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		if err := tx.Set(tombstoneRef, tombstone); err != nil {
+			return err
+		}
+		return tx.Delete(entryRef)
+	})
+	if err != nil {
+		return fmt.Errorf("error dismissing timeline entry: %w", err)
+	}
+	return nil
+}
+
+// ListTimelineTombstones lists the sources of the generated timeline entries editors deleted
+func (r *DBRepository) ListTimelineTombstones(ctx context.Context) ([]entities.TimelineTombstone, error) {
+	iter := r.client.Collection(r.collections.TimelineTombstones).Documents(ctx)
+	defer iter.Stop()
+
+	var tombstones []entities.TimelineTombstone
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error iterating timeline tombstones: %w", err)
+		}
+
+		var tombstone entities.TimelineTombstone
+		if err := doc.DataTo(&tombstone); err != nil {
+			return nil, fmt.Errorf("error parsing timeline tombstone: %w", err)
+		}
+		tombstone.Source = doc.Ref.ID
+		tombstones = append(tombstones, tombstone)
+	}
+
+	return tombstones, nil
+}
+
 // =======================
 // HELPER METHODS
 // =======================
@@ -432,6 +709,7 @@ func (r *DBRepository) CreateGaleryEvent(ctx context.Context, event entities.Gal
 	if event.UpdatedAt.IsZero() {
 		event.UpdatedAt = time.Now()
 	}
+	event.SyncImageArrays()
 
 	// Create document
 	if _, err := docRef.Set(ctx, event); err != nil {
@@ -450,11 +728,10 @@ func (r *DBRepository) GetGaleryEventByID(ctx context.Context, id string) (entit
 		return entities.GaleryEvent{}, fmt.Errorf("error fetching galery event: %w", err)
 	}
 
-	var event entities.GaleryEvent
-	if err := doc.DataTo(&event); err != nil {
+	event, err := galeryEventFromDoc(doc)
+	if err != nil {
 		return entities.GaleryEvent{}, fmt.Errorf("error parsing galery event: %w", err)
 	}
-	event.ID = doc.Ref.ID
 	return event, nil
 }
 
@@ -463,6 +740,31 @@ func (r *DBRepository) ListGaleryEvents(ctx context.Context) ([]entities.GaleryE
 	return r.galeryEventsFromIterator(iter)
 }
 
+func (r *DBRepository) ListGaleryEventsByImageID(ctx context.Context, imageID string) ([]entities.GaleryEvent, error) {
+	iter := r.client.Collection(r.collections.GaleryEvents).Where("image_ids", "array-contains", imageID).Documents(ctx)
+	return r.galeryEventsFromIterator(iter)
+}
+
+// GetGaleryEventByGrupyEventID finds the galery event linked to a Grupy event
+func (r *DBRepository) GetGaleryEventByGrupyEventID(ctx context.Context, grupyEventID string) (entities.GaleryEvent, error) {
+	iter := r.client.Collection(r.collections.GaleryEvents).Where("grupy_event_id", "==", grupyEventID).Limit(1).Documents(ctx)
+	defer iter.Stop()
+
+	doc, err := iter.Next()
+	if err == iterator.Done {
+		return entities.GaleryEvent{}, fmt.Errorf("galery event for grupy event %s not found: %w", grupyEventID, customerrors.ErrNotFound)
+	}
+	if err != nil {
+		return entities.GaleryEvent{}, fmt.Errorf("error fetching galery event: %w", err)
+	}
+
+	event, err := galeryEventFromDoc(doc)
+	if err != nil {
+		return entities.GaleryEvent{}, fmt.Errorf("error parsing galery event: %w", err)
+	}
+	return event, nil
+}
+
 func (r *DBRepository) DeleteGaleryEvent(ctx context.Context, id string) error {
 	docRef := r.client.Collection(r.collections.GaleryEvents).Doc(id)
 	if _, err := docRef.Delete(ctx); err != nil {
@@ -482,23 +784,59 @@ func (r *DBRepository) galeryEventsFromIterator(iter *firestore.DocumentIterator
 			return nil, fmt.Errorf("error iterating galery events: %w", err)
 		}
 
-		var event entities.GaleryEvent
-		if err := doc.DataTo(&event); err != nil {
+		event, err := galeryEventFromDoc(doc)
+		if err != nil {
 			continue // Skip malformed documents
 		}
-		event.ID = doc.Ref.ID
 		events = append(events, event)
 	}
 	return events, nil
 }
 
+// legacyGaleryEvent holds the fields of galery events stored before items existed
+type legacyGaleryEvent struct {
+	ImageURLs []string `firestore:"image_urls"`
+	ImageIDs  []string `firestore:"image_ids"`
+}
+
+// galeryEventFromDoc parses a galery event document
+// Documents stored before items existed get their items built from the old parallel arrays
+func galeryEventFromDoc(doc *firestore.DocumentSnapshot) (entities.GaleryEvent, error) {
+	var event entities.GaleryEvent
+	if err := doc.DataTo(&event); err != nil {
+		return entities.GaleryEvent{}, err
+	}
+	event.ID = doc.Ref.ID
+
+	if _, err := doc.DataAt("items"); err == nil {
+		return event, nil
+	}
+
+	var legacy legacyGaleryEvent
+	if err := doc.DataTo(&legacy); err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	event.Items = make([]entities.GaleryItem, len(legacy.ImageIDs))
+	for i, imageID := range legacy.ImageIDs {
+		event.Items[i].ImageID = imageID
+		if i < len(legacy.ImageURLs) {
+			event.Items[i].ImageURL = legacy.ImageURLs[i]
+		}
+	}
+	event.SyncImageArrays()
+
+	return event, nil
+}
+
+// ModifyGaleryEvent updates the fields of a galery event, its items are always replaced
 func (r *DBRepository) ModifyGaleryEvent(ctx context.Context, id string, newEvent entities.GaleryEvent) (entities.GaleryEvent, error) {
 	docRef := r.client.Collection(r.collections.GaleryEvents).Doc(id)
 
 	newEvent.UpdatedAt = time.Now()
 	// Build update map
 	updates := []firestore.Update{
-		{Path: "updatedAt", Value: newEvent.UpdatedAt},
+		{Path: "updated_at", Value: newEvent.UpdatedAt},
 	}
 
 	if newEvent.Name != "" {
@@ -513,14 +851,15 @@ func (r *DBRepository) ModifyGaleryEvent(ctx context.Context, id string, newEven
 		updates = append(updates, firestore.Update{Path: "date", Value: newEvent.Date})
 	}
 
-	// we might want to delete images
-	if len(newEvent.ImageURLs) >= 0 {
-		updates = append(updates, firestore.Update{Path: "image_urls", Value: newEvent.ImageURLs})
+	if newEvent.LastUpdatedBy != "" {
+		updates = append(updates, firestore.Update{Path: "last_updated_by", Value: newEvent.LastUpdatedBy})
 	}
 
-	if len(newEvent.ImageIDs) >= 0 {
-		updates = append(updates, firestore.Update{Path: "image_ids", Value: newEvent.ImageIDs})
-	}
+	// The link is written as given so it can be removed, the server resolves updates keeping it
+	updates = append(updates, firestore.Update{Path: "grupy_event_id", Value: newEvent.GrupyEventID})
+
+	// we might want to delete images
+	updates = append(updates, galeryItemsUpdates(newEvent)...)
 
 	if _, err := docRef.Update(ctx, updates); err != nil {
 		if status.Code(err) == codes.NotFound {
@@ -531,3 +870,690 @@ func (r *DBRepository) ModifyGaleryEvent(ctx context.Context, id string, newEven
 
 	return r.GetGaleryEventByID(ctx, id)
 }
+
+// SetGaleryEventPrivate changes the visibility of a galery event
+func (r *DBRepository) SetGaleryEventPrivate(ctx context.Context, id string, private bool, by string) (entities.GaleryEvent, error) {
+	docRef := r.client.Collection(r.collections.GaleryEvents).Doc(id)
+
+	updates := []firestore.Update{
+		{Path: "updated_at", Value: time.Now()},
+		{Path: "private", Value: private},
+	}
+	if by != "" {
+		updates = append(updates, firestore.Update{Path: "last_updated_by", Value: by})
+	}
+
+	if _, err := docRef.Update(ctx, updates); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return entities.GaleryEvent{}, fmt.Errorf("galery event with id %s not found: %w", id, customerrors.ErrNotFound)
+		}
+		return entities.GaleryEvent{}, fmt.Errorf("error updating galery event visibility: %w", err)
+	}
+
+	return r.GetGaleryEventByID(ctx, id)
+}
+
+// galeryItemsUpdates builds the updates writing the items of an event along with the fields derived from them
+func galeryItemsUpdates(event entities.GaleryEvent) []firestore.Update {
+	event.SyncImageArrays()
+	if event.Items == nil {
+		event.Items = make([]entities.GaleryItem, 0)
+	}
+
+	return []firestore.Update{
+		{Path: "items", Value: event.Items},
+		{Path: "cover_image_id", Value: event.CoverImageID},
+		{Path: "image_ids", Value: event.ImageIDs},
+		{Path: "image_urls", Value: event.ImageURLs},
+	}
+}
+
+// AppendGaleryEventImage attaches an item at the end of a galery event
+// Runs in a transaction so concurrent edits don't overwrite each other. Created images, uploaded for the event,
+// are recorded to be deleted along with it
+func (r *DBRepository) AppendGaleryEventImage(ctx context.Context, id string, item entities.GaleryItem, created bool, by string) (entities.GaleryEvent, error) {
+	return r.updateGaleryEventItems(ctx, id, by, func(event *entities.GaleryEvent) error {
+		if slices.ContainsFunc(event.Items, func(existing entities.GaleryItem) bool { return existing.ImageID == item.ImageID }) {
+			return fmt.Errorf("%w: image %s is already in galery event %s", customerrors.ErrConflict, item.ImageID, id)
+		}
+
+		event.Items = append(event.Items, item)
+		if created {
+			event.CreatedImageIDs = append(event.CreatedImageIDs, item.ImageID)
+		}
+		return nil
+	})
+}
+
+// RemoveGaleryEventImage detaches an image from a galery event
+// Removing the cover falls back to the first remaining item
+func (r *DBRepository) RemoveGaleryEventImage(ctx context.Context, id string, imageID string, by string) (entities.GaleryEvent, error) {
+	return r.updateGaleryEventItems(ctx, id, by, func(event *entities.GaleryEvent) error {
+		index := slices.IndexFunc(event.Items, func(item entities.GaleryItem) bool { return item.ImageID == imageID })
+		if index < 0 {
+			return fmt.Errorf("%w: image %s is not in galery event %s", customerrors.ErrNotFound, imageID, id)
+		}
+
+		event.Items = slices.Delete(event.Items, index, index+1)
+		if event.CoverImageID == imageID {
+			event.CoverImageID = ""
+		}
+		return nil
+	})
+}
+
+// ReorderGaleryEventImages sorts the items of a galery event in the given order
+// imageIDs must hold exactly the images already attached to the event
+func (r *DBRepository) ReorderGaleryEventImages(ctx context.Context, id string, imageIDs []string, by string) (entities.GaleryEvent, error) {
+	return r.updateGaleryEventItems(ctx, id, by, func(event *entities.GaleryEvent) error {
+		if len(imageIDs) != len(event.Items) {
+			return fmt.Errorf("%w: expected %d image ids, got %d", customerrors.ErrValidation, len(event.Items), len(imageIDs))
+		}
+
+		items := make([]entities.GaleryItem, len(imageIDs))
+		seen := make(map[string]bool, len(imageIDs))
+		for i, imageID := range imageIDs {
+			index := slices.IndexFunc(event.Items, func(item entities.GaleryItem) bool { return item.ImageID == imageID })
+			if index < 0 || seen[imageID] {
+				return fmt.Errorf("%w: image ids must list each image of the galery event once", customerrors.ErrValidation)
+			}
+			seen[imageID] = true
+			items[i] = event.Items[index]
+		}
+
+		event.Items = items
+		return nil
+	})
+}
+
+// updateGaleryEventItems applies a change to the items of a galery event inside a transaction
+func (r *DBRepository) updateGaleryEventItems(ctx context.Context, id string, by string, update func(event *entities.GaleryEvent) error) (entities.GaleryEvent, error) {
+	docRef := r.client.Collection(r.collections.GaleryEvents).Doc(id)
+
+	var updated entities.GaleryEvent
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(docRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("galery event with id %s not found: %w", id, customerrors.ErrNotFound)
+			}
+			return fmt.Errorf("error fetching galery event: %w", err)
+		}
+
+		event, err := galeryEventFromDoc(doc)
+		if err != nil {
+			return fmt.Errorf("error parsing galery event: %w", err)
+		}
+
+		if err := update(&event); err != nil {
+			return err
+		}
+		event.UpdatedAt = time.Now()
+		event.SyncImageArrays()
+
+		updates := append(galeryItemsUpdates(event),
+			firestore.Update{Path: "created_image_ids", Value: event.CreatedImageIDs},
+			firestore.Update{Path: "updated_at", Value: event.UpdatedAt},
+		)
+		if by != "" {
+			event.LastUpdatedBy = by
+			updates = append(updates, firestore.Update{Path: "last_updated_by", Value: by})
+		}
+
+		updated = event
+		return tx.Update(docRef, updates)
+	})
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	return updated, nil
+}
+
+// MigrateGaleryEventItems stores the items of galery events created before items existed
+// Items are built from the old image arrays, events that already have items are left alone
+func (r *DBRepository) MigrateGaleryEventItems(ctx context.Context) (int, error) {
+	iter := r.client.Collection(r.collections.GaleryEvents).Documents(ctx)
+	defer iter.Stop()
+
+	migrated := 0
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return migrated, fmt.Errorf("error iterating galery events: %w", err)
+		}
+
+		if _, err := doc.DataAt("items"); err == nil {
+			continue
+		}
+
+		event, err := galeryEventFromDoc(doc)
+		if err != nil {
+			return migrated, fmt.Errorf("error parsing galery event %s: %w", doc.Ref.ID, err)
+		}
+
+		if _, err := doc.Ref.Update(ctx, galeryItemsUpdates(event), firestore.LastUpdateTime(doc.UpdateTime)); err != nil {
+			return migrated, fmt.Errorf("error migrating galery event %s: %w", doc.Ref.ID, err)
+		}
+		migrated++
+	}
+
+	return migrated, nil
+}
+
+// =======================
+// JOB OPERATIONS
+// =======================
+
+func (r *DBRepository) CreateJob(ctx context.Context, job entities.Job) (entities.Job, error) {
+	// Generate new document reference
+	docRef := r.client.Collection(r.collections.Jobs).NewDoc()
+	job.ID = docRef.ID
+
+	now := time.Now()
+	job.CreatedAt = now
+	job.UpdatedAt = now
+
+	if _, err := docRef.Set(ctx, job); err != nil {
+		return entities.Job{}, fmt.Errorf("error creating job: %w", err)
+	}
+
+	return job, nil
+}
+
+func (r *DBRepository) GetJobByID(ctx context.Context, id string) (entities.Job, error) {
+	doc, err := r.client.Collection(r.collections.Jobs).Doc(id).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return entities.Job{}, fmt.Errorf("job with id %s not found: %w", id, customerrors.ErrNotFound)
+		}
+		return entities.Job{}, fmt.Errorf("error fetching job: %w", err)
+	}
+
+	var job entities.Job
+	if err := doc.DataTo(&job); err != nil {
+		return entities.Job{}, fmt.Errorf("error parsing job: %w", err)
+	}
+	job.ID = doc.Ref.ID
+	return job, nil
+}
+
+// ListJobsByState lists the jobs in any of the given states, oldest first
+func (r *DBRepository) ListJobsByState(ctx context.Context, states ...entities.JobState) ([]entities.Job, error) {
+	iter := r.client.Collection(r.collections.Jobs).Where("state", "in", states).Documents(ctx)
+	defer iter.Stop()
+
+	var jobs []entities.Job
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error iterating jobs: %w", err)
+		}
+
+		var job entities.Job
+		if err := doc.DataTo(&job); err != nil {
+			continue // Skip malformed documents
+		}
+		job.ID = doc.Ref.ID
+		jobs = append(jobs, job)
+	}
+
+	// Sorted here rather than in the query, which would need a composite index
+	slices.SortFunc(jobs, func(a, b entities.Job) int { return a.CreatedAt.Compare(b.CreatedAt) })
+	return jobs, nil
+}
+
+// UpdateJob updates the state of a job, only provided fields are written
+// Progress is written whenever it has a total, Error and Result are written along with a state
+func (r *DBRepository) UpdateJob(ctx context.Context, id string, patch entities.Job) (entities.Job, error) {
+	docRef := r.client.Collection(r.collections.Jobs).Doc(id)
+
+	updates := []firestore.Update{
+		{Path: "updated_at", Value: time.Now()},
+	}
+	if patch.State != "" {
+		updates = append(updates,
+			firestore.Update{Path: "state", Value: patch.State},
+			firestore.Update{Path: "error", Value: patch.Error},
+			firestore.Update{Path: "result", Value: patch.Result},
+		)
+	}
+	if patch.Progress.Total > 0 {
+		updates = append(updates, firestore.Update{Path: "progress", Value: patch.Progress})
+	}
+	if !patch.StartedAt.IsZero() {
+		updates = append(updates, firestore.Update{Path: "started_at", Value: patch.StartedAt})
+	}
+	if !patch.FinishedAt.IsZero() {
+		updates = append(updates, firestore.Update{Path: "finished_at", Value: patch.FinishedAt})
+	}
+
+	if _, err := docRef.Update(ctx, updates); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return entities.Job{}, fmt.Errorf("job with id %s not found: %w", id, customerrors.ErrNotFound)
+		}
+		return entities.Job{}, fmt.Errorf("error updating job: %w", err)
+	}
+
+	return r.GetJobByID(ctx, id)
+}
+
+// ClaimJob moves a job to running in a transaction, so a single worker of a single instance gets it
+// Queued jobs are claimed, and running jobs whose last update is older than staleBefore, i.e. whose lease expired
+// Other jobs give ErrConflict
+func (r *DBRepository) ClaimJob(ctx context.Context, id string, staleBefore time.Time) (entities.Job, error) {
+	docRef := r.client.Collection(r.collections.Jobs).Doc(id)
+
+	var claimed entities.Job
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(docRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("job with id %s not found: %w", id, customerrors.ErrNotFound)
+			}
+			return fmt.Errorf("error fetching job: %w", err)
+		}
+
+		var job entities.Job
+		if err := doc.DataTo(&job); err != nil {
+			return fmt.Errorf("error parsing job: %w", err)
+		}
+		job.ID = doc.Ref.ID
+
+		stale := job.State == entities.JobRunning && job.UpdatedAt.Before(staleBefore)
+		if job.State != entities.JobQueued && !stale {
+			return fmt.Errorf("%w: job %s is %s", customerrors.ErrConflict, id, job.State)
+		}
+
+		now := time.Now()
+		job.State = entities.JobRunning
+		job.UpdatedAt = now
+		if job.StartedAt.IsZero() {
+			job.StartedAt = now
+		}
+
+		claimed = job
+		return tx.Update(docRef, []firestore.Update{
+			{Path: "state", Value: job.State},
+			{Path: "updated_at", Value: job.UpdatedAt},
+			{Path: "started_at", Value: job.StartedAt},
+		})
+	})
+	if err != nil {
+		return entities.Job{}, err
+	}
+
+	return claimed, nil
+}
+
+// =======================
+// EVENT WATCH OPERATIONS
+// =======================
+
+// eventWatchDocID is the document holding the Grupy events seen by the last poll
+const eventWatchDocID = "grupy"
+
+func (r *DBRepository) GetEventWatch(ctx context.Context) (entities.EventWatch, error) {
+	doc, err := r.client.Collection(r.collections.EventWatch).Doc(eventWatchDocID).Get(ctx)
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return entities.EventWatch{}, fmt.Errorf("event watch not found: %w", customerrors.ErrNotFound)
+		}
+		return entities.EventWatch{}, fmt.Errorf("error fetching event watch: %w", err)
+	}
+
+	var watch entities.EventWatch
+	if err := doc.DataTo(&watch); err != nil {
+		return entities.EventWatch{}, fmt.Errorf("error parsing event watch: %w", err)
+	}
+	return watch, nil
+}
+
+// SaveEventWatch replaces the Grupy events seen by the last poll
+func (r *DBRepository) SaveEventWatch(ctx context.Context, watch entities.EventWatch) error {
+	docRef := r.client.Collection(r.collections.EventWatch).Doc(eventWatchDocID)
+	if _, err := docRef.Set(ctx, watch); err != nil {
+		return fmt.Errorf("error saving event watch: %w", err)
+	}
+	return nil
+}
+
+func (r *DBRepository) CreateDeadLetter(ctx context.Context, letter entities.DeadLetter) (entities.DeadLetter, error) {
+	// Generate new document reference
+	docRef := r.client.Collection(r.collections.DeadLetters).NewDoc()
+	letter.ID = docRef.ID
+	letter.CreatedAt = time.Now()
+
+	if _, err := docRef.Set(ctx, letter); err != nil {
+		return entities.DeadLetter{}, fmt.Errorf("error creating dead letter: %w", err)
+	}
+
+	return letter, nil
+}
+
+// ListDeadLetters lists the dead letters, newest first
+func (r *DBRepository) ListDeadLetters(ctx context.Context) ([]entities.DeadLetter, error) {
+	iter := r.client.Collection(r.collections.DeadLetters).OrderBy("created_at", firestore.Desc).Documents(ctx)
+	defer iter.Stop()
+
+	var letters []entities.DeadLetter
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error iterating dead letters: %w", err)
+		}
+
+		var letter entities.DeadLetter
+		if err := doc.DataTo(&letter); err != nil {
+			continue // Skip malformed documents
+		}
+		letter.ID = doc.Ref.ID
+		letters = append(letters, letter)
+	}
+
+	return letters, nil
+}
+
+// =======================
+// LEASE OPERATIONS
+// =======================
+
+// AcquireLease takes or renews the named lease for holder until ttl from now
+// Fails with ErrConflict while another holder has a lease that hasn't expired
+func (r *DBRepository) AcquireLease(ctx context.Context, name string, holder string, ttl time.Duration) (entities.Lease, error) {
+	docRef := r.client.Collection(r.collections.Leases).Doc(name)
+
+	var acquired entities.Lease
+	err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		now := time.Now()
+
+		doc, err := tx.Get(docRef)
+		if err != nil && status.Code(err) != codes.NotFound {
+			return fmt.Errorf("error fetching lease: %w", err)
+		}
+		if err == nil {
+			var lease entities.Lease
+			if err := doc.DataTo(&lease); err != nil {
+				return fmt.Errorf("error parsing lease: %w", err)
+			}
+			if lease.Holder != holder && lease.ExpiresAt.After(now) {
+				return fmt.Errorf("%w: lease %s is held until %s", customerrors.ErrConflict, name, lease.ExpiresAt.Format(time.RFC3339))
+			}
+		}
+
+		acquired = entities.Lease{
+			Name:      name,
+			Holder:    holder,
+			ExpiresAt: now.Add(ttl),
+		}
+		return tx.Set(docRef, acquired)
+	})
+	if err != nil {
+		return entities.Lease{}, err
+	}
+
+	return acquired, nil
+}
+
+// =======================
+// UPLOAD RESERVATION OPERATIONS
+// =======================
+
+func (r *DBRepository) CreateUploadReservation(ctx context.Context, reservation entities.UploadReservation) (entities.UploadReservation, error) {
+	// Generate new document reference
+	docRef := r.client.Collection(r.collections.Uploads).NewDoc()
+	reservation.ID = docRef.ID
+	reservation.CreatedAt = time.Now()
+
+	if _, err := docRef.Set(ctx, reservation); err != nil {
+		return entities.UploadReservation{}, fmt.Errorf("error creating upload reservation: %w", err)
+	}
+
+	return reservation, nil
+}
+
+// GetUploadReservation returns the reservation of an upload key, ErrNotFound when it was never reserved
+func (r *DBRepository) GetUploadReservation(ctx context.Context, key string) (entities.UploadReservation, error) {
+	iter := r.client.Collection(r.collections.Uploads).Where("key", "==", key).Limit(1).Documents(ctx)
+	defer iter.Stop()
+
+	doc, err := iter.Next()
+	if err == iterator.Done {
+		return entities.UploadReservation{}, fmt.Errorf("%w: upload key %s was not reserved", customerrors.ErrNotFound, key)
+	}
+	if err != nil {
+		return entities.UploadReservation{}, fmt.Errorf("error fetching upload reservation: %w", err)
+	}
+
+	var reservation entities.UploadReservation
+	if err := doc.DataTo(&reservation); err != nil {
+		return entities.UploadReservation{}, fmt.Errorf("error parsing upload reservation: %w", err)
+	}
+	reservation.ID = doc.Ref.ID
+	return reservation, nil
+}
+
+// FinalizeUploadReservation consumes an upload reservation in a transaction, so an upload is finalized into a single image
+// An image without ID is created along with it, an existing one, e.g. a reused duplicate, is only recorded.
+// When the reservation was already finalized nothing is written, the image recorded then is returned and finalized is false
+func (r *DBRepository) FinalizeUploadReservation(ctx context.Context, id string, img entities.Image) (image entities.Image, finalized bool, err error) {
+	reservationRef := r.client.Collection(r.collections.Uploads).Doc(id)
+
+	var imageRef *firestore.DocumentRef
+	if img.ID == "" {
+		imageRef = r.client.Collection(r.collections.Images).NewDoc()
+		img.ID = imageRef.ID
+		if img.CreatedAt.IsZero() {
+			img.CreatedAt = time.Now()
+		}
+		if img.UpdatedAt.IsZero() {
+			img.UpdatedAt = time.Now()
+		}
+	}
+
+	err = r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+		doc, err := tx.Get(reservationRef)
+		if err != nil {
+			if status.Code(err) == codes.NotFound {
+				return fmt.Errorf("upload reservation with id %s not found: %w", id, customerrors.ErrNotFound)
+			}
+			return fmt.Errorf("error fetching upload reservation: %w", err)
+		}
+
+		var reservation entities.UploadReservation
+		if err := doc.DataTo(&reservation); err != nil {
+			return fmt.Errorf("error parsing upload reservation: %w", err)
+		}
+
+		if reservation.ImageID != "" {
+			imageDoc, err := tx.Get(r.client.Collection(r.collections.Images).Doc(reservation.ImageID))
+			if err != nil {
+				if status.Code(err) == codes.NotFound {
+					return fmt.Errorf("image with id %s not found: %w", reservation.ImageID, customerrors.ErrNotFound)
+				}
+				return fmt.Errorf("error fetching image: %w", err)
+			}
+			var existing entities.Image
+			if err := imageDoc.DataTo(&existing); err != nil {
+				return fmt.Errorf("error parsing image: %w", err)
+			}
+			existing.ID = imageDoc.Ref.ID
+			image, finalized = existing, false
+			return nil
+		}
+
+		if imageRef != nil {
+			if err := tx.Create(imageRef, img); err != nil {
+				return err
+			}
+		}
+		image, finalized = img, true
+		return tx.Update(reservationRef, []firestore.Update{{Path: "image_id", Value: img.ID}})
+	})
+	if err != nil {
+		return entities.Image{}, false, err
+	}
+
+	return image, finalized, nil
+}
+
+// =======================
+// AUDIT OPERATIONS
+// =======================
+
+// CreateAuditRecord appends a record to the audit log, which is never updated nor deleted
+func (r *DBRepository) CreateAuditRecord(ctx context.Context, record entities.AuditRecord) (entities.AuditRecord, error) {
+	docRef := r.client.Collection(r.collections.AuditLog).NewDoc()
+	record.ID = docRef.ID
+	if record.OccurredAt.IsZero() {
+		record.OccurredAt = time.Now()
+	}
+
+	// Create fails instead of overwriting an existing record
+	if _, err := docRef.Create(ctx, record); err != nil {
+		return entities.AuditRecord{}, fmt.Errorf("error creating audit record: %w", err)
+	}
+
+	return record, nil
+}
+
+// ListAuditRecords lists a page of the audit log, newest first
+// Filtering on several fields needs a composite index ending with occurred_at descending
+func (r *DBRepository) ListAuditRecords(ctx context.Context, query entities.AuditQuery) (entities.AuditPage, error) {
+	q := r.client.Collection(r.collections.AuditLog).Query
+	if query.Actor != "" {
+		q = q.Where("actor", "==", query.Actor)
+	}
+	if query.Action != "" {
+		q = q.Where("action", "==", query.Action)
+	}
+	if query.EntityType != "" {
+		q = q.Where("entity_type", "==", query.EntityType)
+	}
+	if query.EntityID != "" {
+		q = q.Where("entity_id", "==", query.EntityID)
+	}
+	if !query.From.IsZero() {
+		q = q.Where("occurred_at", ">=", query.From)
+	}
+	if !query.To.IsZero() {
+		q = q.Where("occurred_at", "<", query.To)
+	}
+
+	// One more record than the page holds tells whether there is a next page
+	q = q.OrderBy("occurred_at", firestore.Desc).Offset((query.Page - 1) * query.Limit).Limit(query.Limit + 1)
+
+	iter := q.Documents(ctx)
+	defer iter.Stop()
+
+	var page entities.AuditPage
+	for {
+		doc, err := iter.Next()
+		if err == iterator.Done {
+			break
+		}
+		if err != nil {
+			return entities.AuditPage{}, fmt.Errorf("error iterating audit records: %w", err)
+		}
+
+		var record entities.AuditRecord
+		if err := doc.DataTo(&record); err != nil {
+			continue // Skip malformed documents
+		}
+		record.ID = doc.Ref.ID
+		page.Records = append(page.Records, record)
+	}
+
+	if len(page.Records) > query.Limit {
+		page.Records = page.Records[:query.Limit]
+		page.HasMore = true
+	}
+	return page, nil
+}
+
+// =======================
+// IMPORT OPERATIONS
+// =======================
+
+// importBatchSize bounds the writes committed together by an import, the Firestore limit per commit
+const importBatchSize = 500
+
+// importWrite sets a whole document
+type importWrite struct {
+	ref  *firestore.DocumentRef
+	data any
+}
+
+// ImportTexts writes texts in batches, texts with an ID replace their document and the others are created
+// The written texts are returned with their IDs
+func (r *DBRepository) ImportTexts(ctx context.Context, texts []entities.Text) ([]entities.Text, error) {
+	collection := r.client.Collection(r.collections.Texts)
+	imported := slices.Clone(texts)
+	writes := make([]importWrite, len(imported))
+	for i := range imported {
+		ref := importDocRef(collection, imported[i].ID)
+		imported[i].ID = ref.ID
+		writes[i] = importWrite{ref: ref, data: imported[i]}
+	}
+
+	if err := r.commitImport(ctx, "texts", writes); err != nil {
+		return nil, err
+	}
+	return imported, nil
+}
+
+// ImportTimelineEntries writes timeline entries in batches, entries with an ID replace their document and
+// the others are created. The written entries are returned with their IDs
+func (r *DBRepository) ImportTimelineEntries(ctx context.Context, entries []entities.TimelineEntry) ([]entities.TimelineEntry, error) {
+	collection := r.client.Collection(r.collections.TimelineEntries)
+	imported := slices.Clone(entries)
+	writes := make([]importWrite, len(imported))
+	for i := range imported {
+		ref := importDocRef(collection, imported[i].ID)
+		imported[i].ID = ref.ID
+		writes[i] = importWrite{ref: ref, data: imported[i]}
+	}
+
+	if err := r.commitImport(ctx, "timeline entries", writes); err != nil {
+		return nil, err
+	}
+	return imported, nil
+}
+
+// importDocRef returns the document of an imported item, a new one when it has no ID
+func importDocRef(collection *firestore.CollectionRef, id string) *firestore.DocumentRef {
+	if id == "" {
+		return collection.NewDoc()
+	}
+	return collection.Doc(id)
+}
+
+// commitImport commits the writes of an import, each batch in its own transaction
+// A failed batch leaves the previous ones written, imports are upserts so they can be run again
+func (r *DBRepository) commitImport(ctx context.Context, what string, writes []importWrite) error {
+	for start := 0; start < len(writes); start += importBatchSize {
+		batch := writes[start:min(start+importBatchSize, len(writes))]
+
+		err := r.client.RunTransaction(ctx, func(ctx context.Context, tx *firestore.Transaction) error {
+			for _, write := range batch {
+				if err := tx.Set(write.ref, write.data); err != nil {
+					return err
+				}
+			}
+			return nil
+		})
+		if err != nil {
+			return fmt.Errorf("error importing %s, %d of %d were written: %w", what, start, len(writes), err)
+		}
+	}
+	return nil
+}
@@ -482,3 +482,55 @@ func TestDBRepository_DeleteImageMeta(t *testing.T) {
 	assert.Error(t, err, "Should get error when fetching deleted image")
 	assert.Contains(t, err.Error(), "not found", "Error should mention 'not found'")
 }
+
+func TestDBRepository_ImageTags(t *testing.T) {
+	db, cleanup := setupTestDB(t)
+	defer cleanup()
+
+	ctx := context.Background()
+	tag := "test-tag-" + time.Now().Format("20060102150405.000000")
+
+	var ids []string
+	for i := range 2 {
+		created, err := db.CreateImageMeta(ctx, entities.Image{
+			Name:      "Tagged Image",
+			Text:      "Image used to test tags",
+			Tags:      []string{"existing"},
+			CreatedAt: time.Now(),
+			UpdatedAt: time.Now(),
+		})
+		require.NoError(t, err, "Failed to create image %d", i)
+		ids = append(ids, created.ID)
+
+		defer func() {
+			err := db.DeleteImageMeta(ctx, created.ID)
+			assert.NoError(t, err, "Failed to cleanup created image")
+		}()
+	}
+
+	// Tag both images in one batch
+	require.NoError(t, db.AddImageTags(ctx, ids, []string{tag}, "editor@example.com"))
+
+	tagged, err := db.GetImagesByTag(ctx, tag)
+	require.NoError(t, err)
+	assert.Len(t, tagged, 2)
+	for _, img := range tagged {
+		assert.ElementsMatch(t, []string{"existing", tag}, img.Tags)
+		assert.Equal(t, "editor@example.com", img.LastUpdatedBy)
+	}
+
+	// A missing image fails the whole batch
+	err = db.RemoveImageTags(ctx, append(ids, "non-existent-image-id"), []string{tag}, "")
+	assert.Error(t, err)
+
+	tagged, err = db.GetImagesByTag(ctx, tag)
+	require.NoError(t, err)
+	assert.Len(t, tagged, 2, "No image should be untagged when the batch fails")
+
+	// Untag both images
+	require.NoError(t, db.RemoveImageTags(ctx, ids, []string{tag}, ""))
+
+	tagged, err = db.GetImagesByTag(ctx, tag)
+	require.NoError(t, err)
+	assert.Empty(t, tagged)
+}
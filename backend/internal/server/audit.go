@@ -0,0 +1,102 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"time"
+
+	"backend/internal/entities"
+	"backend/internal/platform/audit"
+	"backend/internal/platform/auth"
+	customerrors "backend/internal/platform/errors"
+	"backend/internal/platform/middleware"
+)
+
+// =======================
+// AUDIT LOG
+// =======================
+
+const (
+	// defaultAuditPageSize is the number of audit records listed when the query has no limit
+	defaultAuditPageSize = 50
+
+	// maxAuditPageSize caps the number of audit records listed at once
+	maxAuditPageSize = 200
+)
+
+// jobActorKey holds the caller who queued the job running with a context
+type jobActorKey struct{}
+
+// contextWithJobActor credits the mutations of a job to the caller who queued it, jobs run without a request
+func contextWithJobActor(ctx context.Context, actor string) context.Context {
+	return context.WithValue(ctx, jobActorKey{}, actor)
+}
+
+// auditActor identifies the caller of a mutation: the verified principal of the request, or the caller who queued the job
+func auditActor(ctx context.Context) string {
+	if actor := auth.ActorFromContext(ctx); actor != "" {
+		return actor
+	}
+	actor, _ := ctx.Value(jobActorKey{}).(string)
+	return actor
+}
+
+// auditIgnoredFields change on every write, they would be listed in the changes of every update
+var auditIgnoredFields = []string{"updatedAt", "updated_at", "lastUpdatedBy", "last_updated_by", "syncedAt"}
+
+// audit appends a mutation of an entity to the audit log
+// before is nil for creations and after is nil for deletions. The caller and request are read from ctx.
+// Best effort: the mutation already happened, so a failure is logged instead of returned
+func (s *server) audit(ctx context.Context, action entities.AuditAction, entityType entities.AuditEntityType, id string, before, after any) {
+	record := entities.AuditRecord{
+		Actor:      auditActor(ctx),
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   id,
+		Changes:    make(map[string]entities.AuditChange),
+		RequestID:  middleware.GetRequestID(ctx),
+		OccurredAt: time.Now(),
+	}
+	for name, change := range audit.Diff(before, after, auditIgnoredFields...) {
+		record.Changes[name] = entities.AuditChange{Before: change.Before, After: change.After}
+	}
+
+	// Written even when the request was cancelled, the record is the only trace of the mutation
+	if _, err := s.db.CreateAuditRecord(context.WithoutCancel(ctx), record); err != nil {
+		log.Printf("audit: failed to record %s of %s %s by %q: %v", action, entityType, id, record.Actor, err)
+	}
+}
+
+// ListAuditRecords lists a page of the audit log, newest first
+// A missing limit or page falls back to the first page of defaultAuditPageSize records
+func (s *server) ListAuditRecords(ctx context.Context, query entities.AuditQuery) (entities.AuditPage, error) {
+	switch query.Action {
+	case "", entities.AuditCreate, entities.AuditUpdate, entities.AuditDelete:
+	default:
+		return entities.AuditPage{}, fmt.Errorf("%w: unknown action %q, expected create, update or delete", customerrors.ErrValidation, query.Action)
+	}
+
+	switch query.EntityType {
+	case "", entities.AuditText, entities.AuditImage, entities.AuditTimelineEntry, entities.AuditGaleryEvent, entities.AuditUser:
+	default:
+		return entities.AuditPage{}, fmt.Errorf("%w: unknown entity type %q", customerrors.ErrValidation, query.EntityType)
+	}
+
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return entities.AuditPage{}, fmt.Errorf("%w: from must be before to", customerrors.ErrValidation)
+	}
+
+	if query.Limit <= 0 {
+		query.Limit = defaultAuditPageSize
+	}
+	query.Limit = min(query.Limit, maxAuditPageSize)
+	query.Page = max(query.Page, 1)
+
+	page, err := s.db.ListAuditRecords(ctx, query)
+	if err != nil {
+		return entities.AuditPage{}, fmt.Errorf("failed to list audit records: %w", err)
+	}
+	page.Page, page.Limit = query.Page, query.Limit
+	return page, nil
+}
@@ -0,0 +1,138 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"html"
+	"regexp"
+	"strings"
+	"time"
+
+	"backend/internal/entities"
+	"backend/internal/platform/ical"
+)
+
+// =======================
+// EVENTS CALENDAR
+// =======================
+
+const (
+	// calendarProdID identifies the iCalendar feeds produced by the site
+	calendarProdID = "-//Grupy Sanca//Site Events//PT"
+
+	// calendarName is the calendar name shown by subscribed clients
+	calendarName = "Grupy Sanca"
+
+	// calendarFeedSize is the number of most recent events published in the feed
+	calendarFeedSize = 100
+
+	// calendarRefreshInterval is how often subscribed clients should refresh the feed
+	calendarRefreshInterval = 12 * time.Hour
+
+	// calendarUIDDomain makes event UIDs globally unique
+	calendarUIDDomain = "eventos.grupysanca.com.br"
+)
+
+var (
+	// htmlLineBreaks matches the tags ending a line of an HTML description
+	htmlLineBreaks = regexp.MustCompile(`(?i)<br\s*/?>|</p>|</li>|</h[1-6]>`)
+	// htmlTags matches any remaining HTML tag
+	htmlTags = regexp.MustCompile(`<[^>]*>`)
+	// blankLines matches runs of empty lines left by stripped markup
+	blankLines = regexp.MustCompile(`\n{3,}`)
+)
+
+// GetEventsCalendar renders the most recent community events as an iCalendar feed
+func (s *server) GetEventsCalendar(ctx context.Context) ([]byte, error) {
+	page, err := s.GetEvents(ctx, entities.EventQuery{
+		Limit:   calendarFeedSize,
+		OrderBy: "starts-at",
+		Desc:    true,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	return renderCalendar(page.Events, time.Now())
+}
+
+// GetEventCalendar renders a single Grupy event as an iCalendar file
+func (s *server) GetEventCalendar(ctx context.Context, id string) ([]byte, error) {
+	event, err := s.getGrupyEvent(ctx, id)
+	if err != nil {
+		return nil, err
+	}
+
+	return renderCalendar([]entities.Event{event}, time.Now())
+}
+
+// renderCalendar encodes events as an iCalendar object, now stamps events without a creation date
+func renderCalendar(events []entities.Event, now time.Time) ([]byte, error) {
+	cal := ical.Calendar{
+		ProdID:          calendarProdID,
+		Name:            calendarName,
+		RefreshInterval: calendarRefreshInterval,
+		Events:          make([]ical.Event, 0, len(events)),
+	}
+
+	for _, event := range events {
+		cal.Events = append(cal.Events, calendarEvent(event, now))
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Encode(&buf, cal); err != nil {
+		return nil, fmt.Errorf("failed to encode calendar: %w", err)
+	}
+	return buf.Bytes(), nil
+}
+
+// calendarEvent converts a community event to a calendar event in the event's own time zone
+func calendarEvent(event entities.Event, now time.Time) ical.Event {
+	loc := eventLocation(event)
+
+	// IDs of partner events are prefixed by their source, identifiers are only unique within a source
+	uid := event.Identifier
+	if uid == "" || event.Source != grupyEventSource {
+		uid = event.ID
+	}
+
+	stamp := event.CreatedAt
+	if stamp.IsZero() {
+		stamp = now
+	}
+
+	return ical.Event{
+		UID:         uid + "@" + calendarUIDDomain,
+		Stamp:       stamp,
+		Start:       event.StartsAt.In(loc),
+		End:         event.EndsAt.In(loc),
+		Summary:     event.Name,
+		Description: htmlToText(event.Description),
+		Location:    event.LocationName,
+		URL:         event.Link,
+	}
+}
+
+// eventLocation returns the time zone of a Grupy event, UTC when it is unknown
+func eventLocation(event entities.Event) *time.Location {
+	if event.Timezone == "" {
+		return time.UTC
+	}
+
+	loc, err := time.LoadLocation(event.Timezone)
+	if err != nil {
+		return time.UTC
+	}
+	return loc
+}
+
+// htmlToText turns the HTML description of a Grupy event into plain text for calendar clients and the timeline
+func htmlToText(s string) string {
+	s = htmlLineBreaks.ReplaceAllString(s, "\n")
+	s = htmlTags.ReplaceAllString(s, "")
+	s = html.UnescapeString(s)
+	s = strings.ReplaceAll(s, "\r\n", "\n")
+	s = blankLines.ReplaceAllString(s, "\n\n")
+	return strings.TrimSpace(s)
+}
@@ -0,0 +1,233 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+	"unicode"
+
+	"backend/internal/entities"
+	customerrors "backend/internal/platform/errors"
+)
+
+// =======================
+// EVENT SOURCES
+// =======================
+
+const (
+	// grupyEventSource names the Grupy Open Event instance among the event sources
+	grupyEventSource = "grupy"
+
+	// maxAggregatedEvents bounds how deep pages of merged events go, every source is read up to it
+	maxAggregatedEvents = 500
+
+	// eventSourcePageSize is the page size used to read a source
+	eventSourcePageSize = 100
+
+	// duplicateEventWindow is how far apart two copies of an event may start
+	duplicateEventWindow = 12 * time.Hour
+)
+
+// grupyEvents adapts the Grupy events API to an event source
+type grupyEvents struct {
+	port GrupyEventsPort
+}
+
+func (g grupyEvents) Name() string {
+	return grupyEventSource
+}
+
+func (g grupyEvents) GetEvents(ctx context.Context, query entities.EventQuery) ([]entities.Event, int, error) {
+	events, count, err := g.port.GetEvents(ctx, query)
+	if err != nil {
+		return nil, 0, err
+	}
+	addLinksToevents(events)
+	return events, count, nil
+}
+
+// sourceResult holds what was read from an event source
+type sourceResult struct {
+	events []entities.Event
+	count  int
+	err    error
+}
+
+// aggregateEvents merges a page of events from the Grupy API and the partner sources
+// Every source is read up to the requested page at the same time. Sources that fail are left out
+// and reported in the page, the request only fails when no source could be read
+func (s *server) aggregateEvents(ctx context.Context, query entities.EventQuery) (entities.EventPage, error) {
+	window := query.Page * query.Limit
+	if window > maxAggregatedEvents {
+		return entities.EventPage{}, fmt.Errorf("%w: only the first %d events can be paged through", customerrors.ErrValidation, maxAggregatedEvents)
+	}
+
+	sources := append([]EventSourcePort{grupyEvents{port: s.events}}, s.eventSources...)
+	results := make([]sourceResult, len(sources))
+
+	var wg sync.WaitGroup
+	for i, source := range sources {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			events, count, err := readEventSource(ctx, source, query, window)
+			results[i] = sourceResult{events: events, count: count, err: err}
+		}()
+	}
+	wg.Wait()
+
+	var (
+		page   entities.EventPage
+		merged []entities.Event
+		errs   []error
+	)
+	for i, result := range results {
+		if result.err != nil {
+			page.FailedSources = append(page.FailedSources, sources[i].Name())
+			errs = append(errs, fmt.Errorf("event source %s: %w", sources[i].Name(), result.err))
+			continue
+		}
+		merged = append(merged, result.events...)
+		page.Count += result.count
+	}
+	if len(errs) == len(sources) {
+		return entities.EventPage{}, errors.Join(errs...)
+	}
+
+	merged, duplicates := dedupeEvents(merged)
+	page.Count -= duplicates
+	entities.SortEvents(merged, query.OrderBy, query.Desc)
+
+	start := min((query.Page-1)*query.Limit, len(merged))
+	end := min(start+query.Limit, len(merged))
+	page.Events = merged[start:end]
+	return page, nil
+}
+
+// readEventSource reads the first window events of a source matching query, page by page
+// Events of partner sources get IDs prefixed by the source name, IDs of different sources may collide
+func readEventSource(ctx context.Context, source EventSourcePort, query entities.EventQuery, window int) ([]entities.Event, int, error) {
+	var (
+		events []entities.Event
+		count  int
+	)
+
+	query.Limit = min(window, eventSourcePageSize)
+	for query.Page = 1; len(events) < window; query.Page++ {
+		batch, n, err := source.GetEvents(ctx, query)
+		if err != nil {
+			return nil, 0, err
+		}
+		count = n
+		events = append(events, batch...)
+
+		if len(batch) < query.Limit || len(events) >= count {
+			break
+		}
+	}
+
+	name := source.Name()
+	for i := range events {
+		events[i].Source = name
+		if name != grupyEventSource {
+			events[i].ID = name + ":" + events[i].ID
+		}
+	}
+
+	if len(events) > window {
+		events = events[:window]
+	}
+	return events, count, nil
+}
+
+// dedupeEvents drops the copies of events listed by several sources, keeping the first one read
+// Blank fields of the kept event are filled from its copies. Copies share their link, or their
+// name and start within a few hours. Events of the same source are never merged
+func dedupeEvents(events []entities.Event) ([]entities.Event, int) {
+	var (
+		kept       = make([]entities.Event, 0, len(events))
+		byLink     = make(map[string][]int)
+		byName     = make(map[string][]int)
+		duplicates int
+	)
+
+	for _, event := range events {
+		link := normalizeEventLink(event.Link)
+		name := normalizeEventName(event.Name)
+
+		original := -1
+		for _, i := range byLink[link] {
+			if link != "" && kept[i].Source != event.Source {
+				original = i
+				break
+			}
+		}
+		for _, i := range byName[name] {
+			if original >= 0 {
+				break
+			}
+			if kept[i].Source != event.Source && absDuration(kept[i].StartsAt.Sub(event.StartsAt)) < duplicateEventWindow {
+				original = i
+			}
+		}
+
+		if original >= 0 {
+			kept[original] = mergeEvent(kept[original], event)
+			duplicates++
+			continue
+		}
+
+		if link != "" {
+			byLink[link] = append(byLink[link], len(kept))
+		}
+		byName[name] = append(byName[name], len(kept))
+		kept = append(kept, event)
+	}
+
+	return kept, duplicates
+}
+
+// mergeEvent fills the blank fields of event from a copy read from another source
+func mergeEvent(event, copy entities.Event) entities.Event {
+	fill := func(field *string, value string) {
+		if *field == "" {
+			*field = value
+		}
+	}
+
+	fill(&event.Description, copy.Description)
+	fill(&event.Timezone, copy.Timezone)
+	fill(&event.LocationName, copy.LocationName)
+	fill(&event.LogoURL, copy.LogoURL)
+	fill(&event.ThumbnailImageURL, copy.ThumbnailImageURL)
+	fill(&event.LargeImageURL, copy.LargeImageURL)
+	fill(&event.OriginalImageURL, copy.OriginalImageURL)
+	fill(&event.IconImageURL, copy.IconImageURL)
+	fill(&event.Link, copy.Link)
+	return event
+}
+
+// normalizeEventName reduces an event name to its lowercase words, ignoring punctuation
+func normalizeEventName(name string) string {
+	words := strings.FieldsFunc(strings.ToLower(name), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+	return strings.Join(words, " ")
+}
+
+// normalizeEventLink drops the scheme and trailing slash of an event link
+func normalizeEventLink(link string) string {
+	link = strings.ToLower(strings.TrimSpace(link))
+	link = strings.TrimPrefix(strings.TrimPrefix(link, "https://"), "http://")
+	return strings.TrimSuffix(link, "/")
+}
+
+func absDuration(d time.Duration) time.Duration {
+	if d < 0 {
+		return -d
+	}
+	return d
+}
@@ -2,37 +2,235 @@ package server
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"slices"
+	"sort"
+	"strings"
 
 	"backend/internal/entities"
+	customerrors "backend/internal/platform/errors"
 )
 
 const (
 	grupyBaseEventsWebPageURL = "https://eventos.grupysanca.com.br"
+
+	// maxEventSearchLength bounds the event name search sent to the Grupy API
+	maxEventSearchLength = 100
 )
 
+// eventStates are the states an event can be filtered by
+var eventStates = []string{"draft", "published"}
+
 // =======================
 // EVENTS OPERATIONS
 // =======================
 
-func (s *server) GetEvents(ctx context.Context, limit int, orderBy string, desc bool) ([]entities.Event, error) {
+// GetEvents lists a page of community events matching query and the number of events matching it
+// Without partner sources the Grupy API is paged directly, otherwise the sources are merged
+func (s *server) GetEvents(ctx context.Context, query entities.EventQuery) (entities.EventPage, error) {
 	// Validate limit
-	if limit <= 0 || limit > 100 {
-		limit = 10 // default
+	if query.Limit <= 0 || query.Limit > 100 {
+		query.Limit = 10 // default
+	}
+	if query.Page <= 0 {
+		query.Page = 1
+	}
+
+	query.Search = strings.TrimSpace(query.Search)
+	if err := validateEventQuery(query); err != nil {
+		return entities.EventPage{}, err
+	}
+
+	if len(s.eventSources) > 0 {
+		return s.aggregateEvents(ctx, query)
 	}
 
 	// Delegate to port
-	events, err := s.events.GetEvents(ctx, limit, orderBy, desc)
+	events, count, err := grupyEvents{port: s.events}.GetEvents(ctx, query)
+	if err != nil {
+		return entities.EventPage{}, err
+	}
+	return entities.EventPage{Events: events, Count: count}, nil
+}
+
+// validateEventQuery rejects queries that can't match any event
+func validateEventQuery(query entities.EventQuery) error {
+	if query.Upcoming && query.Past {
+		return fmt.Errorf("%w: upcoming and past can't be combined", customerrors.ErrValidation)
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return fmt.Errorf("%w: from must be before to", customerrors.ErrValidation)
+	}
+	if query.State != "" && !slices.Contains(eventStates, query.State) {
+		return fmt.Errorf("%w: unknown event state %q", customerrors.ErrValidation, query.State)
+	}
+	if len(query.Search) > maxEventSearchLength {
+		return fmt.Errorf("%w: search is longer than %d characters", customerrors.ErrValidation, maxEventSearchLength)
+	}
+	return nil
+}
+
+// GetEventByID fetches a Grupy event with its tracks
+func (s *server) GetEventByID(ctx context.Context, id string) (entities.Event, error) {
+	event, err := s.getGrupyEvent(ctx, id)
+	if err != nil {
+		return entities.Event{}, err
+	}
+
+	tracks, err := s.events.GetEventTracks(ctx, id)
+	if err != nil {
+		return entities.Event{}, fmt.Errorf("failed to fetch tracks of event %s: %w", id, err)
+	}
+	sort.SliceStable(tracks, func(i, j int) bool {
+		return tracks[i].Name < tracks[j].Name
+	})
+
+	event.Tracks = tracks
+	return event, nil
+}
+
+// GetEventSessions lists the sessions of a Grupy event in schedule order
+// Unscheduled sessions come last, sorted by title
+func (s *server) GetEventSessions(ctx context.Context, eventID string) ([]entities.Session, error) {
+	sessions, err := s.events.GetEventSessions(ctx, eventID)
 	if err != nil {
 		return nil, err
 	}
-	addLinksToevents(events)
-	return events, nil
+
+	sort.SliceStable(sessions, func(i, j int) bool {
+		a, b := sessions[i], sessions[j]
+		if a.StartsAt.IsZero() != b.StartsAt.IsZero() {
+			return !a.StartsAt.IsZero()
+		}
+		if !a.StartsAt.Equal(b.StartsAt) {
+			return a.StartsAt.Before(b.StartsAt)
+		}
+		return a.Title < b.Title
+	})
+
+	return sessions, nil
 }
 
-// fills the Link field of events in place
+// GetEventSpeakers lists the speakers of a Grupy event by name
+func (s *server) GetEventSpeakers(ctx context.Context, eventID string) ([]entities.Speaker, error) {
+	speakers, err := s.events.GetEventSpeakers(ctx, eventID)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.SliceStable(speakers, func(i, j int) bool {
+		return strings.ToLower(speakers[i].Name) < strings.ToLower(speakers[j].Name)
+	})
+
+	return speakers, nil
+}
+
+// fills the Link and Source fields of Grupy events in place
 func addLinksToevents(events []entities.Event) {
 	for i := range events {
+		events[i].Source = grupyEventSource
 		events[i].Link = fmt.Sprintf("%s/e/%s", grupyBaseEventsWebPageURL, events[i].ID)
 	}
 }
+
+// =======================
+// GALERY EVENT LINKS
+// =======================
+
+// GetGaleryEventByGrupyEventID returns the galery event holding the photos of a Grupy event
+// The Grupy event details are embedded in the result
+func (s *server) GetGaleryEventByGrupyEventID(ctx context.Context, grupyEventID string) (entities.GaleryEvent, error) {
+	grupyEvent, err := s.getGrupyEvent(ctx, grupyEventID)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	event, err := s.db.GetGaleryEventByGrupyEventID(ctx, grupyEventID)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	event, err = s.galeryEventForReader(ctx, event)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	event.GrupyEvent = &grupyEvent
+	return event, nil
+}
+
+// getGrupyEvent fetches a Grupy event and fills its link
+func (s *server) getGrupyEvent(ctx context.Context, id string) (entities.Event, error) {
+	event, err := s.events.GetEventByID(ctx, id)
+	if err != nil {
+		return entities.Event{}, err
+	}
+
+	events := []entities.Event{event}
+	addLinksToevents(events)
+	return events[0], nil
+}
+
+// validateGrupyEventLink checks that the galery event galeryID may be linked to a Grupy event
+// The Grupy event must exist and must not have another galery event. An empty grupyEventID unlinks
+func (s *server) validateGrupyEventLink(ctx context.Context, galeryID, grupyEventID string) (*entities.Event, error) {
+	if grupyEventID == "" {
+		return nil, nil
+	}
+
+	grupyEvent, err := s.getGrupyEvent(ctx, grupyEventID)
+	if errors.Is(err, customerrors.ErrNotFound) {
+		return nil, fmt.Errorf("%w: grupy event %s does not exist", customerrors.ErrValidation, grupyEventID)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to validate grupy event %s: %w", grupyEventID, err)
+	}
+
+	linked, err := s.db.GetGaleryEventByGrupyEventID(ctx, grupyEventID)
+	switch {
+	case err == nil && linked.ID != galeryID:
+		return nil, fmt.Errorf("%w: grupy event %s is already linked to galery event %s", customerrors.ErrConflict, grupyEventID, linked.ID)
+	case err != nil && !errors.Is(err, customerrors.ErrNotFound):
+		return nil, err
+	}
+
+	return &grupyEvent, nil
+}
+
+// withGrupyEvent embeds the details of the Grupy event linked to a galery event
+// Best effort: galery events are still served while the events API is unavailable
+func (s *server) withGrupyEvent(ctx context.Context, event entities.GaleryEvent) entities.GaleryEvent {
+	if event.GrupyEventID == "" {
+		return event
+	}
+
+	grupyEvent, err := s.getGrupyEvent(ctx, event.GrupyEventID)
+	if err != nil {
+		return event
+	}
+
+	event.GrupyEvent = &grupyEvent
+	return event
+}
+
+// withGrupyEvents embeds the linked Grupy event details in a list of galery events
+// Each Grupy event is fetched once, failed fetches are left out
+func (s *server) withGrupyEvents(ctx context.Context, events []entities.GaleryEvent) []entities.GaleryEvent {
+	fetched := make(map[string]*entities.Event)
+	for i, event := range events {
+		if event.GrupyEventID == "" {
+			continue
+		}
+
+		grupyEvent, ok := fetched[event.GrupyEventID]
+		if !ok {
+			if found, err := s.getGrupyEvent(ctx, event.GrupyEventID); err == nil {
+				grupyEvent = &found
+			}
+			fetched[event.GrupyEventID] = grupyEvent
+		}
+		events[i].GrupyEvent = grupyEvent
+	}
+	return events
+}
@@ -0,0 +1,243 @@
+package server
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"mime"
+	"path"
+	"sort"
+	"time"
+
+	"backend/internal/entities"
+	customerrors "backend/internal/platform/errors"
+	"backend/internal/platform/feed"
+)
+
+// =======================
+// FEEDS
+// =======================
+
+const (
+	// defaultSiteURL is the public site linked from feeds
+	defaultSiteURL = "https://site-grupy.vercel.app"
+
+	// feedSize is the number of newest items published in a feed
+	feedSize = 50
+
+	// feedTagPrefix prefixes the tag URIs identifying feeds and their items (RFC 4151)
+	feedTagPrefix = "tag:grupysanca.com.br,2025:"
+
+	// feedAuthor is the author of every feed
+	feedAuthor = "Grupy Sanca"
+)
+
+// GetFeed renders a feed of the newest content of the site
+// Only public content is published, feeds are cached and relayed by readers
+func (s *server) GetFeed(ctx context.Context, kind entities.FeedKind, format entities.FeedFormat) (entities.RenderedFeed, error) {
+	var (
+		f   feed.Feed
+		err error
+	)
+
+	switch kind {
+	case entities.FeedTimeline:
+		f, err = s.timelineFeed(ctx)
+	case entities.FeedGalery:
+		f, err = s.galeryFeed(ctx)
+	case entities.FeedEvents:
+		f, err = s.eventsFeed(ctx)
+	default:
+		return entities.RenderedFeed{}, fmt.Errorf("%w: feed %q not found", customerrors.ErrNotFound, kind)
+	}
+	if err != nil {
+		return entities.RenderedFeed{}, err
+	}
+
+	// The newest item dates the feed, a fixed date keeps empty feeds cacheable
+	f.Updated = time.Unix(0, 0).UTC()
+	for _, item := range f.Items {
+		if item.Updated.After(f.Updated) {
+			f.Updated = item.Updated
+		}
+	}
+
+	var buf bytes.Buffer
+	switch format {
+	case entities.FeedAtom:
+		err = feed.EncodeAtom(&buf, f)
+	case entities.FeedRSS:
+		err = feed.EncodeRSS(&buf, f)
+	default:
+		return entities.RenderedFeed{}, fmt.Errorf("%w: feed format %q not found", customerrors.ErrNotFound, format)
+	}
+	if err != nil {
+		return entities.RenderedFeed{}, err
+	}
+
+	sum := sha256.Sum256(buf.Bytes())
+	return entities.RenderedFeed{
+		Format:  format,
+		Content: buf.Bytes(),
+		ETag:    `"` + hex.EncodeToString(sum[:16]) + `"`,
+		ModTime: f.Updated.Truncate(time.Second),
+	}, nil
+}
+
+// timelineFeed lists the newest history entries
+func (s *server) timelineFeed(ctx context.Context) (feed.Feed, error) {
+	entries, err := s.db.ListTimelineEntries(ctx)
+	if err != nil {
+		return feed.Feed{}, err
+	}
+	entries = publishedTimelineEntries(entries)
+
+	sort.SliceStable(entries, func(i, j int) bool {
+		return entries[i].CreatedAt.After(entries[j].CreatedAt)
+	})
+	entries = entries[:min(len(entries), feedSize)]
+
+	f := feed.Feed{
+		ID:          feedTagPrefix + "feeds/timeline",
+		Title:       "Grupy Sanca - História",
+		Description: "Novos marcos da história do Grupy Sanca",
+		Link:        s.siteURL + "/historia",
+		Author:      feedAuthor,
+		Items:       make([]feed.Item, 0, len(entries)),
+	}
+
+	for _, entry := range entries {
+		f.Items = append(f.Items, feed.Item{
+			ID:        feedTagPrefix + "timeline/" + entry.ID,
+			Title:     entry.Name,
+			Link:      s.siteURL + "/historia#" + entry.ID,
+			Summary:   entry.Text,
+			Published: entry.CreatedAt,
+			Updated:   entry.UpdatedAt,
+		})
+	}
+
+	return f, nil
+}
+
+// galeryFeed lists the newest public albums with their cover image as enclosure
+func (s *server) galeryFeed(ctx context.Context) (feed.Feed, error) {
+	events, err := s.db.ListGaleryEvents(ctx)
+	if err != nil {
+		return feed.Feed{}, err
+	}
+
+	public := make([]entities.GaleryEvent, 0, len(events))
+	for _, event := range events {
+		if !event.Private {
+			public = append(public, event)
+		}
+	}
+	sort.SliceStable(public, func(i, j int) bool {
+		return public[i].CreatedAt.After(public[j].CreatedAt)
+	})
+	public = public[:min(len(public), feedSize)]
+
+	f := feed.Feed{
+		ID:          feedTagPrefix + "feeds/galery",
+		Title:       "Grupy Sanca - Galeria",
+		Description: "Novos álbuns de fotos dos eventos do Grupy Sanca",
+		Link:        s.siteURL + "/galeria",
+		Author:      feedAuthor,
+		Items:       make([]feed.Item, 0, len(public)),
+	}
+
+	for _, event := range public {
+		summary := fmt.Sprintf("%s, %s - %d fotos", event.Location, event.Date.Format("02/01/2006"), len(event.Items))
+
+		f.Items = append(f.Items, feed.Item{
+			ID:        feedTagPrefix + "galery/" + event.ID,
+			Title:     event.Name,
+			Link:      s.siteURL + "/galeria#" + event.ID,
+			Summary:   summary,
+			Published: event.CreatedAt,
+			Updated:   event.UpdatedAt,
+			Enclosure: s.coverEnclosure(ctx, event),
+		})
+	}
+
+	return f, nil
+}
+
+// coverEnclosure describes the cover image of a public galery event
+// Best effort: the type is guessed from the file extension and the length is left unknown when the object can't be read
+func (s *server) coverEnclosure(ctx context.Context, event entities.GaleryEvent) *feed.Enclosure {
+	cover := event.Cover()
+	if cover < 0 {
+		return nil
+	}
+
+	item := event.Items[cover]
+	enclosure := &feed.Enclosure{
+		URL:  item.ImageURL,
+		Type: mime.TypeByExtension(path.Ext(s.obj.KeyFromURL(item.ImageURL))),
+	}
+
+	if info, err := s.obj.StatObject(ctx, s.obj.KeyFromURL(item.ImageURL)); err == nil {
+		enclosure.Length = info.Size
+		if info.ContentType != "" {
+			enclosure.Type = info.ContentType
+		}
+	}
+	if enclosure.Type == "" {
+		enclosure.Type = "image/jpeg"
+	}
+
+	return enclosure
+}
+
+// eventsFeed lists the upcoming community events, the soonest first
+func (s *server) eventsFeed(ctx context.Context) (feed.Feed, error) {
+	page, err := s.GetEvents(ctx, entities.EventQuery{
+		Limit:    feedSize,
+		OrderBy:  "starts-at",
+		Upcoming: true,
+	})
+	if err != nil {
+		return feed.Feed{}, err
+	}
+	events := page.Events
+
+	f := feed.Feed{
+		ID:          feedTagPrefix + "feeds/events",
+		Title:       "Grupy Sanca - Próximos eventos",
+		Description: "Próximos eventos do Grupy Sanca",
+		Link:        grupyBaseEventsWebPageURL,
+		Author:      feedAuthor,
+		Items:       make([]feed.Item, 0, len(events)),
+	}
+
+	for _, event := range events {
+		// The events API has no update date, an event is dated by its creation
+		published := event.CreatedAt
+		if published.IsZero() {
+			published = event.StartsAt
+		}
+
+		summary := event.StartsAt.In(eventLocation(event)).Format("02/01/2006 15:04")
+		if event.LocationName != "" {
+			summary += " - " + event.LocationName
+		}
+		if description := htmlToText(event.Description); description != "" {
+			summary += "\n\n" + description
+		}
+
+		f.Items = append(f.Items, feed.Item{
+			ID:        feedTagPrefix + "events/" + event.ID,
+			Title:     event.Name,
+			Link:      event.Link,
+			Summary:   summary,
+			Published: published,
+			Updated:   published,
+		})
+	}
+
+	return f, nil
+}
@@ -0,0 +1,333 @@
+package server
+
+import (
+	"archive/zip"
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"hash/crc32"
+	"io"
+	"path"
+	"sync"
+	"time"
+
+	"backend/internal/entities"
+)
+
+// =======================
+// GALERY EVENT ARCHIVE
+// =======================
+
+const (
+	// archiveFetchConcurrency bounds the objects downloaded at the same time, and so the ones held in memory
+	archiveFetchConcurrency = 4
+
+	// archiveStatConcurrency bounds the objects looked up at the same time to size an archive
+	archiveStatConcurrency = 8
+
+	// archiveManifestName is the name of the manifest file inside galery archives
+	archiveManifestName = "manifest.json"
+)
+
+// archiveManifest lists the photos of a galery archive along with their captions and credits
+type archiveManifest struct {
+	Name     string                `json:"name"`
+	Location string                `json:"location"`
+	Date     time.Time             `json:"date"`
+	Items    []archiveManifestItem `json:"items"`
+}
+
+type archiveManifestItem struct {
+	File    string `json:"file"`
+	ImageID string `json:"image_id"`
+	Caption string `json:"caption,omitempty"`
+	Credit  string `json:"credit,omitempty"`
+	AltText string `json:"alt_text,omitempty"`
+	Cover   bool   `json:"cover,omitempty"`
+}
+
+// GetGaleryEventArchive describes the ZIP archive of the original images of a galery event
+// Nothing is downloaded, object sizes are enough to know the exact size of the archive
+func (s *server) GetGaleryEventArchive(ctx context.Context, id string) (entities.Archive, error) {
+	event, err := s.db.GetGaleryEventByID(ctx, id)
+	if err != nil {
+		return entities.Archive{}, err
+	}
+	if event, err = s.galeryEventForReader(ctx, event); err != nil {
+		return entities.Archive{}, err
+	}
+
+	archive := entities.Archive{
+		Name:    fmt.Sprintf("%s.zip", normalizeSlug(event.Name)),
+		ModTime: event.UpdatedAt.UTC().Truncate(time.Second),
+	}
+
+	manifest := archiveManifest{
+		Name:     event.Name,
+		Location: event.Location,
+		Date:     event.Date,
+		Items:    make([]archiveManifestItem, 0, len(event.Items)),
+	}
+
+	keys := make([]string, len(event.Items))
+	for i, item := range event.Items {
+		keys[i] = s.obj.KeyFromURL(item.ImageURL)
+	}
+	infos, err := s.statArchiveObjects(ctx, event.Items, keys)
+	if err != nil {
+		return entities.Archive{}, err
+	}
+
+	cover := event.Cover()
+	for i, item := range event.Items {
+		key, info := keys[i], infos[i]
+
+		ext := path.Ext(key)
+		if ext == "" {
+			ext = ".jpg"
+		}
+		name := fmt.Sprintf("%03d-%s%s", i+1, item.ImageID, ext)
+
+		archive.Files = append(archive.Files, entities.ArchiveFile{
+			Name:       name,
+			Key:        key,
+			Size:       info.Size,
+			Generation: info.Generation,
+			CRC32:      info.CRC32,
+		})
+		manifest.Items = append(manifest.Items, archiveManifestItem{
+			File:    name,
+			ImageID: item.ImageID,
+			Caption: item.Caption,
+			Credit:  item.Credit,
+			AltText: item.AltText,
+			Cover:   i == cover,
+		})
+	}
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return entities.Archive{}, fmt.Errorf("failed to encode manifest: %w", err)
+	}
+	archive.Files = append(archive.Files, entities.ArchiveFile{
+		Name:    archiveManifestName,
+		Size:    int64(len(manifestJSON)),
+		Content: manifestJSON,
+	})
+
+	// Dry run over zeroed content, the ZIP layout only depends on names and sizes
+	counter := &countingWriter{}
+	err = writeZip(counter, archive, func(i int) (io.Reader, uint32, error) {
+		return io.LimitReader(zeroReader{}, archive.Files[i].Size), 0, nil
+	}, func(i int) {
+		archive.Files[i].End = counter.n
+	})
+	if err != nil {
+		return entities.Archive{}, fmt.Errorf("failed to size archive: %w", err)
+	}
+	archive.Size = counter.n
+	archive.ETag = archiveETag(event.ID, archive)
+
+	return archive, nil
+}
+
+// statArchiveObjects looks up the objects of the items of a galery event, at most archiveStatConcurrency at a time
+// Results keep the order of keys, the first failure cancels the remaining lookups and is returned
+func (s *server) statArchiveObjects(ctx context.Context, items []entities.GaleryItem, keys []string) ([]entities.ObjectInfo, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	infos := make([]entities.ObjectInfo, len(keys))
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+	)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(archiveStatConcurrency, len(keys)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				info, err := s.obj.StatObject(ctx, keys[i])
+				if err != nil {
+					mu.Lock()
+					if firstErr == nil {
+						firstErr = fmt.Errorf("failed to find image %s: %w", items[i].ImageID, err)
+						cancel()
+					}
+					mu.Unlock()
+					continue
+				}
+				infos[i] = info
+			}
+		}()
+	}
+
+feed:
+	for i := range keys {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr != nil {
+		return nil, firstErr
+	}
+	if err := ctx.Err(); err != nil {
+		// The caller gave up before every object was looked up
+		return nil, err
+	}
+	return infos, nil
+}
+
+// WriteArchive streams a ZIP archive to w, from is the offset of the first byte the caller keeps
+// Objects are downloaded ahead with bounded parallelism and written in order, so at most
+// archiveFetchConcurrency objects are held in memory at a time. Objects whose content ends before from
+// and whose checksum is known aren't downloaded: zeros take their place, the caller discards them anyway
+func (s *server) WriteArchive(ctx context.Context, archive entities.Archive, w io.Writer, from int64) error {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type fetched struct {
+		data []byte
+		err  error
+	}
+
+	skipped := func(file entities.ArchiveFile) bool {
+		return file.Key != "" && file.CRC32 != 0 && file.End <= from
+	}
+
+	results := make([]chan fetched, len(archive.Files))
+	for i := range results {
+		results[i] = make(chan fetched, 1)
+	}
+
+	slots := make(chan struct{}, archiveFetchConcurrency)
+	go func() {
+		for i, file := range archive.Files {
+			if file.Key == "" || skipped(file) {
+				continue
+			}
+
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			go func(i int, key string) {
+				data, err := s.obj.GetObject(ctx, key)
+				results[i] <- fetched{data: data, err: err}
+			}(i, file.Key)
+		}
+	}()
+
+	return writeZip(w, archive, func(i int) (io.Reader, uint32, error) {
+		file := archive.Files[i]
+		if file.Key == "" {
+			return bytes.NewReader(file.Content), crc32.ChecksumIEEE(file.Content), nil
+		}
+		if skipped(file) {
+			return io.LimitReader(zeroReader{}, file.Size), file.CRC32, nil
+		}
+
+		select {
+		case result := <-results[i]:
+			<-slots
+			if result.err != nil {
+				return nil, 0, fmt.Errorf("failed to download %s: %w", file.Name, result.err)
+			}
+			return bytes.NewReader(result.data), crc32.ChecksumIEEE(result.data), nil
+		case <-ctx.Done():
+			return nil, 0, ctx.Err()
+		}
+	}, nil)
+}
+
+// writeZip writes the files of an archive as stored (uncompressed) ZIP entries
+// Photos are already compressed, storing them keeps the layout predictable and the CPU idle
+// open gives the content of a file along with its checksum, written in the headers ahead of the content so
+// the layout doesn't depend on it. written, when set, is called once the content of a file is flushed to w
+func writeZip(w io.Writer, archive entities.Archive, open func(i int) (io.Reader, uint32, error), written func(i int)) error {
+	zw := zip.NewWriter(w)
+
+	for i, file := range archive.Files {
+		content, checksum, err := open(i)
+		if err != nil {
+			return err
+		}
+
+		header := &zip.FileHeader{
+			Name:               file.Name,
+			Method:             zip.Store,
+			CreatorVersion:     20,
+			ReaderVersion:      20,
+			CRC32:              checksum,
+			CompressedSize64:   uint64(file.Size),
+			UncompressedSize64: uint64(file.Size),
+		}
+		// Raw entries don't derive the MS-DOS time from Modified
+		header.SetModTime(archive.ModTime)
+
+		fw, err := zw.CreateRaw(header)
+		if err != nil {
+			return err
+		}
+
+		n, err := io.Copy(fw, content)
+		if err != nil {
+			return err
+		}
+		if n != file.Size {
+			return fmt.Errorf("%s changed since the archive was prepared: expected %d bytes, got %d", file.Name, file.Size, n)
+		}
+
+		if written != nil {
+			if err := zw.Flush(); err != nil {
+				return err
+			}
+			written(i)
+		}
+	}
+
+	return zw.Close()
+}
+
+// archiveETag derives a strong ETag from everything that shapes the archive bytes
+func archiveETag(id string, archive entities.Archive) string {
+	h := sha256.New()
+	fmt.Fprintf(h, "%s\n%d\n", id, archive.ModTime.Unix())
+	for _, file := range archive.Files {
+		fmt.Fprintf(h, "%s\n%s\n%d\n%d\n", file.Name, file.Key, file.Size, file.Generation)
+		h.Write(file.Content)
+	}
+	return `"` + hex.EncodeToString(h.Sum(nil)[:16]) + `"`
+}
+
+// countingWriter discards what it is given and counts the bytes
+type countingWriter struct {
+	n int64
+}
+
+func (c *countingWriter) Write(p []byte) (int, error) {
+	c.n += int64(len(p))
+	return len(p), nil
+}
+
+// zeroReader is an endless source of zero bytes
+type zeroReader struct{}
+
+func (zeroReader) Read(p []byte) (int, error) {
+	clear(p)
+	return len(p), nil
+}
@@ -4,9 +4,13 @@ import (
 	"context"
 	"encoding/base64"
 	"fmt"
+	"slices"
+	"sync"
 	"time"
 
 	"backend/internal/entities"
+	"backend/internal/platform/auth"
+	customerrors "backend/internal/platform/errors"
 
 	"github.com/google/uuid"
 )
@@ -16,83 +20,182 @@ import (
 // =======================
 
 // CreateGaleryEvent uploads images to object storage, creates image documents, and creates a galery event
-// This method is transactional: if any image upload fails, the entire operation fails
-func (s *server) CreateGaleryEvent(ctx context.Context, name, location string, date time.Time, imagesBase64 []string) (entities.GaleryEvent, error) {
+// Images are uploaded in parallel by a bounded pool of workers and keep the order they were given in
+// This method is transactional: the first failed upload cancels the others and every created image is deleted
+// Images with the same content as an existing one are handled according to onDuplicate
+func (s *server) CreateGaleryEvent(ctx context.Context, event entities.GaleryEvent, imagesBase64 []string, onDuplicate entities.DuplicatePolicy) (entities.GaleryEvent, error) {
+	imagesData, err := decodeGaleryImages(event, imagesBase64)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	event.CreatedBy = auth.ActorFromContext(ctx)
+
+	savedEvent, err := s.createGaleryEvent(ctx, event, imagesData, onDuplicate, nil)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	return s.withSignedImageURLs(ctx, savedEvent)
+}
+
+// decodeGaleryImages validates a new galery event and decodes its images
+// Every image is decoded before uploading anything, so bad input never needs a rollback
+func decodeGaleryImages(event entities.GaleryEvent, imagesBase64 []string) ([][]byte, error) {
 	// Validate inputs
-	if name == "" {
-		return entities.GaleryEvent{}, fmt.Errorf("name is required")
+	if event.Name == "" {
+		return nil, fmt.Errorf("name is required")
 	}
-	if location == "" {
-		return entities.GaleryEvent{}, fmt.Errorf("location is required")
+	if event.Location == "" {
+		return nil, fmt.Errorf("location is required")
 	}
-	if date.IsZero() {
-		return entities.GaleryEvent{}, fmt.Errorf("date is required")
+	if event.Date.IsZero() {
+		return nil, fmt.Errorf("date is required")
 	}
 	if len(imagesBase64) == 0 {
-		return entities.GaleryEvent{}, fmt.Errorf("at least one image is required")
+		return nil, fmt.Errorf("at least one image is required")
 	}
 
-	// Upload all images to object storage and create image documents
-	imageURLs := make([]string, 0, len(imagesBase64))
-	createdImageIDs := make([]string, 0, len(imagesBase64)) // Track created image IDs for rollback
-
+	imagesData := make([][]byte, len(imagesBase64))
 	for i, base64Image := range imagesBase64 {
-		// Decode base64 image
 		imageData, err := base64.StdEncoding.DecodeString(base64Image)
 		if err != nil {
-			// Rollback: delete all previously uploaded images and image documents
-			s.rollbackGaleryEventCreation(ctx, createdImageIDs)
-			return entities.GaleryEvent{}, fmt.Errorf("failed to decode image %d: %w", i, err)
-		}
-
-		// Generate unique key for image in object storage
-		imageSlug := fmt.Sprintf("galery_events/%s/%s_%d", uuid.New().String(), time.Now().Format("20060102"), i)
-		// Create an Image document in Firestore for this photo
-		imageMeta := entities.Image{
-			Slug:     imageSlug,
-			Name:     fmt.Sprintf("%s - Foto %d", name, i+1),
-			Text:     fmt.Sprintf("Imagem do evento: %s", name),
-			Date:     date,
-			Location: location,
+			return nil, fmt.Errorf("failed to decode image %d: %w", i, err)
 		}
+		imagesData[i] = imageData
+	}
+	return imagesData, nil
+}
 
-		createdImage, err := s.UploadImage(ctx, imageMeta, imageData)
-		if err != nil {
-			// Rollback: delete all previously uploaded images and image documents
-			s.rollbackGaleryEventCreation(ctx, createdImageIDs)
-			return entities.GaleryEvent{}, fmt.Errorf("failed to create image document %d: %w", i, err)
-		}
+// createGaleryEvent uploads decoded images and saves the galery event using them
+// The creator of the event is the creator of its images, ctx has no caller when a job runs it
+// onUploaded, when set, is called with the number of images uploaded so far
+func (s *server) createGaleryEvent(ctx context.Context, event entities.GaleryEvent, imagesData [][]byte, onDuplicate entities.DuplicatePolicy, onUploaded func(done int)) (entities.GaleryEvent, error) {
+	grupyEvent, err := s.validateGrupyEventLink(ctx, "", event.GrupyEventID)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
 
-		imageURLs = append(imageURLs, createdImage.ObjectURL)
-		createdImageIDs = append(createdImageIDs, createdImage.ID)
+	// Upload all images to object storage and create image documents
+	uploads, err := s.uploadGaleryImages(ctx, event, imagesData, onDuplicate, onUploaded)
+	if err != nil {
+		return entities.GaleryEvent{}, err
 	}
 
-	// Create galery event entity
-	galeryEvent := entities.GaleryEvent{
-		Name:      name,
-		Location:  location,
-		Date:      date,
-		ImageURLs: imageURLs,
-		ImageIDs:  createdImageIDs,
+	// Attach uploaded images to the galery event entity
+	// Reused images may repeat an image of the same request, each image is attached once
+	event.LastUpdatedBy = event.CreatedBy
+	event.Items = make([]entities.GaleryItem, 0, len(uploads))
+	event.CreatedImageIDs = make([]string, 0, len(uploads))
+	for _, upload := range uploads {
+		if slices.ContainsFunc(event.Items, func(item entities.GaleryItem) bool { return item.ImageID == upload.image.ID }) {
+			continue
+		}
+		event.Items = append(event.Items, entities.GaleryItemFromImage(upload.image))
+		if !upload.reused {
+			event.CreatedImageIDs = append(event.CreatedImageIDs, upload.image.ID)
+		}
 	}
 
 	// Save galery event to database
-	savedEvent, err := s.db.CreateGaleryEvent(ctx, galeryEvent)
+	savedEvent, err := s.db.CreateGaleryEvent(ctx, event)
 	if err != nil {
 		// Rollback: delete all uploaded images and image documents
-		s.rollbackGaleryEventCreation(ctx, createdImageIDs)
+		s.rollbackGaleryEventCreation(ctx, uploads)
 		return entities.GaleryEvent{}, fmt.Errorf("failed to save galery event to database: %w", err)
 	}
+	s.audit(ctx, entities.AuditCreate, entities.AuditGaleryEvent, savedEvent.ID, nil, savedEvent)
 
+	savedEvent.GrupyEvent = grupyEvent
 	return savedEvent, nil
 }
 
-// rollbackGaleryEventCreation deletes uploaded images and image documents in case of failure
-func (s *server) rollbackGaleryEventCreation(ctx context.Context, imageIDs []string) {
-	// Delete created image documents from Firestore
-	for _, imageID := range imageIDs {
+// galeryImageUpload is the outcome of uploading one image of a galery event
+type galeryImageUpload struct {
+	image  entities.Image
+	reused bool // Reused images belong to someone else and are never rolled back
+	err    error
+}
+
+// uploadGaleryImages uploads the images of a galery event with at most galeryUploadConcurrency uploads at a time
+// Results keep the order of imagesData. On the first failure the remaining uploads are cancelled,
+// the images created so far are deleted and the failure is returned
+func (s *server) uploadGaleryImages(ctx context.Context, event entities.GaleryEvent, imagesData [][]byte, onDuplicate entities.DuplicatePolicy, onUploaded func(done int)) ([]galeryImageUpload, error) {
+	uploadCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	uploads := make([]galeryImageUpload, len(imagesData))
+
+	var (
+		mu       sync.Mutex
+		firstErr error
+		done     int
+	)
+
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for range min(s.galeryUploadConcurrency, len(imagesData)) {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				// Create an Image document in Firestore for this photo
+				imageMeta := galeryImageMeta(event, i, entities.Image{CreatedBy: event.CreatedBy})
+
+				img, reused, err := s.uploadImage(uploadCtx, imageMeta, imagesData[i], onDuplicate)
+				uploads[i] = galeryImageUpload{image: img, reused: reused, err: err}
+
+				mu.Lock()
+				switch {
+				case err != nil && firstErr == nil:
+					firstErr = fmt.Errorf("failed to create image document %d: %w", i, err)
+					cancel()
+				case err == nil && onUploaded != nil:
+					done++
+					onUploaded(done)
+				}
+				mu.Unlock()
+			}
+		}()
+	}
+
+feed:
+	for i := range imagesData {
+		select {
+		case jobs <- i:
+		case <-uploadCtx.Done():
+			break feed
+		}
+	}
+	close(jobs)
+	wg.Wait()
+
+	if firstErr == nil && uploadCtx.Err() != nil {
+		// The caller gave up before every image was handed out
+		firstErr = fmt.Errorf("galery upload cancelled: %w", ctx.Err())
+	}
+
+	if firstErr != nil {
+		// Rollback: delete all uploaded images and image documents
+		s.rollbackGaleryEventCreation(ctx, uploads)
+		return nil, firstErr
+	}
+
+	return uploads, nil
+}
+
+// rollbackGaleryEventCreation deletes the images created for a galery event, objects included
+// It runs even if ctx was cancelled, which is usually why a rollback is needed
+func (s *server) rollbackGaleryEventCreation(ctx context.Context, uploads []galeryImageUpload) {
+	ctx = context.WithoutCancel(ctx)
+
+	for _, upload := range uploads {
+		if upload.err != nil || upload.reused || upload.image.ID == "" {
+			continue
+		}
+
 		// Best effort deletion - log errors but don't fail
-		if err := s.db.DeleteImageMeta(ctx, imageID); err != nil {
+		if err := s.DeleteImage(ctx, upload.image.ID); err != nil {
 			// In production, you might want to log this error
 			// For now, we silently continue
 			_ = err
@@ -100,33 +203,236 @@ func (s *server) rollbackGaleryEventCreation(ctx context.Context, imageIDs []str
 	}
 }
 
-// GetGaleryEventByID retrieves a galery event by ID
+// GetGaleryEventByID retrieves a galery event by ID along with its linked Grupy event
 func (s *server) GetGaleryEventByID(ctx context.Context, id string) (entities.GaleryEvent, error) {
-	return s.db.GetGaleryEventByID(ctx, id)
+	event, err := s.db.GetGaleryEventByID(ctx, id)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	event, err = s.galeryEventForReader(ctx, event)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+	return s.withGrupyEvent(ctx, event), nil
 }
 
-// ListGaleryEvents retrieves all galery events, ordered by date descending
+// ListGaleryEvents retrieves all galery events, ordered by date descending, along with their linked Grupy events
 func (s *server) ListGaleryEvents(ctx context.Context) ([]entities.GaleryEvent, error) {
-	return s.db.ListGaleryEvents(ctx)
+	events, err := s.db.ListGaleryEvents(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	visible, err := s.galeryEventsForReader(ctx, events)
+	if err != nil {
+		return nil, err
+	}
+	return s.withGrupyEvents(ctx, visible), nil
 }
 
-// DeleteGaleryEvent deletes a galery event by ID
-// Note: This does NOT delete the associated images from object storage
+// DeleteGaleryEvent deletes a galery event by ID along with the images uploaded for it, objects included
+// Images reused from elsewhere, and uploaded images now shown by another galery event or a timeline entry, are kept
 func (s *server) DeleteGaleryEvent(ctx context.Context, id string) error {
-	event, err := s.GetGaleryEventByID(ctx, id)
+	event, err := s.db.GetGaleryEventByID(ctx, id)
 	if err != nil {
-		return fmt.Errorf("failed to get event by id %s", err)
+		return fmt.Errorf("failed to get event by id %w", err)
 	}
 
-	// best effort deletion of images associated with the event
+	// best effort deletion of images created for the event
 	for _, imageID := range event.ImageIDs {
+		if !slices.Contains(event.CreatedImageIDs, imageID) || s.isImageUsedElsewhere(ctx, imageID, id) {
+			continue
+		}
 		_ = s.DeleteImage(ctx, imageID)
 	}
 
-	err = s.db.DeleteGaleryEvent(ctx, id)
-	return err
+	if err := s.db.DeleteGaleryEvent(ctx, id); err != nil {
+		return err
+	}
+
+	s.audit(ctx, entities.AuditDelete, entities.AuditGaleryEvent, id, event, nil)
+	return nil
 }
 
+// isImageUsedElsewhere reports whether an image is shown by a timeline entry or a galery event other than eventID
+// Lookup errors are treated as used so images are never deleted by mistake
+func (s *server) isImageUsedElsewhere(ctx context.Context, imageID, eventID string) bool {
+	events, err := s.db.ListGaleryEventsByImageID(ctx, imageID)
+	if err != nil {
+		return true
+	}
+
+	for _, event := range events {
+		if event.ID != eventID {
+			return true
+		}
+	}
+
+	entries, err := s.db.ListTimelineEntriesByImageID(ctx, imageID)
+	return err != nil || len(entries) > 0
+}
+
+// ModifyGaleryEvent replaces the fields and items of a galery event, see mergeGaleryItems for updates of older clients
+// Item URLs and placeholders are refreshed from the Image documents, and the cover must be one of the items
+// An update without cover keeps the stored one while it is still an item
+// The Grupy event link is only replaced when the update sets LinksGrupyEvent, the new one must exist and have no other galery event
 func (s *server) ModifyGaleryEvent(ctx context.Context, id string, newEvent entities.GaleryEvent) (entities.GaleryEvent, error) {
-	return s.db.ModifyGaleryEvent(ctx, id, newEvent)
+	before, err := s.db.GetGaleryEventByID(ctx, id)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	var grupyEvent *entities.Event
+	if newEvent.LinksGrupyEvent {
+		grupyEvent, err = s.validateGrupyEventLink(ctx, id, newEvent.GrupyEventID)
+		if err != nil {
+			return entities.GaleryEvent{}, err
+		}
+	} else {
+		newEvent.GrupyEventID = before.GrupyEventID
+	}
+
+	newEvent.Items = s.galeryItemsWithImages(ctx, mergeGaleryItems(before, newEvent))
+	newEvent.LastUpdatedBy = auth.ActorFromContext(ctx)
+
+	containsImage := func(imageID string) bool {
+		return slices.ContainsFunc(newEvent.Items, func(item entities.GaleryItem) bool { return item.ImageID == imageID })
+	}
+	if newEvent.CoverImageID == "" && containsImage(before.CoverImageID) {
+		newEvent.CoverImageID = before.CoverImageID
+	}
+	if newEvent.CoverImageID != "" && !containsImage(newEvent.CoverImageID) {
+		return entities.GaleryEvent{}, fmt.Errorf("%w: cover image %s is not in the galery event", customerrors.ErrValidation, newEvent.CoverImageID)
+	}
+
+	modified, err := s.db.ModifyGaleryEvent(ctx, id, newEvent)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+	s.audit(ctx, entities.AuditUpdate, entities.AuditGaleryEvent, id, before, modified)
+
+	if grupyEvent != nil {
+		modified.GrupyEvent = grupyEvent
+	} else {
+		modified = s.withGrupyEvent(ctx, modified)
+	}
+	return s.withSignedImageURLs(ctx, modified)
+}
+
+// mergeGaleryItems returns the items of a galery event after an update
+// Items given by the update replace the stored ones. Older clients only send image IDs and URLs,
+// the stored items of those images are kept as they are so their caption, credit and alt text survive
+// Updates without either keep the stored items
+func mergeGaleryItems(stored entities.GaleryEvent, update entities.GaleryEvent) []entities.GaleryItem {
+	if update.Items != nil {
+		return update.Items
+	}
+	if update.ImageIDs == nil {
+		return stored.Items
+	}
+
+	items := make([]entities.GaleryItem, 0, len(update.ImageIDs))
+	for i, imageID := range update.ImageIDs {
+		if j := slices.IndexFunc(stored.Items, func(item entities.GaleryItem) bool { return item.ImageID == imageID }); j >= 0 {
+			items = append(items, stored.Items[j])
+			continue
+		}
+
+		item := entities.GaleryItem{ImageID: imageID}
+		if i < len(update.ImageURLs) {
+			item.ImageURL = update.ImageURLs[i]
+		}
+		items = append(items, item)
+	}
+	return items
+}
+
+// galeryImageMeta fills the metadata of the image at index of a galery event
+// Fields already set in meta are kept, the visibility always follows the event
+func galeryImageMeta(event entities.GaleryEvent, index int, meta entities.Image) entities.Image {
+	if meta.Slug == "" {
+		// Generate unique key for image in object storage
+		meta.Slug = fmt.Sprintf("galery_events/%s/%s_%d", uuid.New().String(), time.Now().Format("20060102"), index)
+	}
+	if meta.Name == "" {
+		meta.Name = fmt.Sprintf("%s - Foto %d", event.Name, index+1)
+	}
+	if meta.Text == "" {
+		meta.Text = fmt.Sprintf("Imagem do evento: %s", event.Name)
+	}
+	if meta.Date.IsZero() {
+		meta.Date = event.Date
+	}
+	if meta.Location == "" {
+		meta.Location = event.Location
+	}
+	meta.Private = event.Private
+	return meta
+}
+
+// AddGaleryEventImage uploads an image and appends it to an existing galery event as item
+// Missing metadata is derived from the event, and a newly created image is deleted again if it can't be attached
+func (s *server) AddGaleryEventImage(ctx context.Context, id string, item entities.GaleryItem, meta entities.Image, data []byte, onDuplicate entities.DuplicatePolicy) (entities.GaleryEvent, error) {
+	event, err := s.db.GetGaleryEventByID(ctx, id)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	img, reused, err := s.uploadImage(ctx, galeryImageMeta(event, len(event.ImageIDs), meta), data, onDuplicate)
+	if err != nil {
+		return entities.GaleryEvent{}, fmt.Errorf("failed to upload image: %w", err)
+	}
+
+	// Keep the caption, credit and alt text given for the item
+	fromImage := entities.GaleryItemFromImage(img)
+	item.ImageID, item.ImageURL, item.Placeholder = fromImage.ImageID, fromImage.ImageURL, fromImage.Placeholder
+
+	updated, err := s.db.AppendGaleryEventImage(ctx, id, item, !reused, auth.ActorFromContext(ctx))
+	if err != nil {
+		if !reused {
+			_ = s.DeleteImage(ctx, img.ID)
+		}
+		return entities.GaleryEvent{}, err
+	}
+	s.audit(ctx, entities.AuditUpdate, entities.AuditGaleryEvent, id, event, updated)
+
+	return s.withSignedImageURLs(ctx, updated)
+}
+
+// RemoveGaleryEventImage detaches an image from a galery event
+// When deleteImage is set the image itself is deleted too, unless another galery event or a timeline entry still shows it
+func (s *server) RemoveGaleryEventImage(ctx context.Context, id string, imageID string, deleteImage bool) (entities.GaleryEvent, error) {
+	before, err := s.db.GetGaleryEventByID(ctx, id)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	updated, err := s.db.RemoveGaleryEventImage(ctx, id, imageID, auth.ActorFromContext(ctx))
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+	s.audit(ctx, entities.AuditUpdate, entities.AuditGaleryEvent, id, before, updated)
+
+	// best effort deletion, the image is already detached
+	if deleteImage && !s.isImageUsedElsewhere(ctx, imageID, id) {
+		_ = s.DeleteImage(ctx, imageID)
+	}
+
+	return s.withSignedImageURLs(ctx, updated)
+}
+
+// ReorderGaleryEventImages sorts the images of a galery event in the given order
+func (s *server) ReorderGaleryEventImages(ctx context.Context, id string, imageIDs []string) (entities.GaleryEvent, error) {
+	before, err := s.db.GetGaleryEventByID(ctx, id)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	updated, err := s.db.ReorderGaleryEventImages(ctx, id, imageIDs, auth.ActorFromContext(ctx))
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+	s.audit(ctx, entities.AuditUpdate, entities.AuditGaleryEvent, id, before, updated)
+	return s.withSignedImageURLs(ctx, updated)
 }
@@ -1,11 +1,41 @@
 package server
 
 import (
+	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
+
+	"github.com/google/uuid"
+)
+
+const (
+	// maxImageSizeBytes is the largest image accepted by the service (10MB)
+	maxImageSizeBytes = 10 * 1024 * 1024
+
+	// uploadKeyPrefix is the object key prefix reserved for direct-to-bucket uploads
+	uploadKeyPrefix = "uploads/"
+
+	// uploadFinalizeGrace is how long a reserved upload may still be finalized after its signed URL expired
+	uploadFinalizeGrace = time.Hour
+
+	// defaultGaleryUploadConcurrency is the number of galery event images uploaded at the same time
+	defaultGaleryUploadConcurrency = 4
+
+	// maxBulkTagImages is the most images tagged in one request, Firestore commits at most 500 writes at once
+	maxBulkTagImages = 500
 )
 
+// allowedImageContentTypes maps the accepted image content types to their file extensions
+var allowedImageContentTypes = map[string]string{
+	"image/jpeg": ".jpg",
+	"image/png":  ".png",
+	"image/gif":  ".gif",
+	"image/webp": ".webp",
+}
+
 // normalizeSlug normalizes a slug by lowercasing, trimming, and replacing spaces with hyphens
 func normalizeSlug(slug string) string {
 	normalized := strings.TrimSpace(strings.ToLower(slug))
@@ -13,6 +43,30 @@ func normalizeSlug(slug string) string {
 	return normalized
 }
 
+// normalizeTag normalizes a tag name by lowercasing, trimming, and joining words with hyphens
+// e.g., "  Python Brasil " becomes "python-brasil"
+func normalizeTag(tag string) string {
+	return strings.Join(strings.Fields(strings.ToLower(tag)), "-")
+}
+
+// normalizeTags normalizes tag names, dropping empty and repeated ones and sorting the rest
+// A nil slice stays nil so patches can tell "keep the tags" apart from "remove all tags"
+func normalizeTags(tags []string) []string {
+	if tags == nil {
+		return nil
+	}
+
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		if tag = normalizeTag(tag); tag != "" {
+			normalized = append(normalized, tag)
+		}
+	}
+
+	slices.Sort(normalized)
+	return slices.Compact(normalized)
+}
+
 // generateObjectKey generates a unique object storage key for an image
 // Note: The base path (e.g., "images/") is handled by the gateway layer
 func generateObjectKey(slug string) string {
@@ -20,20 +74,18 @@ func generateObjectKey(slug string) string {
 	return fmt.Sprintf("%s-%d.jpg", normalizeSlug(slug), time.Now().Unix())
 }
 
-// extractKeyFromURL extracts the object storage key from a full URL
-func extractKeyFromURL(url string) string {
-	// Extract everything after the bucket name
-	// Example: https://storage.googleapis.com/bucket/images/sunset-123.jpg -> images/sunset-123.jpg
-	// Example: https://storage.googleapis.com/bucket/sunset-123.jpg -> sunset-123.jpg
-	parts := strings.Split(url, "/")
-	if len(parts) >= 5 {
-		// URL format: https://storage.googleapis.com/{bucket}/{path...}
-		// We want everything from index 4 onwards
-		return strings.Join(parts[4:], "/")
-	}
-	// Fallback: just get the filename
-	if len(parts) > 0 {
-		return parts[len(parts)-1]
+// generateUploadKey generates a unique object key reserved for a direct upload
+// Format: uploads/{uuid}/{slug}{ext} (base path is added by gateway)
+func generateUploadKey(slug, ext string) string {
+	name := normalizeSlug(slug)
+	if name == "" {
+		name = "image"
 	}
-	return ""
+	return fmt.Sprintf("%s%s/%s%s", uploadKeyPrefix, uuid.New().String(), name, ext)
+}
+
+// hashImageData returns the hex encoded SHA-256 of the image bytes
+func hashImageData(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
 }
@@ -3,9 +3,13 @@ package server
 import (
 	"context"
 	"fmt"
+	"slices"
+	"sort"
 	"time"
 
 	"backend/internal/entities"
+	"backend/internal/platform/auth"
+	customerrors "backend/internal/platform/errors"
 )
 
 // =======================
@@ -13,31 +17,64 @@ import (
 // =======================
 
 func (s *server) GetImageByID(ctx context.Context, id string) (entities.Image, error) {
-	return s.db.GetImageByID(ctx, id)
+	img, err := s.db.GetImageByID(ctx, id)
+	if err != nil {
+		return entities.Image{}, err
+	}
+	return s.imageForReader(ctx, img)
 }
 
 func (s *server) GetImagesBySlug(ctx context.Context, slug string) ([]entities.Image, error) {
 	normalized := normalizeSlug(slug)
-	return s.db.GetImagesBySlug(ctx, normalized)
+	images, err := s.db.GetImagesBySlug(ctx, normalized)
+	if err != nil {
+		return nil, err
+	}
+	return s.imagesForReader(ctx, images)
 }
 
 func (s *server) ListAllImages(ctx context.Context) ([]entities.Image, error) {
-	return s.db.ListAllImages(ctx)
+	images, err := s.db.ListAllImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+	return s.imagesForReader(ctx, images)
 }
 
-func (s *server) UploadImage(ctx context.Context, meta entities.Image, data []byte) (entities.Image, error) {
+func (s *server) UploadImage(ctx context.Context, meta entities.Image, data []byte, onDuplicate entities.DuplicatePolicy) (entities.Image, error) {
+	img, _, err := s.uploadImage(ctx, meta, data, onDuplicate)
+	return img, err
+}
+
+// uploadImage stores an image applying the duplicate policy and reports whether an existing image was reused
+func (s *server) uploadImage(ctx context.Context, meta entities.Image, data []byte, onDuplicate entities.DuplicatePolicy) (entities.Image, bool, error) {
 	// Business logic: generate object key with timestamp
 	key := generateObjectKey(meta.Slug)
 
 	// Validate image size (10MB limit)
-	if len(data) > 10*1024*1024 {
-		return entities.Image{}, fmt.Errorf("image too large: max 10MB")
+	if len(data) > maxImageSizeBytes {
+		return entities.Image{}, false, fmt.Errorf("image too large: max 10MB")
 	}
 
-	// Upload to object store
-	url, err := s.obj.PutObject(ctx, key, data)
+	meta.Tags = normalizeTags(meta.Tags)
+
+	// Look for an image with the same content before uploading
+	meta.SHA256 = hashImageData(data)
+	existing, found, err := s.findDuplicateImage(ctx, meta.SHA256, meta.Private, onDuplicate)
 	if err != nil {
-		return entities.Image{}, fmt.Errorf("upload failed: %w", err)
+		return entities.Image{}, false, err
+	}
+	if found {
+		reused, err := s.withSignedURL(ctx, existing)
+		return reused, true, err
+	}
+
+	applyPlaceholder(&meta, data)
+
+	// Upload to object store, skipping the public ACL for private images
+	url, err := s.putImageObject(ctx, key, data, meta.Private)
+	if err != nil {
+		return entities.Image{}, false, fmt.Errorf("upload failed: %w", err)
 	}
 
 	// Update entity with storage URL and audit fields
@@ -45,40 +82,80 @@ func (s *server) UploadImage(ctx context.Context, meta entities.Image, data []by
 	now := time.Now()
 	meta.CreatedAt = now
 	meta.UpdatedAt = now
+	if meta.CreatedBy == "" {
+		meta.CreatedBy = auth.ActorFromContext(ctx)
+	}
+	meta.LastUpdatedBy = meta.CreatedBy
 
 	// Persist metadata
 	created, err := s.db.CreateImageMeta(ctx, meta)
 	if err != nil {
-		// Rollback: delete uploaded object
-		_ = s.obj.DeleteObject(ctx, key)
-		return entities.Image{}, fmt.Errorf("db persist failed: %w", err)
+		// Rollback: delete uploaded object, even if the failure comes from a cancelled context
+		_ = s.obj.DeleteObject(context.WithoutCancel(ctx), key)
+		return entities.Image{}, false, fmt.Errorf("db persist failed: %w", err)
+	}
+
+	s.audit(ctx, entities.AuditCreate, entities.AuditImage, created.ID, nil, created)
+
+	signed, err := s.withSignedURL(ctx, created)
+	return signed, false, err
+}
+
+// findDuplicateImage looks up an image with the given content hash according to the duplicate policy
+// It returns the image to reuse, or a conflict error if duplicates are rejected.
+// Only images with the same visibility are duplicates, a private upload never returns a public image or the reverse
+func (s *server) findDuplicateImage(ctx context.Context, hash string, private bool, onDuplicate entities.DuplicatePolicy) (entities.Image, bool, error) {
+	switch onDuplicate {
+	case "", entities.DuplicateAllow:
+		return entities.Image{}, false, nil
+	case entities.DuplicateReuse, entities.DuplicateReject:
+	default:
+		return entities.Image{}, false, fmt.Errorf("%w: unknown duplicate policy %q", customerrors.ErrValidation, onDuplicate)
+	}
+
+	duplicates, err := s.db.GetImagesBySHA256(ctx, hash)
+	if err != nil {
+		return entities.Image{}, false, fmt.Errorf("failed to look up duplicate images: %w", err)
+	}
+	index := slices.IndexFunc(duplicates, func(img entities.Image) bool { return img.Private == private })
+	if index < 0 {
+		return entities.Image{}, false, nil
 	}
 
-	return created, nil
+	if onDuplicate == entities.DuplicateReject {
+		return entities.Image{}, false, fmt.Errorf("%w: image is a duplicate of image %s", customerrors.ErrConflict, duplicates[index].ID)
+	}
+	return duplicates[index], true, nil
 }
 
 func (s *server) UpdateImage(ctx context.Context, id string, meta entities.Image, data []byte) (entities.Image, error) {
+	// Validate size
+	if len(data) > maxImageSizeBytes {
+		return entities.Image{}, fmt.Errorf("image too large: max 10MB")
+	}
+
+	// Get existing image to keep its visibility, delete the old object and audit the changes
+	existing, err := s.db.GetImageByID(ctx, id)
+	if err != nil {
+		return entities.Image{}, err
+	}
+
 	// If new image data provided, upload it
 	if len(data) > 0 {
-		// Validate size
-		if len(data) > 10*1024*1024 {
-			return entities.Image{}, fmt.Errorf("image too large: max 10MB")
-		}
-
 		// Generate new key
 		key := generateObjectKey(meta.Slug)
 
 		// Upload new image
-		url, err := s.obj.PutObject(ctx, key, data)
+		url, err := s.putImageObject(ctx, key, data, existing.Private)
 		if err != nil {
 			return entities.Image{}, fmt.Errorf("upload failed: %w", err)
 		}
+		meta.SHA256 = hashImageData(data)
+		applyPlaceholder(&meta, data)
 
-		// Get existing image to delete old object
-		existing, err := s.db.GetImageByID(ctx, id)
-		if err == nil && existing.ObjectURL != "" {
+		if existing.ObjectURL != "" {
 			// Delete old object (best effort, don't fail if it errors)
-			_ = s.obj.DeleteObject(ctx, extractKeyFromURL(existing.ObjectURL))
+			_ = s.obj.DeleteObject(ctx, s.obj.KeyFromURL(existing.ObjectURL))
 		}
 
 		meta.ObjectURL = url
@@ -86,9 +163,56 @@ func (s *server) UpdateImage(ctx context.Context, id string, meta entities.Image
 
 	// Set audit fields
 	meta.UpdatedAt = time.Now()
+	meta.LastUpdatedBy = auth.ActorFromContext(ctx)
+	meta.Tags = normalizeTags(meta.Tags)
 
 	// Update metadata
-	return s.db.UpdateImageMeta(ctx, id, meta)
+	updated, err := s.db.UpdateImageMeta(ctx, id, meta)
+	if err != nil {
+		return entities.Image{}, err
+	}
+
+	s.audit(ctx, entities.AuditUpdate, entities.AuditImage, id, existing, updated)
+	return s.withSignedURL(ctx, updated)
+}
+
+// ListDuplicateImages groups images that share the same content hash
+// Images uploaded before hashes were recorded are not taken into account
+func (s *server) ListDuplicateImages(ctx context.Context) ([]entities.DuplicateCluster, error) {
+	images, err := s.db.ListAllImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	byHash := make(map[string][]entities.Image)
+	for _, img := range images {
+		if img.SHA256 == "" {
+			continue
+		}
+		signed, err := s.withSignedURL(ctx, img)
+		if err != nil {
+			return nil, err
+		}
+		byHash[img.SHA256] = append(byHash[img.SHA256], signed)
+	}
+
+	clusters := make([]entities.DuplicateCluster, 0)
+	for hash, group := range byHash {
+		if len(group) < 2 {
+			continue
+		}
+		clusters = append(clusters, entities.DuplicateCluster{SHA256: hash, Images: group})
+	}
+
+	// Biggest clusters first, hash as a stable tie breaker
+	sort.Slice(clusters, func(i, j int) bool {
+		if len(clusters[i].Images) != len(clusters[j].Images) {
+			return len(clusters[i].Images) > len(clusters[j].Images)
+		}
+		return clusters[i].SHA256 < clusters[j].SHA256
+	})
+
+	return clusters, nil
 }
 
 func (s *server) DeleteImage(ctx context.Context, id string) error {
@@ -102,11 +226,23 @@ func (s *server) DeleteImage(ctx context.Context, id string) error {
 	if err := s.db.DeleteImageMeta(ctx, id); err != nil {
 		return err
 	}
+	s.audit(ctx, entities.AuditDelete, entities.AuditImage, id, img, nil)
 
 	// Delete object from storage (best effort)
 	if img.ObjectURL != "" {
-		_ = s.obj.DeleteObject(ctx, extractKeyFromURL(img.ObjectURL))
+		_ = s.obj.DeleteObject(ctx, s.obj.KeyFromURL(img.ObjectURL))
 	}
 
+	// Drop the references of timeline entries (best effort, expanded entries skip missing images anyway)
+	_ = s.db.RemoveTimelineImage(ctx, id)
+
 	return nil
 }
+
+// putImageObject uploads image bytes, skipping the public ACL for private images
+func (s *server) putImageObject(ctx context.Context, key string, data []byte, private bool) (string, error) {
+	if private {
+		return s.obj.PutPrivateObject(ctx, key, data)
+	}
+	return s.obj.PutObject(ctx, key, data)
+}
@@ -0,0 +1,154 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+	"time"
+
+	"backend/internal/entities"
+	"backend/internal/platform/auth"
+	customerrors "backend/internal/platform/errors"
+)
+
+// =======================
+// DIRECT UPLOAD OPERATIONS
+// =======================
+
+// CreateImageUpload reserves an object key for the caller and returns a signed URL the client can PUT the image bytes to
+// Private uploads are signed without the public ACL
+func (s *server) CreateImageUpload(ctx context.Context, slug, contentType string, private bool) (entities.SignedUpload, error) {
+	ext, ok := allowedImageContentTypes[contentType]
+	if !ok {
+		return entities.SignedUpload{}, fmt.Errorf("%w: unsupported content type %q", customerrors.ErrValidation, contentType)
+	}
+
+	key := generateUploadKey(slug, ext)
+
+	upload, err := s.obj.SignedUploadURL(ctx, key, contentType, private)
+	if err != nil {
+		return entities.SignedUpload{}, fmt.Errorf("failed to sign upload URL: %w", err)
+	}
+
+	caller, _ := auth.PrincipalFromContext(ctx)
+	_, err = s.db.CreateUploadReservation(ctx, entities.UploadReservation{
+		Key:         key,
+		UID:         caller.UID,
+		ContentType: contentType,
+		ExpiresAt:   upload.ExpiresAt.Add(uploadFinalizeGrace),
+	})
+	if err != nil {
+		return entities.SignedUpload{}, fmt.Errorf("failed to reserve upload: %w", err)
+	}
+
+	return upload, nil
+}
+
+// FinalizeImageUpload checks that a directly uploaded object is a valid image and creates its metadata
+// Only the caller that reserved the key may finalize it, until the reservation expires. The first bytes
+// must be an image of the reserved content type. Objects that fail validation are deleted so the reserved
+// key can't be reused. The reservation is consumed in a transaction along with the creation of the image, so
+// finalizing a key again, e.g. on a client retry or concurrently, returns the image of the first call.
+// The duplicate policy applies like on UploadImage, the uploaded object is deleted when an existing image is reused
+func (s *server) FinalizeImageUpload(ctx context.Context, key string, meta entities.Image, onDuplicate entities.DuplicatePolicy) (entities.Image, error) {
+	if !strings.HasPrefix(key, uploadKeyPrefix) || strings.Contains(key, "..") {
+		return entities.Image{}, fmt.Errorf("%w: invalid upload key %q", customerrors.ErrValidation, key)
+	}
+
+	reservation, err := s.db.GetUploadReservation(ctx, key)
+	if err != nil {
+		return entities.Image{}, fmt.Errorf("failed to find upload reservation: %w", err)
+	}
+	if caller, _ := auth.PrincipalFromContext(ctx); caller.UID != reservation.UID {
+		return entities.Image{}, fmt.Errorf("%w: upload %s was reserved by another user", customerrors.ErrForbidden, key)
+	}
+
+	if reservation.ImageID != "" {
+		// A retry, the object may be gone if the first call reused a duplicate
+		finalized, err := s.db.GetImageByID(ctx, reservation.ImageID)
+		if err != nil {
+			return entities.Image{}, err
+		}
+		return s.withSignedURL(ctx, finalized)
+	}
+
+	info, err := s.obj.StatObject(ctx, key)
+	if err != nil {
+		return entities.Image{}, fmt.Errorf("failed to find uploaded object: %w", err)
+	}
+
+	if time.Now().After(reservation.ExpiresAt) {
+		_ = s.obj.DeleteObject(ctx, key)
+		return entities.Image{}, fmt.Errorf("%w: upload reservation of %s expired", customerrors.ErrValidation, key)
+	}
+
+	if info.Size == 0 || info.Size > maxImageSizeBytes {
+		_ = s.obj.DeleteObject(ctx, key)
+		return entities.Image{}, fmt.Errorf("%w: uploaded image must have between 1 byte and 10MB, got %d bytes", customerrors.ErrValidation, info.Size)
+	}
+
+	if info.ContentType != reservation.ContentType {
+		_ = s.obj.DeleteObject(ctx, key)
+		return entities.Image{}, fmt.Errorf("%w: uploaded content type %q doesn't match the reserved %q", customerrors.ErrValidation, info.ContentType, reservation.ContentType)
+	}
+
+	data, err := s.obj.GetObject(ctx, key)
+	if err != nil {
+		return entities.Image{}, fmt.Errorf("failed to read uploaded object: %w", err)
+	}
+
+	// The declared content type is chosen by the client, the bytes must back it up
+	if detected := http.DetectContentType(data); detected != reservation.ContentType {
+		_ = s.obj.DeleteObject(ctx, key)
+		return entities.Image{}, fmt.Errorf("%w: uploaded bytes are %q, not %q", customerrors.ErrValidation, detected, reservation.ContentType)
+	}
+
+	meta.SHA256 = hashImageData(data)
+	duplicate, found, err := s.findDuplicateImage(ctx, meta.SHA256, meta.Private, onDuplicate)
+	if err != nil {
+		if errors.Is(err, customerrors.ErrConflict) {
+			_ = s.obj.DeleteObject(ctx, key)
+		}
+		return entities.Image{}, err
+	}
+	if found {
+		reused, finalized, err := s.db.FinalizeUploadReservation(ctx, reservation.ID, duplicate)
+		if err != nil {
+			return entities.Image{}, fmt.Errorf("failed to finalize upload: %w", err)
+		}
+		if finalized {
+			// Only the call that consumed the reservation drops the object, a concurrent one may have used it
+			_ = s.obj.DeleteObject(ctx, key)
+		}
+		return s.withSignedURL(ctx, reused)
+	}
+
+	// Enforce the requested visibility, the upload may have been signed with a different one
+	if err := s.obj.SetObjectPrivate(ctx, key, meta.Private); err != nil {
+		return entities.Image{}, fmt.Errorf("failed to set visibility of uploaded object: %w", err)
+	}
+
+	applyPlaceholder(&meta, data)
+
+	// Update entity with storage URL and audit fields
+	meta.ObjectURL = info.URL
+	meta.Tags = normalizeTags(meta.Tags)
+	now := time.Now()
+	meta.CreatedAt = now
+	meta.UpdatedAt = now
+	meta.CreatedBy = auth.ActorFromContext(ctx)
+	meta.LastUpdatedBy = meta.CreatedBy
+
+	// The object is kept when this fails, the reservation stays open for a retry
+	created, finalized, err := s.db.FinalizeUploadReservation(ctx, reservation.ID, meta)
+	if err != nil {
+		return entities.Image{}, fmt.Errorf("db persist failed: %w", err)
+	}
+
+	if finalized {
+		s.audit(ctx, entities.AuditCreate, entities.AuditImage, created.ID, nil, created)
+	}
+	return s.withSignedURL(ctx, created)
+}
@@ -0,0 +1,349 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"backend/internal/entities"
+	"backend/internal/platform/auth"
+	customerrors "backend/internal/platform/errors"
+	"backend/internal/platform/importer"
+)
+
+// =======================
+// BULK IMPORT
+// =======================
+
+// Import reads the timeline entries or texts of an import file and upserts them
+// Texts are matched by slug and timeline entries by name and day, a record matching an existing item
+// overwrites the fields it fills. Every record is validated first: when any is invalid the report lists
+// the errors by line and nothing is written. Nothing is written either when dryRun is set
+//
+// Timeline fields: name, date, text (or the Markdown body), location, category, importance, image_ids, draft
+// Text fields: slug, content (or the Markdown body), page_id, page_slug
+func (s *server) Import(ctx context.Context, kind entities.ImportKind, format importer.Format, r io.Reader, dryRun bool) (entities.ImportReport, error) {
+	report := entities.ImportReport{Kind: kind, DryRun: dryRun}
+	if !kind.Valid() {
+		return report, fmt.Errorf("%w: unknown import kind %q, expected timeline or texts", customerrors.ErrValidation, kind)
+	}
+
+	records, err := importer.Decode(format, r)
+	if err != nil {
+		return report, fmt.Errorf("%w: %w", customerrors.ErrValidation, err)
+	}
+	if len(records) == 0 {
+		return report, fmt.Errorf("%w: the import file has no records", customerrors.ErrValidation)
+	}
+	report.Records = len(records)
+
+	switch kind {
+	case entities.ImportTimeline:
+		err = s.importTimelineEntries(ctx, records, &report)
+	case entities.ImportTexts:
+		err = s.importTexts(ctx, records, &report)
+	}
+	if err != nil {
+		return report, err
+	}
+
+	if len(report.Errors) > 0 {
+		return report, fmt.Errorf("%w: the import file has %d errors, nothing was imported", customerrors.ErrValidation, len(report.Errors))
+	}
+	return report, nil
+}
+
+// importTimelineEntries validates the timeline records and upserts them unless the report is a dry run
+func (s *server) importTimelineEntries(ctx context.Context, records []importer.Record, report *entities.ImportReport) error {
+	existing, err := s.db.ListTimelineEntries(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list timeline entries: %w", err)
+	}
+
+	byKey := make(map[string]entities.TimelineEntry, len(existing))
+	for _, entry := range existing {
+		byKey[timelineImportKey(entry.Name, entry.Date)] = entry
+	}
+
+	now, actor := time.Now(), auth.ActorFromContext(ctx)
+	seen := make(map[string]int, len(records)) // Line of the record by key
+	entries := make([]entities.TimelineEntry, 0, len(records))
+	previous := make([]entities.TimelineEntry, 0, len(records)) // Entry before the import, empty for created ones
+	for _, record := range records {
+		imported, errs := timelineEntryFromRecord(record)
+		if len(errs) > 0 {
+			report.Errors = append(report.Errors, errs...)
+			continue
+		}
+
+		if err := s.validateTimelineEntry(ctx, imported); err != nil {
+			if !errors.Is(err, customerrors.ErrValidation) {
+				return err
+			}
+			report.Errors = append(report.Errors, importError(record, "image_ids", err))
+			continue
+		}
+
+		key := timelineImportKey(imported.Name, imported.Date)
+		if line, found := seen[key]; found {
+			report.Errors = append(report.Errors, entities.ImportError{
+				Line:    record.Line,
+				Message: fmt.Sprintf("same name and date as line %d", line),
+			})
+			continue
+		}
+		seen[key] = record.Line
+
+		entry, found := byKey[key]
+		if found {
+			report.Updated++
+			previous = append(previous, entry)
+			mergeTimelineEntry(&entry, imported, record.Get("draft") != "")
+		} else {
+			report.Created++
+			previous = append(previous, entities.TimelineEntry{})
+			entry = imported
+			if entry.Importance == 0 {
+				entry.Importance = entities.TimelineImportanceNormal
+			}
+			entry.CreatedAt = now
+			entry.CreatedBy = actor
+		}
+		entry.UpdatedAt = now
+		entry.LastUpdatedBy = actor
+		entries = append(entries, entry)
+	}
+
+	if len(report.Errors) > 0 || report.DryRun {
+		return nil
+	}
+	written, err := s.db.ImportTimelineEntries(ctx, entries)
+	if err != nil {
+		return fmt.Errorf("failed to import timeline entries: %w", err)
+	}
+
+	for i, entry := range written {
+		if previous[i].ID == "" {
+			s.audit(ctx, entities.AuditCreate, entities.AuditTimelineEntry, entry.ID, nil, entry)
+		} else {
+			s.audit(ctx, entities.AuditUpdate, entities.AuditTimelineEntry, entry.ID, previous[i], entry)
+		}
+	}
+	return nil
+}
+
+// importTexts validates the text records and upserts them unless the report is a dry run
+func (s *server) importTexts(ctx context.Context, records []importer.Record, report *entities.ImportReport) error {
+	existing, err := s.db.ListAllTexts(ctx)
+	if err != nil {
+		return fmt.Errorf("failed to list texts: %w", err)
+	}
+
+	bySlug := make(map[string]entities.Text, len(existing))
+	for _, text := range existing {
+		bySlug[text.Slug] = text
+	}
+
+	now, actor := time.Now(), auth.ActorFromContext(ctx)
+	seen := make(map[string]int, len(records)) // Line of the record by slug
+	texts := make([]entities.Text, 0, len(records))
+	previous := make([]entities.Text, 0, len(records)) // Text before the import, empty for created ones
+	for _, record := range records {
+		imported, errs := textFromRecord(record)
+		if len(errs) > 0 {
+			report.Errors = append(report.Errors, errs...)
+			continue
+		}
+
+		if line, found := seen[imported.Slug]; found {
+			report.Errors = append(report.Errors, entities.ImportError{
+				Line:    record.Line,
+				Field:   "slug",
+				Message: fmt.Sprintf("same slug as line %d", line),
+			})
+			continue
+		}
+		seen[imported.Slug] = record.Line
+
+		text, found := bySlug[imported.Slug]
+		if found {
+			report.Updated++
+			previous = append(previous, text)
+			text.Content = imported.Content
+			if imported.PageID != "" {
+				text.PageID = imported.PageID
+			}
+			if imported.PageSlug != "" {
+				text.PageSlug = imported.PageSlug
+			}
+		} else {
+			report.Created++
+			previous = append(previous, entities.Text{})
+			text = imported
+			text.CreatedAt = now
+			text.CreatedBy = actor
+		}
+		text.UpdatedAt = now
+		text.LastUpdatedBy = actor
+		texts = append(texts, text)
+	}
+
+	if len(report.Errors) > 0 || report.DryRun {
+		return nil
+	}
+	written, err := s.db.ImportTexts(ctx, texts)
+	if err != nil {
+		return fmt.Errorf("failed to import texts: %w", err)
+	}
+
+	for i, text := range written {
+		if previous[i].ID == "" {
+			s.audit(ctx, entities.AuditCreate, entities.AuditText, text.ID, nil, text)
+		} else {
+			s.audit(ctx, entities.AuditUpdate, entities.AuditText, text.ID, previous[i], text)
+		}
+	}
+	return nil
+}
+
+// timelineEntryFromRecord reads the fields of a timeline record, images are checked later against the database
+func timelineEntryFromRecord(record importer.Record) (entities.TimelineEntry, []entities.ImportError) {
+	entry := entities.TimelineEntry{
+		Name:     record.Get("name"),
+		Text:     record.Get("text"),
+		Location: record.Get("location"),
+		Category: entities.TimelineCategory(strings.ToLower(record.Get("category"))),
+		ImageIDs: splitImportList(record.Get("image_ids")),
+	}
+	if entry.Text == "" {
+		entry.Text = record.Body
+	}
+
+	var errs []entities.ImportError
+	if entry.Name == "" {
+		errs = append(errs, entities.ImportError{Line: record.Line, Field: "name", Message: "name is required"})
+	}
+
+	if value := record.Get("date"); value == "" {
+		errs = append(errs, entities.ImportError{Line: record.Line, Field: "date", Message: "date is required"})
+	} else if date, err := parseImportDate(value); err != nil {
+		errs = append(errs, importError(record, "date", err))
+	} else {
+		entry.Date = date
+	}
+
+	if entry.Category != "" && !entry.Category.Valid() {
+		errs = append(errs, entities.ImportError{
+			Line:    record.Line,
+			Field:   "category",
+			Message: fmt.Sprintf("unknown category %q, expected meetup, conference, milestone or award", entry.Category),
+		})
+	}
+
+	if value := record.Get("importance"); value != "" {
+		importance, err := strconv.Atoi(value)
+		if err != nil || importance < entities.TimelineImportanceLow || importance > entities.TimelineImportanceHigh {
+			errs = append(errs, entities.ImportError{
+				Line:    record.Line,
+				Field:   "importance",
+				Message: fmt.Sprintf("importance must be a number between %d and %d", entities.TimelineImportanceLow, entities.TimelineImportanceHigh),
+			})
+		}
+		entry.Importance = importance
+	}
+
+	if value := record.Get("draft"); value != "" {
+		draft, err := strconv.ParseBool(value)
+		if err != nil {
+			errs = append(errs, entities.ImportError{Line: record.Line, Field: "draft", Message: "draft must be true or false"})
+		}
+		entry.Draft = draft
+	}
+
+	return entry, errs
+}
+
+// textFromRecord reads the fields of a text record, slugs are normalized like the ones of created texts
+func textFromRecord(record importer.Record) (entities.Text, []entities.ImportError) {
+	text := entities.Text{
+		Slug:     normalizeSlug(record.Get("slug")),
+		Content:  record.Get("content"),
+		PageID:   record.Get("page_id"),
+		PageSlug: normalizeSlug(record.Get("page_slug")),
+	}
+	if text.Content == "" {
+		text.Content = record.Body
+	}
+
+	var errs []entities.ImportError
+	if text.Slug == "" {
+		errs = append(errs, entities.ImportError{Line: record.Line, Field: "slug", Message: "slug is required"})
+	}
+	if text.Content == "" {
+		errs = append(errs, entities.ImportError{Line: record.Line, Field: "content", Message: "content is required"})
+	}
+	return text, errs
+}
+
+// mergeTimelineEntry overwrites the fields of an existing entry filled by an imported one
+// Draft is only overwritten when the record sets it
+func mergeTimelineEntry(entry *entities.TimelineEntry, imported entities.TimelineEntry, setDraft bool) {
+	entry.Name = imported.Name
+	entry.Date = imported.Date
+	if imported.Text != "" {
+		entry.Text = imported.Text
+	}
+	if imported.Location != "" {
+		entry.Location = imported.Location
+	}
+	if imported.Category != "" {
+		entry.Category = imported.Category
+	}
+	if imported.Importance != 0 {
+		entry.Importance = imported.Importance
+	}
+	if imported.ImageIDs != nil {
+		entry.ImageIDs = imported.ImageIDs
+	}
+	if setDraft {
+		entry.Draft = imported.Draft
+	}
+}
+
+// timelineImportKey identifies a timeline entry by its name, ignoring case and spacing, and its day in UTC
+func timelineImportKey(name string, date time.Time) string {
+	return strings.ToLower(strings.Join(strings.Fields(name), " ")) + "|" + date.UTC().Format(time.DateOnly)
+}
+
+// parseImportDate parses an RFC 3339 timestamp or a YYYY-MM-DD date, taken at midnight UTC
+func parseImportDate(value string) (time.Time, error) {
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	day, err := time.Parse(time.DateOnly, value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("%q is not an RFC 3339 timestamp or a YYYY-MM-DD date", value)
+	}
+	return day, nil
+}
+
+// splitImportList splits a comma separated field, nil when it's empty
+func splitImportList(value string) []string {
+	var items []string
+	for _, item := range strings.Split(value, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			items = append(items, item)
+		}
+	}
+	return items
+}
+
+// importError reports err on a field of a record, without the domain error prefix
+func importError(record importer.Record, field string, err error) entities.ImportError {
+	message := strings.TrimPrefix(err.Error(), customerrors.ErrValidation.Error()+": ")
+	return entities.ImportError{Line: record.Line, Field: field, Message: message}
+}
@@ -0,0 +1,413 @@
+package server
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"backend/internal/entities"
+	"backend/internal/platform/auth"
+	customerrors "backend/internal/platform/errors"
+)
+
+// =======================
+// BACKGROUND JOBS
+// =======================
+
+const (
+	// defaultJobWorkers is the number of jobs run at the same time
+	defaultJobWorkers = 2
+
+	// jobStagingPrefix is the object key prefix holding the input files of jobs until they finish
+	jobStagingPrefix = "jobs/"
+
+	// jobResultGaleryEvent is the result key holding the ID of the galery event created by a job
+	jobResultGaleryEvent = "galery_event_id"
+
+	// jobLease is how long a running job stays claimed without heartbeat, past it the instance running it is presumed dead
+	jobLease = 5 * time.Minute
+
+	// jobHeartbeatInterval is the time between two renewals of the lease of a running job
+	jobHeartbeatInterval = time.Minute
+
+	// jobSweepInterval is the time between two sweeps failing the running jobs whose lease expired
+	jobSweepInterval = jobLease / 2
+)
+
+// errJobInterrupted fails the jobs that were running when their instance stopped without rolling them back
+var errJobInterrupted = errors.New("job was interrupted by the loss of the server running it")
+
+// galeryEventJobPayload is the input of a JobTypeCreateGaleryEvent job
+type galeryEventJobPayload struct {
+	Name         string                   `json:"name"`
+	Location     string                   `json:"location"`
+	Date         time.Time                `json:"date"`
+	Private      bool                     `json:"private"`
+	GrupyEventID string                   `json:"grupy_event_id,omitempty"`
+	OnDuplicate  entities.DuplicatePolicy `json:"on_duplicate"`
+	ImageKeys    []string                 `json:"image_keys"` // Staged images, in galery order
+}
+
+// CreateGaleryEventJob stages the images of a new galery event and queues a job creating it
+// Input is validated right away, upload failures are reported by the job
+func (s *server) CreateGaleryEventJob(ctx context.Context, event entities.GaleryEvent, imagesBase64 []string, onDuplicate entities.DuplicatePolicy) (entities.Job, error) {
+	imagesData, err := decodeGaleryImages(event, imagesBase64)
+	if err != nil {
+		return entities.Job{}, fmt.Errorf("%w: %w", customerrors.ErrValidation, err)
+	}
+
+	switch onDuplicate {
+	case "", entities.DuplicateAllow, entities.DuplicateReuse, entities.DuplicateReject:
+	default:
+		return entities.Job{}, fmt.Errorf("%w: unknown duplicate policy %q", customerrors.ErrValidation, onDuplicate)
+	}
+
+	for i, data := range imagesData {
+		if len(data) > maxImageSizeBytes {
+			return entities.Job{}, fmt.Errorf("%w: image %d too large: max 10MB", customerrors.ErrValidation, i)
+		}
+	}
+
+	// Checked again by the job, the link may be taken in between
+	if _, err := s.validateGrupyEventLink(ctx, "", event.GrupyEventID); err != nil {
+		return entities.Job{}, err
+	}
+
+	payload := galeryEventJobPayload{
+		Name:         event.Name,
+		Location:     event.Location,
+		Date:         event.Date,
+		Private:      event.Private,
+		GrupyEventID: event.GrupyEventID,
+		OnDuplicate:  onDuplicate,
+		ImageKeys:    make([]string, 0, len(imagesData)),
+	}
+
+	// Stage the images so the job survives restarts, the request body is gone once we answer
+	batch := uuid.New().String()
+	for i, data := range imagesData {
+		key := fmt.Sprintf("%s%s/%03d", jobStagingPrefix, batch, i)
+		if _, err := s.obj.PutPrivateObject(ctx, key, data); err != nil {
+			s.deleteStagedObjects(ctx, payload.ImageKeys)
+			return entities.Job{}, fmt.Errorf("failed to stage image %d: %w", i, err)
+		}
+		payload.ImageKeys = append(payload.ImageKeys, key)
+	}
+
+	encoded, err := json.Marshal(payload)
+	if err != nil {
+		s.deleteStagedObjects(ctx, payload.ImageKeys)
+		return entities.Job{}, fmt.Errorf("failed to encode job payload: %w", err)
+	}
+
+	job, err := s.db.CreateJob(ctx, entities.Job{
+		Type:      entities.JobTypeCreateGaleryEvent,
+		State:     entities.JobQueued,
+		Progress:  entities.JobProgress{Total: len(imagesData)},
+		Payload:   encoded,
+		CreatedBy: auth.ActorFromContext(ctx),
+	})
+	if err != nil {
+		s.deleteStagedObjects(ctx, payload.ImageKeys)
+		return entities.Job{}, fmt.Errorf("failed to save job: %w", err)
+	}
+
+	s.jobs.push(job.ID)
+	return job, nil
+}
+
+// GetJob retrieves a job by ID
+func (s *server) GetJob(ctx context.Context, id string) (entities.Job, error) {
+	return s.db.GetJobByID(ctx, id)
+}
+
+// ResumeJobs picks up the jobs left unfinished by previous runs
+// Queued jobs are queued again, the claim of runJob keeps them from running on two instances.
+// Running jobs whose lease expired were left by a dead instance: they can't be rolled back,
+// so they are failed rather than run twice. Jobs still renewed by another instance are left alone
+func (s *server) ResumeJobs(ctx context.Context) (resumed int, failed int, err error) {
+	jobs, err := s.db.ListJobsByState(ctx, entities.JobQueued, entities.JobRunning)
+	if err != nil {
+		return 0, 0, fmt.Errorf("failed to list unfinished jobs: %w", err)
+	}
+
+	var running []entities.Job
+	for _, job := range jobs {
+		if job.State == entities.JobRunning {
+			running = append(running, job)
+			continue
+		}
+
+		s.jobs.push(job.ID)
+		resumed++
+	}
+
+	return resumed, s.failStaleJobs(ctx, running), nil
+}
+
+// failStaleJobs fails the running jobs whose lease expired and returns how many it failed
+func (s *server) failStaleJobs(ctx context.Context, running []entities.Job) (failed int) {
+	for _, job := range running {
+		if time.Since(job.UpdatedAt) < jobLease {
+			continue
+		}
+
+		// Claimed first so a single instance fails the job and deletes its staged input
+		claimed, err := s.db.ClaimJob(ctx, job.ID, time.Now().Add(-jobLease))
+		if err != nil {
+			continue
+		}
+		s.finishJob(ctx, claimed, nil, errJobInterrupted)
+		failed++
+	}
+	return failed
+}
+
+// sweepStaleJobs fails the jobs of dead instances every jobSweepInterval until ctx is done
+// ResumeJobs only catches the ones left when this process started, instances die while it runs too.
+// Best effort: a failed sweep is made up by the next one
+func (s *server) sweepStaleJobs(ctx context.Context) {
+	ticker := time.NewTicker(jobSweepInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			running, err := s.db.ListJobsByState(ctx, entities.JobRunning)
+			if err != nil {
+				continue
+			}
+			s.failStaleJobs(context.WithoutCancel(ctx), running)
+		}
+	}
+}
+
+// RunJobs runs queued jobs with at most jobWorkers at a time until ctx is done
+// A job interrupted by ctx is rolled back and queued again, so the next run picks it up.
+// Meanwhile the running jobs of dead instances are failed once their lease expires
+func (s *server) RunJobs(ctx context.Context) {
+	var wg sync.WaitGroup
+	wg.Add(1)
+	go func() {
+		defer wg.Done()
+		s.sweepStaleJobs(ctx)
+	}()
+
+	for range s.jobWorkers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for {
+				id, ok := s.jobs.pop(ctx)
+				if !ok {
+					return
+				}
+				s.runJob(ctx, id)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// runJob claims a queued job, runs it and records its outcome
+// The lease of the job is renewed while it runs. Job records are written even after ctx is done,
+// they must reflect what happened to the work
+func (s *server) runJob(ctx context.Context, id string) {
+	persistCtx := context.WithoutCancel(ctx)
+
+	// Fails when another worker, maybe of another instance, got the job first
+	job, err := s.db.ClaimJob(persistCtx, id, time.Now().Add(-jobLease))
+	if err != nil || job.State != entities.JobRunning {
+		return
+	}
+
+	stopHeartbeat := s.heartbeatJob(persistCtx, id)
+	result, err := s.executeJob(ctx, job)
+	stopHeartbeat()
+
+	if err != nil && ctx.Err() != nil {
+		// Shutting down: the work was rolled back, run it again from scratch after the restart
+		_, _ = s.db.UpdateJob(persistCtx, id, entities.Job{
+			State:    entities.JobQueued,
+			Progress: entities.JobProgress{Total: job.Progress.Total},
+		})
+		return
+	}
+
+	s.finishJob(persistCtx, job, result, err)
+}
+
+// heartbeatJob renews the lease of a running job every jobHeartbeatInterval until the returned func is called
+// Best effort: a missed renewal is made up by the next one, well before the lease expires
+func (s *server) heartbeatJob(ctx context.Context, id string) (stop func()) {
+	done := make(chan struct{})
+	stopped := make(chan struct{})
+	go func() {
+		defer close(stopped)
+		ticker := time.NewTicker(jobHeartbeatInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-done:
+				return
+			case <-ticker.C:
+				// An empty patch only writes updated_at
+				_, _ = s.db.UpdateJob(ctx, id, entities.Job{})
+			}
+		}
+	}()
+
+	return func() {
+		close(done)
+		<-stopped
+	}
+}
+
+// executeJob performs the work of a job according to its type
+func (s *server) executeJob(ctx context.Context, job entities.Job) (map[string]string, error) {
+	switch job.Type {
+	case entities.JobTypeCreateGaleryEvent:
+		return s.runCreateGaleryEventJob(ctx, job)
+	default:
+		return nil, fmt.Errorf("unknown job type %q", job.Type)
+	}
+}
+
+// finishJob records the outcome of a job and releases its staged input
+func (s *server) finishJob(ctx context.Context, job entities.Job, result map[string]string, err error) {
+	patch := entities.Job{
+		State:      entities.JobSucceeded,
+		Result:     result,
+		FinishedAt: time.Now(),
+	}
+	if err != nil {
+		patch.State = entities.JobFailed
+		patch.Error = err.Error()
+		patch.Result = nil
+	}
+
+	if job.Type == entities.JobTypeCreateGaleryEvent {
+		var payload galeryEventJobPayload
+		if json.Unmarshal(job.Payload, &payload) == nil {
+			s.deleteStagedObjects(ctx, payload.ImageKeys)
+		}
+	}
+
+	// Best effort: a job left running is failed by a ResumeJobs once its lease expires
+	_, _ = s.db.UpdateJob(ctx, job.ID, patch)
+}
+
+// runCreateGaleryEventJob creates a galery event from the images staged by CreateGaleryEventJob
+func (s *server) runCreateGaleryEventJob(ctx context.Context, job entities.Job) (map[string]string, error) {
+	var payload galeryEventJobPayload
+	if err := json.Unmarshal(job.Payload, &payload); err != nil {
+		return nil, fmt.Errorf("failed to decode job payload: %w", err)
+	}
+
+	imagesData := make([][]byte, len(payload.ImageKeys))
+	for i, key := range payload.ImageKeys {
+		data, err := s.obj.GetObject(ctx, key)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read staged image %d: %w", i, err)
+		}
+		imagesData[i] = data
+	}
+
+	event := entities.GaleryEvent{
+		Name:         payload.Name,
+		Location:     payload.Location,
+		Date:         payload.Date,
+		Private:      payload.Private,
+		GrupyEventID: payload.GrupyEventID,
+		CreatedBy:    job.CreatedBy,
+	}
+
+	// The audit log credits the caller who queued the job
+	ctx = contextWithJobActor(ctx, job.CreatedBy)
+
+	created, err := s.createGaleryEvent(ctx, event, imagesData, payload.OnDuplicate, s.jobProgress(ctx, job.ID, len(imagesData)))
+	if err != nil {
+		return nil, err
+	}
+
+	return map[string]string{jobResultGaleryEvent: created.ID}, nil
+}
+
+// jobProgress returns a callback recording how many of the total steps of a job are done
+// Best effort: a missed update is fixed by the next one
+func (s *server) jobProgress(ctx context.Context, id string, total int) func(done int) {
+	ctx = context.WithoutCancel(ctx)
+	return func(done int) {
+		_, _ = s.db.UpdateJob(ctx, id, entities.Job{Progress: entities.JobProgress{Done: done, Total: total}})
+	}
+}
+
+// deleteStagedObjects removes the staged input of a job, ignoring failures
+func (s *server) deleteStagedObjects(ctx context.Context, keys []string) {
+	ctx = context.WithoutCancel(ctx)
+	for _, key := range keys {
+		_ = s.obj.DeleteObject(ctx, key)
+	}
+}
+
+// jobQueue is an unbounded FIFO of the IDs of jobs waiting for a worker
+type jobQueue struct {
+	mu      sync.Mutex
+	pending []string
+	wake    chan struct{} // Holds a token while jobs may be pending
+}
+
+func newJobQueue() *jobQueue {
+	return &jobQueue{wake: make(chan struct{}, 1)}
+}
+
+// push appends a job ID to the queue, it never blocks
+func (q *jobQueue) push(id string) {
+	q.mu.Lock()
+	q.pending = append(q.pending, id)
+	q.mu.Unlock()
+
+	q.signal()
+}
+
+// pop waits for the next job ID, ok is false once ctx is done
+func (q *jobQueue) pop(ctx context.Context) (id string, ok bool) {
+	for {
+		if ctx.Err() != nil {
+			return "", false
+		}
+
+		q.mu.Lock()
+		if len(q.pending) > 0 {
+			id, q.pending = q.pending[0], q.pending[1:]
+			more := len(q.pending) > 0
+			q.mu.Unlock()
+
+			if more {
+				// Pass the token on so another worker picks the next job
+				q.signal()
+			}
+			return id, true
+		}
+		q.mu.Unlock()
+
+		select {
+		case <-q.wake:
+		case <-ctx.Done():
+			return "", false
+		}
+	}
+}
+
+func (q *jobQueue) signal() {
+	select {
+	case q.wake <- struct{}{}:
+	default:
+	}
+}
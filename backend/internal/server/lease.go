@@ -0,0 +1,22 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	customerrors "backend/internal/platform/errors"
+)
+
+// AcquireLease takes or renews the named lease for this server until ttl from now
+// Reports false without error while another instance holds it, so periodic tasks run on a single instance.
+// The holder keeps the lease by acquiring it again before it expires
+func (s *server) AcquireLease(ctx context.Context, name string, ttl time.Duration) (bool, error) {
+	if _, err := s.db.AcquireLease(ctx, name, s.instanceID, ttl); err != nil {
+		if errors.Is(err, customerrors.ErrConflict) {
+			return false, nil
+		}
+		return false, err
+	}
+	return true, nil
+}
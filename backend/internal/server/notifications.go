@@ -0,0 +1,236 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+
+	"backend/internal/entities"
+	customerrors "backend/internal/platform/errors"
+)
+
+// =======================
+// EVENT NOTIFICATIONS
+// =======================
+
+const (
+	// defaultWebhookMaxAttempts is how many times a notification is sent to a webhook before it is dead-lettered
+	defaultWebhookMaxAttempts = 5
+
+	// webhookRetryDelay is the wait before the second attempt, it doubles after every failed attempt
+	webhookRetryDelay = 2 * time.Second
+
+	// maxWebhookRetryDelay caps the wait between attempts
+	maxWebhookRetryDelay = time.Minute
+
+	// maxWatchedEvents bounds the upcoming events read by a poll
+	maxWatchedEvents = 1000
+
+	// cancelledEventState is the state of a cancelled Grupy event
+	cancelledEventState = "cancelled"
+
+	// publishedEventState is the state of a Grupy event listed publicly
+	publishedEventState = "published"
+)
+
+// PollEvents compares the upcoming Grupy events with the ones seen by the previous poll,
+// records them and delivers a notification for every change to the webhooks
+// The first poll only records the events, announcing all of them would flood the webhooks.
+// Notifications a webhook doesn't accept are retried, then written to the dead-letter log
+func (s *server) PollEvents(ctx context.Context) ([]entities.Notification, error) {
+	now := time.Now()
+
+	events, err := s.listWatchedEvents(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list grupy events: %w", err)
+	}
+
+	watch, err := s.db.GetEventWatch(ctx)
+	first := errors.Is(err, customerrors.ErrNotFound)
+	if err != nil && !first {
+		return nil, fmt.Errorf("failed to read watched events: %w", err)
+	}
+
+	notifications := diffEvents(watch.Events, events, now)
+
+	// Recorded before delivering, a crash must not announce the same changes twice
+	next := entities.EventWatch{
+		Events:   make(map[string]entities.EventSnapshot, len(events)),
+		PolledAt: now,
+	}
+	for _, event := range events {
+		next.Events[event.ID] = entities.SnapshotOf(event)
+	}
+	if err := s.db.SaveEventWatch(ctx, next); err != nil {
+		return nil, fmt.Errorf("failed to save watched events: %w", err)
+	}
+
+	if first {
+		return nil, nil
+	}
+
+	s.deliverNotifications(ctx, notifications)
+	return notifications, nil
+}
+
+// ListDeadLetters lists the notifications the webhooks didn't accept, newest first
+func (s *server) ListDeadLetters(ctx context.Context) ([]entities.DeadLetter, error) {
+	return s.db.ListDeadLetters(ctx)
+}
+
+// listWatchedEvents reads every Grupy event that hasn't ended yet, page by page
+func (s *server) listWatchedEvents(ctx context.Context) ([]entities.Event, error) {
+	query := entities.EventQuery{
+		Limit:    eventSourcePageSize,
+		OrderBy:  "starts-at",
+		Upcoming: true,
+	}
+
+	var events []entities.Event
+	for query.Page = 1; len(events) < maxWatchedEvents; query.Page++ {
+		batch, count, err := s.events.GetEvents(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, batch...)
+
+		if len(batch) < query.Limit || len(events) >= count {
+			break
+		}
+	}
+
+	addLinksToevents(events)
+	return events, nil
+}
+
+// diffEvents lists the changes between the events seen by the previous poll and the current ones
+// Events that are no longer listed are cancelled, unless they have ended in the meantime
+func diffEvents(previous map[string]entities.EventSnapshot, current []entities.Event, now time.Time) []entities.Notification {
+	var notifications []entities.Notification
+	notify := func(kind entities.NotificationKind, event entities.Event, prev *entities.EventSnapshot) {
+		notifications = append(notifications, entities.Notification{
+			ID:         uuid.New().String(),
+			Kind:       kind,
+			Event:      event,
+			Previous:   prev,
+			OccurredAt: now,
+		})
+	}
+
+	listed := make(map[string]bool, len(current))
+	for _, event := range current {
+		listed[event.ID] = true
+
+		prev, seen := previous[event.ID]
+		if !seen {
+			if event.State != cancelledEventState {
+				notify(entities.NotificationEventCreated, event, nil)
+			}
+			continue
+		}
+
+		if event.State == cancelledEventState {
+			if prev.State != cancelledEventState {
+				notify(entities.NotificationEventCancelled, event, &prev)
+			}
+			continue
+		}
+
+		if event.State == publishedEventState && prev.State != publishedEventState {
+			notify(entities.NotificationEventPublished, event, &prev)
+		}
+		if !event.StartsAt.Equal(prev.StartsAt) || !event.EndsAt.Equal(prev.EndsAt) {
+			notify(entities.NotificationEventRescheduled, event, &prev)
+		}
+	}
+
+	// Sorted so removed events are announced in a stable order
+	var removed []string
+	for id, prev := range previous {
+		if !listed[id] && prev.EndsAt.After(now) && prev.State != cancelledEventState {
+			removed = append(removed, id)
+		}
+	}
+	sort.Strings(removed)
+
+	for _, id := range removed {
+		prev := previous[id]
+		event := entities.Event{
+			ID:       id,
+			Source:   grupyEventSource,
+			Name:     prev.Name,
+			State:    cancelledEventState,
+			StartsAt: prev.StartsAt,
+			EndsAt:   prev.EndsAt,
+			Link:     prev.Link,
+		}
+		notify(entities.NotificationEventCancelled, event, &prev)
+	}
+
+	return notifications
+}
+
+// deliverNotifications sends the notifications to every webhook, each webhook gets them in order
+// Webhooks are served at the same time so a failing one doesn't hold the others back
+func (s *server) deliverNotifications(ctx context.Context, notifications []entities.Notification) {
+	if len(notifications) == 0 {
+		return
+	}
+
+	var wg sync.WaitGroup
+	for _, hook := range s.webhooks {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for _, notification := range notifications {
+				s.deliver(ctx, hook, notification)
+			}
+		}()
+	}
+	wg.Wait()
+}
+
+// deliver sends a notification to a webhook, retrying with a growing delay
+// Rejected notifications and the ones still failing after the last attempt, or when ctx is done,
+// are written to the dead-letter log
+func (s *server) deliver(ctx context.Context, hook WebhookPort, notification entities.Notification) {
+	var (
+		err      error
+		attempts int
+		delay    = webhookRetryDelay
+	)
+
+retry:
+	for attempts < s.webhookMaxAttempts {
+		attempts++
+		if err = hook.Deliver(ctx, notification); err == nil {
+			return
+		}
+		if errors.Is(err, ErrWebhookRejected) || attempts == s.webhookMaxAttempts {
+			break
+		}
+
+		select {
+		case <-ctx.Done():
+			break retry
+		case <-time.After(delay):
+		}
+		delay = min(delay*2, maxWebhookRetryDelay)
+	}
+
+	// Best effort: written even when shutting down, the dead letter is the only trace of the failure
+	_, _ = s.db.CreateDeadLetter(context.WithoutCancel(ctx), entities.DeadLetter{
+		Webhook:        hook.Name(),
+		NotificationID: notification.ID,
+		Kind:           notification.Kind,
+		EventID:        notification.Event.ID,
+		EventName:      notification.Event.Name,
+		Attempts:       attempts,
+		Error:          err.Error(),
+	})
+}
@@ -0,0 +1,130 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"backend/internal/entities"
+	"backend/internal/platform/imaging"
+)
+
+// =======================
+// IMAGE PLACEHOLDERS
+// =======================
+
+// applyPlaceholder computes the loading placeholders of an image from its bytes
+// Best effort: formats that can't be decoded (e.g., WebP) are stored without placeholders
+func applyPlaceholder(meta *entities.Image, data []byte) {
+	placeholder, err := imaging.ComputePlaceholder(data)
+	if err != nil {
+		return
+	}
+
+	meta.BlurHash = placeholder.BlurHash
+	meta.LQIP = placeholder.LQIP
+	meta.DominantColor = placeholder.DominantColor
+}
+
+// galeryItemsWithImages refreshes the URL and placeholder of each item from its Image document
+// Items whose image can't be fetched are kept as they are
+func (s *server) galeryItemsWithImages(ctx context.Context, items []entities.GaleryItem) []entities.GaleryItem {
+	refreshed := make([]entities.GaleryItem, len(items))
+	for i, item := range items {
+		refreshed[i] = item
+
+		img, err := s.db.GetImageByID(ctx, item.ImageID)
+		if err != nil {
+			continue
+		}
+		refreshed[i].ImageURL = img.ObjectURL
+		refreshed[i].Placeholder = img.Placeholder()
+	}
+	return refreshed
+}
+
+// BackfillImagePlaceholders computes the placeholders of existing images and refreshes the galery events using them
+// Images that already have placeholders are skipped unless overwrite is set
+// Images that fail are reported and don't stop the run
+func (s *server) BackfillImagePlaceholders(ctx context.Context, overwrite bool) (entities.PlaceholderBackfillReport, error) {
+	var report entities.PlaceholderBackfillReport
+
+	images, err := s.db.ListAllImages(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to list images: %w", err)
+	}
+
+	for _, img := range images {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		report.Scanned++
+
+		if img.BlurHash != "" && !overwrite {
+			report.Skipped++
+			continue
+		}
+
+		if err := s.backfillImagePlaceholder(ctx, img); err != nil {
+			report.Failed = append(report.Failed, img.ID)
+			continue
+		}
+		report.Updated++
+	}
+
+	events, err := s.db.ListGaleryEvents(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to list galery events: %w", err)
+	}
+
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+
+		items := s.galeryItemsWithImages(ctx, event.Items)
+		if slices.Equal(items, event.Items) {
+			continue
+		}
+
+		before := event
+		event.Items = items
+		modified, err := s.db.ModifyGaleryEvent(ctx, event.ID, event)
+		if err != nil {
+			return report, fmt.Errorf("failed to update galery event %s: %w", event.ID, err)
+		}
+		s.audit(ctx, entities.AuditUpdate, entities.AuditGaleryEvent, event.ID, before, modified)
+		report.GaleryUpdated++
+	}
+
+	return report, nil
+}
+
+// backfillImagePlaceholder downloads an image and stores its placeholders
+func (s *server) backfillImagePlaceholder(ctx context.Context, img entities.Image) error {
+	if img.ObjectURL == "" {
+		return fmt.Errorf("image %s has no object", img.ID)
+	}
+
+	data, err := s.obj.GetObject(ctx, s.obj.KeyFromURL(img.ObjectURL))
+	if err != nil {
+		return fmt.Errorf("failed to download image %s: %w", img.ID, err)
+	}
+
+	placeholder, err := imaging.ComputePlaceholder(data)
+	if err != nil {
+		return fmt.Errorf("failed to compute placeholder of image %s: %w", img.ID, err)
+	}
+
+	updated, err := s.db.UpdateImageMeta(ctx, img.ID, entities.Image{
+		BlurHash:      placeholder.BlurHash,
+		LQIP:          placeholder.LQIP,
+		DominantColor: placeholder.DominantColor,
+	})
+	if err != nil {
+		return err
+	}
+
+	s.audit(ctx, entities.AuditUpdate, entities.AuditImage, img.ID, img, updated)
+	return nil
+}
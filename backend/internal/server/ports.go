@@ -2,11 +2,14 @@ package server
 
 import (
 	"context"
+	"errors"
+	"time"
 
 	"backend/internal/entities"
 )
 
 // DBPort defines the contract for database operations
+// The by arguments identify the caller making a change, stored as LastUpdatedBy
 type DBPort interface {
 	// Text operations
 	GetTextBySlug(ctx context.Context, slug string) (entities.Text, error)
@@ -17,38 +20,118 @@ type DBPort interface {
 	CreateText(ctx context.Context, text entities.Text) (entities.Text, error)
 	UpdateText(ctx context.Context, id string, patch entities.Text) (entities.Text, error)
 	DeleteText(ctx context.Context, id string) error
+	ImportTexts(ctx context.Context, texts []entities.Text) ([]entities.Text, error)
 
 	// Image operations
 	GetImageByID(ctx context.Context, id string) (entities.Image, error)
 	GetImagesBySlug(ctx context.Context, slug string) ([]entities.Image, error)
+	GetImagesBySHA256(ctx context.Context, hash string) ([]entities.Image, error)
+	GetImagesByTag(ctx context.Context, tag string) ([]entities.Image, error)
+	GetImagesByIDs(ctx context.Context, ids []string) ([]entities.Image, error)
 	ListAllImages(ctx context.Context) ([]entities.Image, error)
 	CreateImageMeta(ctx context.Context, img entities.Image) (entities.Image, error)
 	UpdateImageMeta(ctx context.Context, id string, patch entities.Image) (entities.Image, error)
 	DeleteImageMeta(ctx context.Context, id string) error
+	SetImagePrivate(ctx context.Context, id string, private bool, by string) (entities.Image, error)
+	AddImageTags(ctx context.Context, imageIDs []string, tags []string, by string) error
+	RemoveImageTags(ctx context.Context, imageIDs []string, tags []string, by string) error
 
 	// Timeline operations
 	GetTimelineEntryByID(ctx context.Context, id string) (entities.TimelineEntry, error)
 	ListTimelineEntries(ctx context.Context) ([]entities.TimelineEntry, error)
 	CreateTimelineEntry(ctx context.Context, entry entities.TimelineEntry) (entities.TimelineEntry, error)
 	UpdateTimelineEntry(ctx context.Context, id string, patch entities.TimelineEntry) (entities.TimelineEntry, error)
+	SetTimelineEntryDraft(ctx context.Context, id string, draft bool, by string) (entities.TimelineEntry, error)
+	ListTimelineEntriesByImageID(ctx context.Context, imageID string) ([]entities.TimelineEntry, error)
+	RemoveTimelineImage(ctx context.Context, imageID string) error
 	DeleteTimelineEntry(ctx context.Context, id string) error
+	DismissTimelineEntry(ctx context.Context, id string, tombstone entities.TimelineTombstone) error
+	ListTimelineTombstones(ctx context.Context) ([]entities.TimelineTombstone, error)
+	ImportTimelineEntries(ctx context.Context, entries []entities.TimelineEntry) ([]entities.TimelineEntry, error)
 
 	// GaleryEvent operations
 	CreateGaleryEvent(ctx context.Context, event entities.GaleryEvent) (entities.GaleryEvent, error)
 	GetGaleryEventByID(ctx context.Context, id string) (entities.GaleryEvent, error)
 	ListGaleryEvents(ctx context.Context) ([]entities.GaleryEvent, error)
+	ListGaleryEventsByImageID(ctx context.Context, imageID string) ([]entities.GaleryEvent, error)
+	GetGaleryEventByGrupyEventID(ctx context.Context, grupyEventID string) (entities.GaleryEvent, error)
 	DeleteGaleryEvent(ctx context.Context, id string) error
 	ModifyGaleryEvent(ctx context.Context, id string, newEvent entities.GaleryEvent) (entities.GaleryEvent, error)
+	SetGaleryEventPrivate(ctx context.Context, id string, private bool, by string) (entities.GaleryEvent, error)
+	AppendGaleryEventImage(ctx context.Context, id string, item entities.GaleryItem, created bool, by string) (entities.GaleryEvent, error)
+	RemoveGaleryEventImage(ctx context.Context, id string, imageID string, by string) (entities.GaleryEvent, error)
+	ReorderGaleryEventImages(ctx context.Context, id string, imageIDs []string, by string) (entities.GaleryEvent, error)
+
+	// Job operations
+	CreateJob(ctx context.Context, job entities.Job) (entities.Job, error)
+	GetJobByID(ctx context.Context, id string) (entities.Job, error)
+	ListJobsByState(ctx context.Context, states ...entities.JobState) ([]entities.Job, error)
+	UpdateJob(ctx context.Context, id string, patch entities.Job) (entities.Job, error)
+	ClaimJob(ctx context.Context, id string, staleBefore time.Time) (entities.Job, error)
+
+	// Event watch operations
+	GetEventWatch(ctx context.Context) (entities.EventWatch, error)
+	SaveEventWatch(ctx context.Context, watch entities.EventWatch) error
+	CreateDeadLetter(ctx context.Context, letter entities.DeadLetter) (entities.DeadLetter, error)
+	ListDeadLetters(ctx context.Context) ([]entities.DeadLetter, error)
+
+	// Lease operations
+	AcquireLease(ctx context.Context, name string, holder string, ttl time.Duration) (entities.Lease, error)
+
+	// Upload reservation operations
+	CreateUploadReservation(ctx context.Context, reservation entities.UploadReservation) (entities.UploadReservation, error)
+	GetUploadReservation(ctx context.Context, key string) (entities.UploadReservation, error)
+	FinalizeUploadReservation(ctx context.Context, id string, img entities.Image) (image entities.Image, finalized bool, err error)
+
+	// Audit operations, records are never updated nor deleted
+	CreateAuditRecord(ctx context.Context, record entities.AuditRecord) (entities.AuditRecord, error)
+	ListAuditRecords(ctx context.Context, query entities.AuditQuery) (entities.AuditPage, error)
 }
 
 // ObjectStorePort defines the contract for object storage operations
 type ObjectStorePort interface {
 	PutObject(ctx context.Context, key string, data []byte) (publicURL string, err error)
+	PutPrivateObject(ctx context.Context, key string, data []byte) (url string, err error)
+	SetObjectPrivate(ctx context.Context, key string, private bool) error
 	DeleteObject(ctx context.Context, key string) error
+	GetObject(ctx context.Context, key string) ([]byte, error)
 	SignedURL(ctx context.Context, key string) (string, error)
+	SignedUploadURL(ctx context.Context, key, contentType string, private bool) (entities.SignedUpload, error)
+	StatObject(ctx context.Context, key string) (entities.ObjectInfo, error)
+	KeyFromURL(url string) string // Key of the object behind a URL returned by PutObject or PutPrivateObject
 }
 
 // GrupyEventsPort defines the contract for external events API
 type GrupyEventsPort interface {
-	GetEvents(ctx context.Context, limit int, orderBy string, desc bool) ([]entities.Event, error)
+	GetEvents(ctx context.Context, query entities.EventQuery) ([]entities.Event, int, error)
+	GetEventByID(ctx context.Context, id string) (entities.Event, error)
+	GetEventTracks(ctx context.Context, eventID string) ([]entities.Track, error)
+	GetEventSessions(ctx context.Context, eventID string) ([]entities.Session, error)
+	GetEventSpeakers(ctx context.Context, eventID string) ([]entities.Speaker, error)
+}
+
+// EventSourcePort defines the contract for a partner source of community events
+// Sources apply the filters, order and page of the query themselves
+type EventSourcePort interface {
+	// Name identifies the source, its events carry it in their Source field
+	Name() string
+	GetEvents(ctx context.Context, query entities.EventQuery) ([]entities.Event, int, error)
+}
+
+// ErrWebhookRejected is returned by a webhook that refused a notification, sending it again won't help
+var ErrWebhookRejected = errors.New("notification rejected by the webhook")
+
+// WebhookPort defines the contract for an outbound webhook announcing event changes
+type WebhookPort interface {
+	// Name identifies the webhook in the dead-letter log
+	Name() string
+	// Deliver sends a notification once, errors wrapping ErrWebhookRejected aren't retried
+	Deliver(ctx context.Context, notification entities.Notification) error
+}
+
+// UserDirectoryPort defines the contract for the accounts of the editors kept by the identity provider
+type UserDirectoryPort interface {
+	GetUser(ctx context.Context, uid string) (entities.User, error)
+	// SetUserRole stores the role in the custom claims of the account, it applies to the tokens issued afterwards
+	SetUserRole(ctx context.Context, uid string, role string) (entities.User, error)
 }
@@ -2,9 +2,14 @@ package server
 
 import (
 	"context"
+	"io"
+	"strings"
 	"time"
 
+	"github.com/google/uuid"
+
 	"backend/internal/entities"
+	"backend/internal/platform/importer"
 )
 
 // Server defines the unified service interface for all business operations
@@ -23,26 +28,78 @@ type Server interface {
 	GetImageByID(ctx context.Context, id string) (entities.Image, error)
 	GetImagesBySlug(ctx context.Context, slug string) ([]entities.Image, error)
 	ListAllImages(ctx context.Context) ([]entities.Image, error)
-	UploadImage(ctx context.Context, meta entities.Image, data []byte) (entities.Image, error)
+	UploadImage(ctx context.Context, meta entities.Image, data []byte, onDuplicate entities.DuplicatePolicy) (entities.Image, error)
 	UpdateImage(ctx context.Context, id string, meta entities.Image, data []byte) (entities.Image, error)
 	DeleteImage(ctx context.Context, id string) error
+	ListDuplicateImages(ctx context.Context) ([]entities.DuplicateCluster, error)
+	SetImageVisibility(ctx context.Context, id string, private bool) (entities.Image, error)
+	CreateImageUpload(ctx context.Context, slug, contentType string, private bool) (entities.SignedUpload, error)
+	FinalizeImageUpload(ctx context.Context, key string, meta entities.Image, onDuplicate entities.DuplicatePolicy) (entities.Image, error)
+	BackfillImagePlaceholders(ctx context.Context, overwrite bool) (entities.PlaceholderBackfillReport, error)
+
+	// Tag operations
+	ListTags(ctx context.Context) ([]entities.TagCount, error)
+	ListImagesByTag(ctx context.Context, tag string) ([]entities.Image, error)
+	TagImages(ctx context.Context, imageIDs []string, tags []string) error
+	UntagImages(ctx context.Context, imageIDs []string, tags []string) error
 
 	// Timeline operations
-	GetTimelineEntryByID(ctx context.Context, id string) (entities.TimelineEntry, error)
-	ListTimelineEntries(ctx context.Context) ([]entities.TimelineEntry, error)
+	GetTimelineEntryByID(ctx context.Context, id string, expandImages bool) (entities.TimelineEntry, error)
+	ListTimelineEntries(ctx context.Context, query entities.TimelineQuery) ([]entities.TimelineEntry, error)
+	GroupTimelineEntries(ctx context.Context, query entities.TimelineQuery, by entities.TimelineGrouping) ([]entities.TimelineGroup, error)
 	CreateTimelineEntry(ctx context.Context, entry entities.TimelineEntry) (entities.TimelineEntry, error)
 	UpdateTimelineEntry(ctx context.Context, id string, entry entities.TimelineEntry) (entities.TimelineEntry, error)
+	SetTimelineEntryDraft(ctx context.Context, id string, draft bool) (entities.TimelineEntry, error)
 	DeleteTimelineEntry(ctx context.Context, id string) error
+	SyncTimelineFromEvents(ctx context.Context, dryRun bool) (entities.TimelineSyncReport, error)
 
 	// Events operations
-	GetEvents(ctx context.Context, limit int, orderBy string, desc bool) ([]entities.Event, error)
+	GetEvents(ctx context.Context, query entities.EventQuery) (entities.EventPage, error)
+	GetEventByID(ctx context.Context, id string) (entities.Event, error)
+	GetEventSessions(ctx context.Context, eventID string) ([]entities.Session, error)
+	GetEventSpeakers(ctx context.Context, eventID string) ([]entities.Speaker, error)
+	GetEventsCalendar(ctx context.Context) ([]byte, error)
+	GetEventCalendar(ctx context.Context, id string) ([]byte, error)
+
+	// Feed operations
+	GetFeed(ctx context.Context, kind entities.FeedKind, format entities.FeedFormat) (entities.RenderedFeed, error)
 
 	// GaleryEvent operations
-	CreateGaleryEvent(ctx context.Context, name, location string, date time.Time, imagesBase64 []string) (entities.GaleryEvent, error)
+	CreateGaleryEvent(ctx context.Context, event entities.GaleryEvent, imagesBase64 []string, onDuplicate entities.DuplicatePolicy) (entities.GaleryEvent, error)
 	GetGaleryEventByID(ctx context.Context, id string) (entities.GaleryEvent, error)
 	ListGaleryEvents(ctx context.Context) ([]entities.GaleryEvent, error)
 	ModifyGaleryEvent(ctx context.Context, id string, newEvent entities.GaleryEvent) (entities.GaleryEvent, error)
 	DeleteGaleryEvent(ctx context.Context, id string) error
+	SetGaleryEventVisibility(ctx context.Context, id string, private bool) (entities.GaleryEvent, error)
+	AddGaleryEventImage(ctx context.Context, id string, item entities.GaleryItem, meta entities.Image, data []byte, onDuplicate entities.DuplicatePolicy) (entities.GaleryEvent, error)
+	RemoveGaleryEventImage(ctx context.Context, id string, imageID string, deleteImage bool) (entities.GaleryEvent, error)
+	ReorderGaleryEventImages(ctx context.Context, id string, imageIDs []string) (entities.GaleryEvent, error)
+	GetGaleryEventByGrupyEventID(ctx context.Context, grupyEventID string) (entities.GaleryEvent, error)
+	GetGaleryEventArchive(ctx context.Context, id string) (entities.Archive, error)
+	WriteArchive(ctx context.Context, archive entities.Archive, w io.Writer, from int64) error
+	CreateGaleryEventJob(ctx context.Context, event entities.GaleryEvent, imagesBase64 []string, onDuplicate entities.DuplicatePolicy) (entities.Job, error)
+
+	// Job operations
+	GetJob(ctx context.Context, id string) (entities.Job, error)
+	ResumeJobs(ctx context.Context) (resumed int, failed int, err error)
+	RunJobs(ctx context.Context)
+
+	// Event notification operations
+	PollEvents(ctx context.Context) ([]entities.Notification, error)
+	ListDeadLetters(ctx context.Context) ([]entities.DeadLetter, error)
+
+	// Lease operations
+	AcquireLease(ctx context.Context, name string, ttl time.Duration) (bool, error)
+
+	// Import operations
+	Import(ctx context.Context, kind entities.ImportKind, format importer.Format, r io.Reader, dryRun bool) (entities.ImportReport, error)
+
+	// User operations
+	GetUser(ctx context.Context, uid string) (entities.User, error)
+	SetUserRole(ctx context.Context, uid string, role string) (entities.User, error)
+
+	// Audit operations
+	ListAuditRecords(ctx context.Context, query entities.AuditQuery) (entities.AuditPage, error)
 }
 
 // server implements the Server interface
@@ -50,13 +107,101 @@ type server struct {
 	db     DBPort
 	obj    ObjectStorePort
 	events GrupyEventsPort
+
+	instanceID              string // Holder of the leases taken by this server
+	eventSources            []EventSourcePort
+	galeryUploadConcurrency int
+	jobWorkers              int
+	jobs                    *jobQueue
+	siteURL                 string
+	users                   UserDirectoryPort
+	webhooks                []WebhookPort
+	webhookMaxAttempts      int
+}
+
+// Option customizes a Server created by NewServer
+type Option func(*server)
+
+// WithGaleryUploadConcurrency sets how many images of a galery event are uploaded at the same time
+// Values below 1 are ignored
+func WithGaleryUploadConcurrency(n int) Option {
+	return func(s *server) {
+		if n > 0 {
+			s.galeryUploadConcurrency = n
+		}
+	}
+}
+
+// WithJobWorkers sets how many background jobs run at the same time
+// Values below 1 are ignored
+func WithJobWorkers(n int) Option {
+	return func(s *server) {
+		if n > 0 {
+			s.jobWorkers = n
+		}
+	}
+}
+
+// WithSiteURL sets the root URL of the public site linked from feeds
+// Empty values are ignored
+func WithSiteURL(url string) Option {
+	return func(s *server) {
+		if url != "" {
+			s.siteURL = strings.TrimSuffix(url, "/")
+		}
+	}
+}
+
+// WithEventSources adds partner event sources merged with the Grupy events in GetEvents
+// Sources are trusted in the given order, the first copy of an event listed by several sources is kept
+func WithEventSources(sources ...EventSourcePort) Option {
+	return func(s *server) {
+		s.eventSources = append(s.eventSources, sources...)
+	}
+}
+
+// WithWebhooks adds the webhooks announcing the changes of Grupy events found by PollEvents
+func WithWebhooks(hooks ...WebhookPort) Option {
+	return func(s *server) {
+		s.webhooks = append(s.webhooks, hooks...)
+	}
+}
+
+// WithWebhookMaxAttempts sets how many times a notification is sent to a webhook before it is dead-lettered
+// Values below 1 are ignored
+func WithWebhookMaxAttempts(n int) Option {
+	return func(s *server) {
+		if n > 0 {
+			s.webhookMaxAttempts = n
+		}
+	}
+}
+
+// WithUserDirectory sets the accounts whose roles are managed by GetUser and SetUserRole
+func WithUserDirectory(users UserDirectoryPort) Option {
+	return func(s *server) {
+		s.users = users
+	}
 }
 
 // NewServer creates a new unified Server with all dependencies
-func NewServer(db DBPort, obj ObjectStorePort, events GrupyEventsPort) Server {
-	return &server{
+func NewServer(db DBPort, obj ObjectStorePort, events GrupyEventsPort, opts ...Option) Server {
+	s := &server{
 		db:     db,
 		obj:    obj,
 		events: events,
+
+		instanceID:              uuid.New().String(),
+		galeryUploadConcurrency: defaultGaleryUploadConcurrency,
+		jobWorkers:              defaultJobWorkers,
+		jobs:                    newJobQueue(),
+		siteURL:                 defaultSiteURL,
+		webhookMaxAttempts:      defaultWebhookMaxAttempts,
+	}
+
+	for _, opt := range opts {
+		opt(s)
 	}
+
+	return s
 }
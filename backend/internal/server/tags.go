@@ -0,0 +1,148 @@
+package server
+
+import (
+	"cmp"
+	"context"
+	"fmt"
+	"slices"
+
+	"backend/internal/entities"
+	"backend/internal/platform/auth"
+	customerrors "backend/internal/platform/errors"
+)
+
+// =======================
+// TAG OPERATIONS
+// =======================
+
+// ListTags counts the images carrying each tag, most used tags first
+// Private images are only counted for authenticated readers
+func (s *server) ListTags(ctx context.Context) ([]entities.TagCount, error) {
+	images, err := s.db.ListAllImages(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	authenticated := auth.IsAuthenticated(ctx)
+	counts := make(map[string]int)
+	for _, img := range images {
+		if img.Private && !authenticated {
+			continue
+		}
+		for _, tag := range img.Tags {
+			counts[tag]++
+		}
+	}
+
+	tags := make([]entities.TagCount, 0, len(counts))
+	for tag, count := range counts {
+		tags = append(tags, entities.TagCount{Tag: tag, Count: count})
+	}
+	slices.SortFunc(tags, func(a, b entities.TagCount) int {
+		if a.Count != b.Count {
+			return cmp.Compare(b.Count, a.Count)
+		}
+		return cmp.Compare(a.Tag, b.Tag)
+	})
+
+	return tags, nil
+}
+
+// ListImagesByTag retrieves the images carrying a tag, the tag is normalized first
+func (s *server) ListImagesByTag(ctx context.Context, tag string) ([]entities.Image, error) {
+	images, err := s.db.GetImagesByTag(ctx, normalizeTag(tag))
+	if err != nil {
+		return nil, err
+	}
+	return s.imagesForReader(ctx, images)
+}
+
+// TagImages adds tags to several images at once, all of them or none are tagged
+func (s *server) TagImages(ctx context.Context, imageIDs []string, tags []string) error {
+	normalized, err := validateBulkTags(imageIDs, tags)
+	if err != nil {
+		return err
+	}
+
+	before, err := s.imagesByID(ctx, imageIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.AddImageTags(ctx, imageIDs, normalized, auth.ActorFromContext(ctx)); err != nil {
+		return err
+	}
+
+	for _, img := range before {
+		after := img
+		after.Tags = normalizeTags(append(slices.Clone(img.Tags), normalized...))
+		s.audit(ctx, entities.AuditUpdate, entities.AuditImage, img.ID, img, after)
+	}
+	return nil
+}
+
+// UntagImages removes tags from several images at once, all of them or none are untagged
+func (s *server) UntagImages(ctx context.Context, imageIDs []string, tags []string) error {
+	normalized, err := validateBulkTags(imageIDs, tags)
+	if err != nil {
+		return err
+	}
+
+	before, err := s.imagesByID(ctx, imageIDs)
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.RemoveImageTags(ctx, imageIDs, normalized, auth.ActorFromContext(ctx)); err != nil {
+		return err
+	}
+
+	for _, img := range before {
+		after := img
+		after.Tags = slices.DeleteFunc(slices.Clone(img.Tags), func(tag string) bool {
+			return slices.Contains(normalized, tag)
+		})
+		s.audit(ctx, entities.AuditUpdate, entities.AuditImage, img.ID, img, after)
+	}
+	return nil
+}
+
+// imagesByID reads several images once each, failing before any write when one of them is missing
+func (s *server) imagesByID(ctx context.Context, ids []string) ([]entities.Image, error) {
+	unique := slices.Clone(ids)
+	slices.Sort(unique)
+	unique = slices.Compact(unique)
+
+	images, err := s.db.GetImagesByIDs(ctx, unique)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(images) != len(unique) {
+		for _, id := range unique {
+			if !slices.ContainsFunc(images, func(img entities.Image) bool { return img.ID == id }) {
+				return nil, fmt.Errorf("%w: image with id %s not found", customerrors.ErrNotFound, id)
+			}
+		}
+	}
+	return images, nil
+}
+
+// validateBulkTags checks a bulk tag request and returns its normalized tags
+func validateBulkTags(imageIDs []string, tags []string) ([]string, error) {
+	if len(imageIDs) == 0 {
+		return nil, fmt.Errorf("%w: at least one image id is required", customerrors.ErrValidation)
+	}
+	if len(imageIDs) > maxBulkTagImages {
+		return nil, fmt.Errorf("%w: at most %d images can be tagged at once", customerrors.ErrValidation, maxBulkTagImages)
+	}
+	if slices.Contains(imageIDs, "") {
+		return nil, fmt.Errorf("%w: image ids must not be empty", customerrors.ErrValidation)
+	}
+
+	normalized := normalizeTags(tags)
+	if len(normalized) == 0 {
+		return nil, fmt.Errorf("%w: at least one tag is required", customerrors.ErrValidation)
+	}
+	return normalized, nil
+}
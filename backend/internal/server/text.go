@@ -2,9 +2,12 @@ package server
 
 import (
 	"context"
+	"errors"
 	"time"
 
 	"backend/internal/entities"
+	"backend/internal/platform/auth"
+	customerrors "backend/internal/platform/errors"
 )
 
 // =======================
@@ -41,19 +44,53 @@ func (s *server) CreateText(ctx context.Context, text entities.Text) (entities.T
 	now := time.Now()
 	text.CreatedAt = now
 	text.UpdatedAt = now
+	text.CreatedBy = auth.ActorFromContext(ctx)
+	text.LastUpdatedBy = text.CreatedBy
 
 	// Delegate to port
-	return s.db.CreateText(ctx, text)
+	created, err := s.db.CreateText(ctx, text)
+	if err != nil {
+		return entities.Text{}, err
+	}
+
+	s.audit(ctx, entities.AuditCreate, entities.AuditText, created.ID, nil, created)
+	return created, nil
 }
 
 func (s *server) UpdateText(ctx context.Context, id string, text entities.Text) (entities.Text, error) {
+	before, err := s.db.GetTextByID(ctx, id)
+	if err != nil {
+		return entities.Text{}, err
+	}
+
 	// Set audit fields
 	text.UpdatedAt = time.Now()
+	text.LastUpdatedBy = auth.ActorFromContext(ctx)
 
 	// Delegate to port
-	return s.db.UpdateText(ctx, id, text)
+	updated, err := s.db.UpdateText(ctx, id, text)
+	if err != nil {
+		return entities.Text{}, err
+	}
+
+	s.audit(ctx, entities.AuditUpdate, entities.AuditText, id, before, updated)
+	return updated, nil
 }
 
+// DeleteText deletes a text, deleting a missing text does nothing
 func (s *server) DeleteText(ctx context.Context, id string) error {
-	return s.db.DeleteText(ctx, id)
+	before, err := s.db.GetTextByID(ctx, id)
+	if errors.Is(err, customerrors.ErrNotFound) {
+		return s.db.DeleteText(ctx, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	if err := s.db.DeleteText(ctx, id); err != nil {
+		return err
+	}
+
+	s.audit(ctx, entities.AuditDelete, entities.AuditText, id, before, nil)
+	return nil
 }
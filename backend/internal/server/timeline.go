@@ -2,39 +2,313 @@ package server
 
 import (
 	"context"
+	"errors"
+	"fmt"
+	"net/url"
+	"strconv"
 	"time"
 
 	"backend/internal/entities"
+	"backend/internal/platform/auth"
+	customerrors "backend/internal/platform/errors"
 )
 
 // =======================
 // TIMELINE OPERATIONS
 // =======================
 
-func (s *server) GetTimelineEntryByID(ctx context.Context, id string) (entities.TimelineEntry, error) {
-	return s.db.GetTimelineEntryByID(ctx, id)
+const (
+	// maxTimelineImages bounds the photos of a timeline entry
+	maxTimelineImages = 20
+
+	// maxTimelineLinks bounds the external links of a timeline entry
+	maxTimelineLinks = 10
+)
+
+// GetTimelineEntryByID returns a timeline entry, drafts are hidden from readers who can't edit the timeline
+// With expandImages the images of the entry are filled, leaving out the ones the reader can't see
+func (s *server) GetTimelineEntryByID(ctx context.Context, id string, expandImages bool) (entities.TimelineEntry, error) {
+	entry, err := s.db.GetTimelineEntryByID(ctx, id)
+	if err != nil {
+		return entities.TimelineEntry{}, err
+	}
+
+	if entry.Draft && !auth.CanFromContext(ctx, auth.PermissionEditTimeline) {
+		return entities.TimelineEntry{}, fmt.Errorf("%w: timeline entry with id %s not found", customerrors.ErrNotFound, id)
+	}
+
+	if !expandImages {
+		return entry, nil
+	}
+	expanded, err := s.expandTimelineImages(ctx, []entities.TimelineEntry{entry})
+	if err != nil {
+		return entities.TimelineEntry{}, err
+	}
+	return expanded[0], nil
 }
 
-func (s *server) ListTimelineEntries(ctx context.Context) ([]entities.TimelineEntry, error) {
-	return s.db.ListTimelineEntries(ctx)
+// ListTimelineEntries lists the timeline entries matching query by date, drafts are only listed to readers who can edit the timeline
+func (s *server) ListTimelineEntries(ctx context.Context, query entities.TimelineQuery) ([]entities.TimelineEntry, error) {
+	if err := validateTimelineQuery(query); err != nil {
+		return nil, err
+	}
+
+	entries, err := s.db.ListTimelineEntries(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	editor := auth.CanFromContext(ctx, auth.PermissionEditTimeline)
+	matching := make([]entities.TimelineEntry, 0, len(entries))
+	for _, entry := range entries {
+		if (entry.Draft && !editor) || !query.Matches(entry) {
+			continue
+		}
+		matching = append(matching, entry)
+	}
+
+	if query.ExpandImages {
+		return s.expandTimelineImages(ctx, matching)
+	}
+	return matching, nil
+}
+
+// GroupTimelineEntries lists the timeline entries matching query in buckets of a year or a decade
+// Buckets are ordered by date and only buckets holding entries are returned
+func (s *server) GroupTimelineEntries(ctx context.Context, query entities.TimelineQuery, by entities.TimelineGrouping) ([]entities.TimelineGroup, error) {
+	if by != entities.TimelineByYear && by != entities.TimelineByDecade {
+		return nil, fmt.Errorf("%w: timeline entries can be grouped by year or decade, not %q", customerrors.ErrValidation, by)
+	}
+
+	entries, err := s.ListTimelineEntries(ctx, query)
+	if err != nil {
+		return nil, err
+	}
+	return groupTimelineEntries(entries, by), nil
 }
 
 func (s *server) CreateTimelineEntry(ctx context.Context, entry entities.TimelineEntry) (entities.TimelineEntry, error) {
+	if err := s.validateTimelineEntry(ctx, entry); err != nil {
+		return entities.TimelineEntry{}, err
+	}
+	if entry.Importance == 0 {
+		entry.Importance = entities.TimelineImportanceNormal
+	}
+
 	// Set audit fields
 	now := time.Now()
 	entry.CreatedAt = now
 	entry.UpdatedAt = now
+	entry.CreatedBy = auth.ActorFromContext(ctx)
+	entry.LastUpdatedBy = entry.CreatedBy
+
+	created, err := s.db.CreateTimelineEntry(ctx, entry)
+	if err != nil {
+		return entities.TimelineEntry{}, err
+	}
 
-	return s.db.CreateTimelineEntry(ctx, entry)
+	s.audit(ctx, entities.AuditCreate, entities.AuditTimelineEntry, created.ID, nil, created)
+	return created, nil
 }
 
 func (s *server) UpdateTimelineEntry(ctx context.Context, id string, entry entities.TimelineEntry) (entities.TimelineEntry, error) {
+	if err := s.validateTimelineEntry(ctx, entry); err != nil {
+		return entities.TimelineEntry{}, err
+	}
+
+	before, err := s.db.GetTimelineEntryByID(ctx, id)
+	if err != nil {
+		return entities.TimelineEntry{}, err
+	}
+
 	// Set audit fields
 	entry.UpdatedAt = time.Now()
+	entry.LastUpdatedBy = auth.ActorFromContext(ctx)
+
+	updated, err := s.db.UpdateTimelineEntry(ctx, id, entry)
+	if err != nil {
+		return entities.TimelineEntry{}, err
+	}
 
-	return s.db.UpdateTimelineEntry(ctx, id, entry)
+	s.audit(ctx, entities.AuditUpdate, entities.AuditTimelineEntry, id, before, updated)
+	return updated, nil
 }
 
+// SetTimelineEntryDraft publishes a draft entry or turns an entry back into a draft
+func (s *server) SetTimelineEntryDraft(ctx context.Context, id string, draft bool) (entities.TimelineEntry, error) {
+	before, err := s.db.GetTimelineEntryByID(ctx, id)
+	if err != nil {
+		return entities.TimelineEntry{}, err
+	}
+
+	updated, err := s.db.SetTimelineEntryDraft(ctx, id, draft, auth.ActorFromContext(ctx))
+	if err != nil {
+		return entities.TimelineEntry{}, err
+	}
+
+	s.audit(ctx, entities.AuditUpdate, entities.AuditTimelineEntry, id, before, updated)
+	return updated, nil
+}
+
+// DeleteTimelineEntry deletes a timeline entry, deleting a missing entry does nothing
+// Generated entries leave a tombstone behind so the sync doesn't create them again
 func (s *server) DeleteTimelineEntry(ctx context.Context, id string) error {
-	return s.db.DeleteTimelineEntry(ctx, id)
+	before, err := s.db.GetTimelineEntryByID(ctx, id)
+	if errors.Is(err, customerrors.ErrNotFound) {
+		return s.db.DeleteTimelineEntry(ctx, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	if before.Source != "" {
+		err = s.db.DismissTimelineEntry(ctx, id, entities.TimelineTombstone{
+			Source:    before.Source,
+			DeletedAt: time.Now(),
+			DeletedBy: auth.ActorFromContext(ctx),
+		})
+	} else {
+		err = s.db.DeleteTimelineEntry(ctx, id)
+	}
+	if err != nil {
+		return err
+	}
+
+	s.audit(ctx, entities.AuditDelete, entities.AuditTimelineEntry, id, before, nil)
+	return nil
+}
+
+// publishedTimelineEntries drops the drafts of a list of timeline entries
+func publishedTimelineEntries(entries []entities.TimelineEntry) []entities.TimelineEntry {
+	published := make([]entities.TimelineEntry, 0, len(entries))
+	for _, entry := range entries {
+		if !entry.Draft {
+			published = append(published, entry)
+		}
+	}
+	return published
+}
+
+// validateTimelineQuery rejects queries that can't match any entry
+func validateTimelineQuery(query entities.TimelineQuery) error {
+	if !query.From.IsZero() && !query.To.IsZero() && !query.From.Before(query.To) {
+		return fmt.Errorf("%w: from must be before to", customerrors.ErrValidation)
+	}
+	if query.Category != "" && !query.Category.Valid() {
+		return fmt.Errorf("%w: unknown timeline category %q", customerrors.ErrValidation, query.Category)
+	}
+	return nil
+}
+
+// validateTimelineEntry checks the category, importance, links and images of an entry, all may be left empty
+// Referenced images must exist
+func (s *server) validateTimelineEntry(ctx context.Context, entry entities.TimelineEntry) error {
+	if entry.Category != "" && !entry.Category.Valid() {
+		return fmt.Errorf("%w: unknown timeline category %q", customerrors.ErrValidation, entry.Category)
+	}
+	if entry.Importance != 0 && (entry.Importance < entities.TimelineImportanceLow || entry.Importance > entities.TimelineImportanceHigh) {
+		return fmt.Errorf("%w: importance must be between %d and %d", customerrors.ErrValidation, entities.TimelineImportanceLow, entities.TimelineImportanceHigh)
+	}
+
+	if len(entry.Links) > maxTimelineLinks {
+		return fmt.Errorf("%w: a timeline entry can have up to %d links", customerrors.ErrValidation, maxTimelineLinks)
+	}
+	for _, link := range entry.Links {
+		u, err := url.Parse(link.URL)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+			return fmt.Errorf("%w: link %q is not an http or https URL", customerrors.ErrValidation, link.URL)
+		}
+	}
+
+	if len(entry.ImageIDs) > maxTimelineImages {
+		return fmt.Errorf("%w: a timeline entry can have up to %d images", customerrors.ErrValidation, maxTimelineImages)
+	}
+	if len(entry.ImageIDs) == 0 {
+		return nil
+	}
+
+	seen := make(map[string]bool, len(entry.ImageIDs))
+	for _, id := range entry.ImageIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: image %s is referenced twice", customerrors.ErrValidation, id)
+		}
+		seen[id] = true
+	}
+
+	images, err := s.db.GetImagesByIDs(ctx, entry.ImageIDs)
+	if err != nil {
+		return fmt.Errorf("failed to read timeline images: %w", err)
+	}
+	for _, img := range images {
+		delete(seen, img.ID)
+	}
+	for _, id := range entry.ImageIDs {
+		if seen[id] {
+			return fmt.Errorf("%w: image %s does not exist", customerrors.ErrValidation, id)
+		}
+	}
+	return nil
+}
+
+// expandTimelineImages fills the images of the entries, reading every referenced image in batches
+// References to deleted images and images the reader can't see are left out
+func (s *server) expandTimelineImages(ctx context.Context, entries []entities.TimelineEntry) ([]entities.TimelineEntry, error) {
+	var ids []string
+	seen := make(map[string]bool)
+	for _, entry := range entries {
+		for _, id := range entry.ImageIDs {
+			if !seen[id] {
+				seen[id] = true
+				ids = append(ids, id)
+			}
+		}
+	}
+
+	images, err := s.db.GetImagesByIDs(ctx, ids)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read timeline images: %w", err)
+	}
+	if images, err = s.imagesForReader(ctx, images); err != nil {
+		return nil, err
+	}
+
+	byID := make(map[string]entities.Image, len(images))
+	for _, img := range images {
+		byID[img.ID] = img
+	}
+
+	for i := range entries {
+		entries[i].Images = make([]entities.Image, 0, len(entries[i].ImageIDs))
+		for _, id := range entries[i].ImageIDs {
+			if img, ok := byID[id]; ok {
+				entries[i].Images = append(entries[i].Images, img)
+			}
+		}
+	}
+	return entries, nil
+}
+
+// groupTimelineEntries splits entries ordered by date into buckets of a year or a decade, in UTC
+func groupTimelineEntries(entries []entities.TimelineEntry, by entities.TimelineGrouping) []entities.TimelineGroup {
+	groups := []entities.TimelineGroup{}
+	for _, entry := range entries {
+		year := entry.Date.UTC().Year()
+		key := strconv.Itoa(year)
+		if by == entities.TimelineByDecade {
+			year -= year % 10
+			key = strconv.Itoa(year) + "s"
+		}
+
+		if n := len(groups); n == 0 || groups[n-1].Key != key {
+			groups = append(groups, entities.TimelineGroup{
+				Key:   key,
+				Start: time.Date(year, time.January, 1, 0, 0, 0, 0, time.UTC),
+			})
+		}
+
+		group := &groups[len(groups)-1]
+		group.Entries = append(group.Entries, entry)
+		group.Count++
+	}
+	return groups
 }
@@ -0,0 +1,156 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"backend/internal/entities"
+)
+
+// =======================
+// TIMELINE SYNC
+// =======================
+
+// timelineSourcePrefix prefixes the source of the timeline entries generated from Grupy events
+const timelineSourcePrefix = grupyEventSource + ":"
+
+// SyncTimelineFromEvents creates a draft timeline entry for every finished public Grupy event
+// Entries remember their event in Source, running the sync again only refreshes the entries
+// no editor has touched since it last wrote them, and skips the events whose entry an editor
+// deleted. Nothing is written when dryRun is set
+func (s *server) SyncTimelineFromEvents(ctx context.Context, dryRun bool) (entities.TimelineSyncReport, error) {
+	var report entities.TimelineSyncReport
+
+	events, err := s.listPastEvents(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to list past grupy events: %w", err)
+	}
+
+	entries, err := s.db.ListTimelineEntries(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to list timeline entries: %w", err)
+	}
+
+	bySource := make(map[string]entities.TimelineEntry, len(entries))
+	for _, entry := range entries {
+		if entry.Source != "" {
+			bySource[entry.Source] = entry
+		}
+	}
+
+	tombstones, err := s.db.ListTimelineTombstones(ctx)
+	if err != nil {
+		return report, fmt.Errorf("failed to list timeline tombstones: %w", err)
+	}
+
+	dismissed := make(map[string]bool, len(tombstones))
+	for _, tombstone := range tombstones {
+		dismissed[tombstone.Source] = true
+	}
+
+	for _, event := range events {
+		if err := ctx.Err(); err != nil {
+			return report, err
+		}
+		if event.Privacy != "public" {
+			continue
+		}
+		report.Scanned++
+
+		synced := timelineEntryFromEvent(event)
+		existing, found := bySource[synced.Source]
+
+		switch {
+		case !found && dismissed[synced.Source]:
+			report.Dismissed++
+
+		case !found:
+			report.Created++
+			if dryRun {
+				continue
+			}
+
+			now := time.Now()
+			synced.Draft = true
+			synced.Importance = entities.TimelineImportanceNormal
+			synced.CreatedAt = now
+			synced.UpdatedAt = now
+			synced.SyncedAt = now
+			created, err := s.db.CreateTimelineEntry(ctx, synced)
+			if err != nil {
+				return report, fmt.Errorf("failed to create timeline entry for event %s: %w", event.ID, err)
+			}
+			s.audit(ctx, entities.AuditCreate, entities.AuditTimelineEntry, created.ID, nil, created)
+
+		case existing.Edited():
+			report.Edited++
+
+		case sameTimelineContent(existing, synced):
+			report.Unchanged++
+
+		default:
+			report.Updated++
+			if dryRun {
+				continue
+			}
+
+			now := time.Now()
+			synced.UpdatedAt = now
+			synced.SyncedAt = now
+			updated, err := s.db.UpdateTimelineEntry(ctx, existing.ID, synced)
+			if err != nil {
+				return report, fmt.Errorf("failed to update timeline entry %s: %w", existing.ID, err)
+			}
+			s.audit(ctx, entities.AuditUpdate, entities.AuditTimelineEntry, existing.ID, existing, updated)
+		}
+	}
+
+	return report, nil
+}
+
+// listPastEvents reads every finished Grupy event, page by page
+func (s *server) listPastEvents(ctx context.Context) ([]entities.Event, error) {
+	query := entities.EventQuery{
+		Limit:   eventSourcePageSize,
+		OrderBy: "starts-at",
+		Past:    true,
+		State:   publishedEventState,
+	}
+
+	var events []entities.Event
+	for query.Page = 1; ; query.Page++ {
+		batch, count, err := s.events.GetEvents(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		events = append(events, batch...)
+
+		if len(batch) < query.Limit || len(events) >= count {
+			break
+		}
+	}
+	return events, nil
+}
+
+// timelineEntryFromEvent maps a finished Grupy event to the content of its timeline entry, in plain text
+func timelineEntryFromEvent(event entities.Event) entities.TimelineEntry {
+	return entities.TimelineEntry{
+		Name:     strings.TrimSpace(event.Name),
+		Text:     htmlToText(event.Description),
+		Location: strings.TrimSpace(event.LocationName),
+		Date:     event.StartsAt,
+		Category: entities.TimelineMeetup,
+		Source:   timelineSourcePrefix + event.ID,
+	}
+}
+
+// sameTimelineContent reports whether an entry already holds the content synced from its event
+func sameTimelineContent(entry, synced entities.TimelineEntry) bool {
+	return entry.Name == synced.Name &&
+		entry.Text == synced.Text &&
+		entry.Location == synced.Location &&
+		entry.Category == synced.Category &&
+		entry.Date.Equal(synced.Date)
+}
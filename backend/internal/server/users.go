@@ -0,0 +1,54 @@
+package server
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"backend/internal/entities"
+	"backend/internal/platform/auth"
+	customerrors "backend/internal/platform/errors"
+)
+
+// =======================
+// USER OPERATIONS
+// =======================
+
+// errNoUserDirectory is returned by the user operations of a server created without WithUserDirectory
+var errNoUserDirectory = errors.New("user accounts can't be managed without a user directory")
+
+// GetUser returns an account and its role
+func (s *server) GetUser(ctx context.Context, uid string) (entities.User, error) {
+	if s.users == nil {
+		return entities.User{}, errNoUserDirectory
+	}
+	return s.users.GetUser(ctx, uid)
+}
+
+// SetUserRole assigns a role to an account, users get it when their token is next refreshed
+func (s *server) SetUserRole(ctx context.Context, uid string, role string) (entities.User, error) {
+	if s.users == nil {
+		return entities.User{}, errNoUserDirectory
+	}
+	if _, err := auth.ParseRole(role); err != nil {
+		return entities.User{}, fmt.Errorf("%w: %w", customerrors.ErrValidation, err)
+	}
+
+	before, err := s.users.GetUser(ctx, uid)
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	updated, err := s.users.SetUserRole(ctx, uid, role)
+	if err != nil {
+		return entities.User{}, err
+	}
+
+	s.audit(ctx, entities.AuditUpdate, entities.AuditUser, uid, auditedUser{Role: before.Role}, auditedUser{Role: updated.Role})
+	return updated, nil
+}
+
+// auditedUser holds the fields of an account recorded by the audit log, only its role is managed here
+type auditedUser struct {
+	Role string `firestore:"role"`
+}
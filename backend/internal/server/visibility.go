@@ -0,0 +1,178 @@
+package server
+
+import (
+	"context"
+	"fmt"
+
+	"backend/internal/entities"
+	"backend/internal/platform/auth"
+	customerrors "backend/internal/platform/errors"
+)
+
+// =======================
+// MEDIA VISIBILITY
+// =======================
+
+// SetImageVisibility makes an image private or public, updating both the object ACL and its metadata
+func (s *server) SetImageVisibility(ctx context.Context, id string, private bool) (entities.Image, error) {
+	updated, err := s.setImagePrivate(ctx, id, private)
+	if err != nil {
+		return entities.Image{}, err
+	}
+
+	return s.withSignedURL(ctx, updated)
+}
+
+// SetGaleryEventVisibility makes a galery event private or public along with the images of its items
+func (s *server) SetGaleryEventVisibility(ctx context.Context, id string, private bool) (entities.GaleryEvent, error) {
+	event, err := s.db.GetGaleryEventByID(ctx, id)
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	// The images go first, a failure leaves the event as it was and the request can be retried
+	for _, item := range event.Items {
+		if item.ImageID == "" {
+			if err := s.obj.SetObjectPrivate(ctx, s.obj.KeyFromURL(item.ImageURL), private); err != nil {
+				return entities.GaleryEvent{}, fmt.Errorf("failed to change object visibility: %w", err)
+			}
+			continue
+		}
+
+		if _, err := s.setImagePrivate(ctx, item.ImageID, private); err != nil {
+			return entities.GaleryEvent{}, fmt.Errorf("failed to change visibility of image %s: %w", item.ImageID, err)
+		}
+	}
+
+	updated, err := s.db.SetGaleryEventPrivate(ctx, id, private, auth.ActorFromContext(ctx))
+	if err != nil {
+		return entities.GaleryEvent{}, err
+	}
+
+	s.audit(ctx, entities.AuditUpdate, entities.AuditGaleryEvent, id, event, updated)
+	return s.withSignedImageURLs(ctx, updated)
+}
+
+// setImagePrivate updates the object ACL and the metadata of an image and audits the change
+func (s *server) setImagePrivate(ctx context.Context, id string, private bool) (entities.Image, error) {
+	img, err := s.db.GetImageByID(ctx, id)
+	if err != nil {
+		return entities.Image{}, err
+	}
+
+	if img.ObjectURL != "" {
+		if err := s.obj.SetObjectPrivate(ctx, s.obj.KeyFromURL(img.ObjectURL), private); err != nil {
+			return entities.Image{}, fmt.Errorf("failed to change object visibility: %w", err)
+		}
+	}
+
+	updated, err := s.db.SetImagePrivate(ctx, id, private, auth.ActorFromContext(ctx))
+	if err != nil {
+		return entities.Image{}, err
+	}
+
+	s.audit(ctx, entities.AuditUpdate, entities.AuditImage, id, img, updated)
+	return updated, nil
+}
+
+// imageForReader prepares an image for the reader of the request in ctx
+// Private images are hidden from anonymous readers, authenticated readers get a freshly signed URL
+func (s *server) imageForReader(ctx context.Context, img entities.Image) (entities.Image, error) {
+	if !img.Private {
+		return img, nil
+	}
+
+	if !auth.IsAuthenticated(ctx) {
+		return entities.Image{}, fmt.Errorf("%w: image with id %s not found", customerrors.ErrNotFound, img.ID)
+	}
+
+	return s.withSignedURL(ctx, img)
+}
+
+// withSignedURL fills the signed URL of a private image, public images are returned untouched
+func (s *server) withSignedURL(ctx context.Context, img entities.Image) (entities.Image, error) {
+	if !img.Private || img.ObjectURL == "" {
+		return img, nil
+	}
+
+	signedURL, err := s.obj.SignedURL(ctx, s.obj.KeyFromURL(img.ObjectURL))
+	if err != nil {
+		return entities.Image{}, fmt.Errorf("failed to sign image URL: %w", err)
+	}
+
+	img.SignedURL = signedURL
+	return img, nil
+}
+
+// imagesForReader prepares a list of images for the reader of the request in ctx, dropping the ones they can't see
+func (s *server) imagesForReader(ctx context.Context, images []entities.Image) ([]entities.Image, error) {
+	authenticated := auth.IsAuthenticated(ctx)
+
+	visible := make([]entities.Image, 0, len(images))
+	for _, img := range images {
+		if img.Private && !authenticated {
+			continue
+		}
+
+		prepared, err := s.imageForReader(ctx, img)
+		if err != nil {
+			return nil, err
+		}
+		visible = append(visible, prepared)
+	}
+
+	return visible, nil
+}
+
+// galeryEventForReader prepares a galery event for the reader of the request in ctx
+// Private events are hidden from anonymous readers, authenticated readers get freshly signed image URLs
+func (s *server) galeryEventForReader(ctx context.Context, event entities.GaleryEvent) (entities.GaleryEvent, error) {
+	if !event.Private {
+		return event, nil
+	}
+
+	if !auth.IsAuthenticated(ctx) {
+		return entities.GaleryEvent{}, fmt.Errorf("%w: galery event with id %s not found", customerrors.ErrNotFound, event.ID)
+	}
+
+	return s.withSignedImageURLs(ctx, event)
+}
+
+// withSignedImageURLs fills the signed image URLs of a private galery event, public events are returned untouched
+func (s *server) withSignedImageURLs(ctx context.Context, event entities.GaleryEvent) (entities.GaleryEvent, error) {
+	if !event.Private {
+		return event, nil
+	}
+
+	signedURLs := make([]string, len(event.Items))
+	for i, item := range event.Items {
+		signedURL, err := s.obj.SignedURL(ctx, s.obj.KeyFromURL(item.ImageURL))
+		if err != nil {
+			return entities.GaleryEvent{}, fmt.Errorf("failed to sign image URL %d: %w", i, err)
+		}
+		signedURLs[i] = signedURL
+	}
+
+	event.SignedImageURLs = signedURLs
+	return event, nil
+}
+
+// galeryEventsForReader prepares a list of galery events for the reader of the request in ctx, dropping the ones they can't see
+func (s *server) galeryEventsForReader(ctx context.Context, events []entities.GaleryEvent) ([]entities.GaleryEvent, error) {
+	authenticated := auth.IsAuthenticated(ctx)
+
+	visible := make([]entities.GaleryEvent, 0, len(events))
+	for _, event := range events {
+		if event.Private && !authenticated {
+			continue
+		}
+
+		prepared, err := s.galeryEventForReader(ctx, event)
+		if err != nil {
+			return nil, err
+		}
+		visible = append(visible, prepared)
+	}
+
+	return visible, nil
+}
@@ -87,6 +87,8 @@ export interface Image {
   location?: string;
   created_at: string;
   updated_at: string;
+  created_by?: string; // Email or uid of the editor, absent on content predating authorship
+  last_updated_by?: string;
 }
 
 export interface CreateImageRequest {
@@ -172,6 +174,8 @@ export interface GaleryEvent {
   image_ids: string[];
   created_at: string;
   updated_at: string;
+  created_by?: string;
+  last_updated_by?: string;
 }
 
 export interface CreateGaleryEventRequest {
@@ -237,14 +241,30 @@ export async function deleteGaleryEvent(id: string): Promise<void> {
 // TIMELINE API
 // ==================
 
+export type TimelineCategory = 'meetup' | 'conference' | 'milestone' | 'award';
+
+export interface TimelineLink {
+  title: string;
+  url: string;
+}
+
 export interface TimelineEntry {
   id: string;
   name: string;
   text: string;
   location: string;
   date: string; // ISO 8601 date string
+  category?: TimelineCategory;
+  importance: number; // 1 (low) to 3 (high)
+  image_ids?: string[];
+  links?: TimelineLink[];
+  images?: Image[]; // Only with expand: 'images'
+  draft: boolean; // Drafts are only listed to editors
+  source?: string; // e.g. "grupy:123" for entries generated from past events
   created_at: string;
   updated_at: string;
+  created_by?: string;
+  last_updated_by?: string;
 }
 
 export interface CreateTimelineEntryRequest {
@@ -252,6 +272,10 @@ export interface CreateTimelineEntryRequest {
   text: string;
   location: string;
   date: string; // ISO 8601 date string
+  category?: TimelineCategory;
+  importance?: number; // Defaults to 2
+  image_ids?: string[];
+  links?: TimelineLink[];
 }
 
 export interface UpdateTimelineEntryRequest {
@@ -259,20 +283,63 @@ export interface UpdateTimelineEntryRequest {
   text?: string;
   location?: string;
   date?: string; // ISO 8601 date string
+  category?: TimelineCategory;
+  importance?: number;
+  image_ids?: string[]; // Replaces the images, [] removes them all
+  links?: TimelineLink[]; // Replaces the links, [] removes them all
+}
+
+export interface TimelineQuery {
+  from?: string; // RFC 3339 or YYYY-MM-DD
+  to?: string; // RFC 3339 or YYYY-MM-DD, a date covers the whole day
+  category?: TimelineCategory;
+  expand?: 'images';
+}
+
+export interface TimelineGroup {
+  key: string; // e.g. "2024" or "2020s"
+  start: string;
+  count: number;
+  entries: TimelineEntry[];
+}
+
+export interface TimelineGroups {
+  by: 'year' | 'decade';
+  count: number;
+  groups: TimelineGroup[];
 }
 
 /**
  * Get timeline entry by ID
  */
-export async function getTimelineEntryById(id: string): Promise<TimelineEntry> {
-  return apiFetch<TimelineEntry>(`/timelineentries/${id}`);
+export async function getTimelineEntryById(id: string, expand?: 'images'): Promise<TimelineEntry> {
+  return apiFetch<TimelineEntry>(`/timelineentries/${id}${expand ? `?expand=${expand}` : ''}`);
 }
 
 /**
- * List all timeline entries
+ * List timeline entries, optionally filtered by date range and category
  */
-export async function listTimelineEntries(): Promise<TimelineEntry[]> {
-  return apiFetch<TimelineEntry[]>('/timelineentries');
+export async function listTimelineEntries(query: TimelineQuery = {}): Promise<TimelineEntry[]> {
+  const qs = timelineParams(query).toString();
+  return apiFetch<TimelineEntry[]>(`/timelineentries${qs ? `?${qs}` : ''}`);
+}
+
+/**
+ * List timeline entries grouped by year or decade
+ */
+export async function groupTimelineEntries(by: 'year' | 'decade' = 'year', query: TimelineQuery = {}): Promise<TimelineGroups> {
+  const params = timelineParams(query);
+  params.set('by', by);
+  return apiFetch<TimelineGroups>(`/timelineentries/grouped?${params.toString()}`);
+}
+
+function timelineParams(query: TimelineQuery): URLSearchParams {
+  const params = new URLSearchParams();
+  if (query.from) params.set('from', query.from);
+  if (query.to) params.set('to', query.to);
+  if (query.category) params.set('category', query.category);
+  if (query.expand) params.set('expand', query.expand);
+  return params;
 }
 
 /**
@@ -295,6 +362,16 @@ export async function updateTimelineEntry(id: string, request: UpdateTimelineEnt
   });
 }
 
+/**
+ * Publish a draft timeline entry, or turn an entry back into a draft
+ */
+export async function setTimelineEntryDraft(id: string, draft: boolean): Promise<TimelineEntry> {
+  return apiFetch<TimelineEntry>(`/timelineentries/${id}/draft`, {
+    method: 'PUT',
+    body: JSON.stringify({ draft }),
+  });
+}
+
 /**
  * Delete timeline entry
  */
@@ -316,6 +393,8 @@ export interface Text {
   page_id?: string;
   created_at: string;
   updated_at: string;
+  created_by?: string;
+  last_updated_by?: string;
 }
 
 export interface CreateTextRequest {
@@ -388,12 +467,140 @@ export async function deleteText(id: string): Promise<void> {
   });
 }
 
+// ==================
+// IMPORT API
+// ==================
+
+export interface ImportError {
+  line: number;
+  field?: string;
+  message: string;
+}
+
+export interface ImportReport {
+  kind: 'timeline' | 'texts';
+  dry_run: boolean;
+  records: number;
+  created: number;
+  updated: number;
+  errors: ImportError[];
+}
+
+/**
+ * Bulk import timeline entries or texts from a CSV, YAML or Markdown file
+ * - invalid files resolve with the errors of the report, nothing is written then
+ */
+export async function importFile(
+  kind: 'timeline' | 'texts',
+  file: File,
+  dryRun = false
+): Promise<ImportReport> {
+  const format = file.name.split('.').pop() ?? '';
+  const params = new URLSearchParams({ kind, format, dry_run: String(dryRun) });
+  const token = await getAuthToken();
+
+  const response = await fetch(`${API_URL}/import?${params}`, {
+    method: 'POST',
+    body: file,
+    headers: {
+      ...(token && { Authorization: `Bearer ${token}` }),
+    },
+  });
+
+  if (!response.ok && response.status !== 422) {
+    const errorText = await response.text();
+    throw new Error(`API Error (${response.status}): ${errorText}`);
+  }
+
+  return response.json();
+}
+
+// ==================
+// USERS API
+// ==================
+
+export type Role = 'admin' | 'editor' | 'photographer' | 'viewer';
+
+export interface User {
+  uid: string;
+  email?: string;
+  display_name?: string;
+  role: Role;
+}
+
+/**
+ * Get a user account and its role (admins only)
+ */
+export async function getUser(uid: string): Promise<User> {
+  return apiFetch<User>(`/users/${uid}`);
+}
+
+/**
+ * Assign a role to a user (admins only), it applies once the user refreshes their token
+ */
+export async function setUserRole(uid: string, role: Role): Promise<User> {
+  return apiFetch<User>(`/users/${uid}/role`, {
+    method: 'PUT',
+    body: JSON.stringify({ role }),
+  });
+}
+
+// ==================
+// AUDIT API
+// ==================
+
+export type AuditAction = 'create' | 'update' | 'delete';
+export type AuditEntityType = 'text' | 'image' | 'timeline_entry' | 'galery_event' | 'user';
+
+export interface AuditRecord {
+  id: string;
+  actor?: string; // Email or uid of the editor, absent for system writes
+  action: AuditAction;
+  entity_type: AuditEntityType;
+  entity_id: string;
+  changes: Record<string, { before: unknown; after: unknown }>; // Keyed by the stored field name
+  request_id?: string;
+  occurred_at: string;
+}
+
+export interface AuditQuery {
+  actor?: string;
+  action?: AuditAction;
+  entity_type?: AuditEntityType;
+  entity_id?: string;
+  from?: string; // RFC 3339 timestamp or YYYY-MM-DD date
+  to?: string;
+  limit?: number; // Default 50, at most 200
+  page?: number;
+}
+
+export interface AuditPage {
+  data: AuditRecord[];
+  meta: {
+    page: number;
+    limit: number;
+    has_more: boolean;
+  };
+}
+
+/**
+ * List the audit log of content mutations, newest first (admins only)
+ */
+export async function listAuditRecords(query: AuditQuery = {}): Promise<AuditPage> {
+  const params = new URLSearchParams();
+  for (const [key, value] of Object.entries(query)) {
+    if (value !== undefined && value !== '') params.set(key, String(value));
+  }
+  return apiFetch<AuditPage>(`/audit?${params.toString()}`);
+}
+
 // ==================
 // EVENTS API (External)
 // ==================
 
 export interface ExternalEvent {
   id: string;
+  source: string;
   name: string;
   description?: string;
   starts_at: string;
@@ -404,23 +611,55 @@ export interface ExternalEvent {
   link?: string;
 }
 
+export interface ExternalEventList {
+  data: ExternalEvent[];
+  meta: {
+    count: number;
+    failed_sources?: string[];
+  };
+}
+
 /**
- * Get events with optional query parameters
+ * Get a page of events with optional query parameters and filters
  */
-export async function getExternalEvents(params?: {
+export async function getExternalEventList(params?: {
   limit?: number;
+  page?: number;
   orderBy?: string;
   desc?: boolean;
-}): Promise<ExternalEvent[]> {
+  from?: string;
+  to?: string;
+  upcoming?: boolean;
+  past?: boolean;
+  q?: string;
+  state?: string;
+}): Promise<ExternalEventList> {
   const queryParams = new URLSearchParams();
   if (params?.limit) queryParams.append('limit', params.limit.toString());
+  if (params?.page) queryParams.append('page', params.page.toString());
   if (params?.orderBy) queryParams.append('orderBy', params.orderBy);
   if (params?.desc !== undefined) queryParams.append('desc', params.desc.toString());
+  if (params?.from) queryParams.append('from', params.from);
+  if (params?.to) queryParams.append('to', params.to);
+  if (params?.upcoming) queryParams.append('upcoming', 'true');
+  if (params?.past) queryParams.append('past', 'true');
+  if (params?.q) queryParams.append('q', params.q);
+  if (params?.state) queryParams.append('state', params.state);
 
   const query = queryParams.toString();
   const endpoint = `/events${query ? `?${query}` : ''}`;
 
-  return apiFetch<ExternalEvent[]>(endpoint);
+  return apiFetch<ExternalEventList>(endpoint);
+}
+
+/**
+ * Get events with optional query parameters
+ */
+export async function getExternalEvents(
+  params?: Parameters<typeof getExternalEventList>[0],
+): Promise<ExternalEvent[]> {
+  const list = await getExternalEventList(params);
+  return list.data ?? [];
 }
 
 export async function getLatestExternalEvent(): Promise<ExternalEvent | null> {